@@ -9,6 +9,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/proto/conv"
 	pb "github.com/adammck/ranger/pkg/proto/gen"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -21,8 +23,10 @@ func main() {
 	dryRun := flag.Bool("dry-run", false, "just print operations")
 	force := flag.Bool("force", false, "actually run operations")
 	num := flag.Uint("num", 10, "maximum number of operations to perform")
-	splitScore := flag.Uint64("split", 100, "score threshold to split ranges")
-	joinScore := flag.Uint64("join", 20, "score threshold to join adjacent ranges")
+	splitScore := flag.Float64("split", 100, "score threshold to split ranges")
+	joinScore := flag.Float64("join", 20, "score threshold to join adjacent ranges")
+	metric := flag.String("metric", api.MetricKeys, "load metric to score ranges by: keys, bytes, qps, cpu, memory, or a custom metric")
+	keys := flag.String("keys", "raw", "format keys as raw, hex, base64, uint64, or tuple:<type>,...")
 	flag.Parse()
 
 	if !*dryRun && !*force {
@@ -30,6 +34,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	keyCodec, err := api.KeyCodecByName(*keys)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// TODO: Catch signals for cancellation.
 	ctx := context.Background()
 
@@ -64,7 +74,7 @@ func main() {
 
 	type Placement struct {
 		index int
-		info  *pb.LoadInfo
+		info  api.LoadInfo
 	}
 
 	type Range struct {
@@ -86,7 +96,7 @@ func main() {
 			if p.Placement.State == pb.PlacementState_PS_ACTIVE {
 				placements = append(placements, Placement{
 					index: i,
-					info:  p.RangeInfo.Info,
+					info:  conv.LoadInfoFromProto(p.RangeInfo.Info),
 				})
 			}
 		}
@@ -106,23 +116,23 @@ func main() {
 
 	type RangeWithScore struct {
 		rID   uint64
-		score uint64
+		score float64
 		split string
 	}
 
 	scores := make([]RangeWithScore, len(ranges))
 
 	for i, r := range ranges {
-		var maxScore uint64
+		var maxScore float64
 		var maxSplit string
 
 		for _, p := range r.placements {
-			s := p.info.Keys
+			s := p.info.Metric(*metric)
 			if s > maxScore {
 				maxScore = s
 
 				if len(p.info.Splits) > 0 {
-					maxSplit = p.info.Splits[0]
+					maxSplit = string(p.info.Splits[0])
 				}
 			}
 		}
@@ -160,7 +170,7 @@ func main() {
 
 	for _, s := range splits {
 		if *dryRun {
-			fmt.Printf("Would split: range %d (score: %d) at %q\n", scores[s].rID, scores[s].score, scores[s].split)
+			fmt.Printf("Would split: range %d (score: %v) at %q\n", scores[s].rID, scores[s].score, api.FormatKey(keyCodec, api.Key(scores[s].split)))
 			continue
 		}
 		if ops >= *num {
@@ -178,8 +188,11 @@ func main() {
 				Boundary: []byte(scores[s].split),
 			}
 
-			fmt.Printf("Splitting: range %d (score: %d)\n", scores[s].rID, scores[s].score)
-			_, err := orchClient.Split(ctxSp, req)
+			fmt.Printf("Splitting: range %d (score: %v)\n", scores[s].rID, scores[s].score)
+			res, err := orchClient.Split(ctxSp, req)
+			if err == nil {
+				err = waitOperation(ctxSp, orchClient, res.Operation)
+			}
 
 			if err != nil {
 				fmt.Printf("Error splitting range %d: %v\n", scores[s].rID, err)
@@ -216,7 +229,7 @@ func main() {
 		right := scores[j+1].rID
 		score := scores[j].score + scores[j].score
 		if *dryRun {
-			fmt.Printf("Would join: range %d, range %d (combined score: %d)\n", left, right, score)
+			fmt.Printf("Would join: range %d, range %d (combined score: %v)\n", left, right, score)
 			continue
 		}
 		if ops >= *num {
@@ -233,8 +246,11 @@ func main() {
 				RangeRight: right,
 			}
 
-			fmt.Printf("Joining: range %d, range %d (combined score: %d)\n", left, right, score)
-			_, err := orchClient.Join(ctxJo, req)
+			fmt.Printf("Joining: range %d, range %d (combined score: %v)\n", left, right, score)
+			res, err := orchClient.Join(ctxJo, req)
+			if err == nil {
+				err = waitOperation(ctxJo, orchClient, res.Operation)
+			}
 
 			if err != nil {
 				fmt.Printf("Error joining ranges %d and %d: %v\n", left, right, err)
@@ -250,3 +266,21 @@ func main() {
 
 	// -- Exit
 }
+
+// waitOperation blocks until the given operation finishes, and returns an error
+// unless it succeeded.
+func waitOperation(ctx context.Context, client pb.OrchestratorClient, opID uint64) error {
+	res, err := client.WaitOperation(ctx, &pb.WaitOperationRequest{
+		Operation: opID,
+	})
+	if err != nil {
+		return err
+	}
+
+	op := res.Operation
+	if op.State != pb.OperationState_OPERATION_STATE_SUCCEEDED {
+		return fmt.Errorf("operation %d finished with state %s: %s", op.Id, op.State, op.Error)
+	}
+
+	return nil
+}
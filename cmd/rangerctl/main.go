@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/adammck/ranger/pkg/api"
 	pb "github.com/adammck/ranger/pkg/proto/gen"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -19,11 +21,27 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// keyCodec formats and parses keys, if the -keys flag is given. Otherwise keys
+// are parsed raw and output like any other bytes field, as base64.
+var keyCodec api.KeyCodec
+
+// namespace is sent with every request, to select which of the controller's
+// keyspaces to operate on. Empty means the default one.
+var namespace string
+
+// async makes move, split, and join return as soon as the operation is queued,
+// rather than waiting for it to finish.
+var async bool
+
+// dryRun makes move, split, and join return the plan of what would happen,
+// rather than doing it.
+var dryRun bool
+
 func main() {
 	w := flag.CommandLine.Output()
 
 	flag.Usage = func() {
-		fmt.Fprintf(w, "Usage: %s [-addr=host:port] <action> [<args>]\n", os.Args[0])
+		fmt.Fprintf(w, "Usage: %s [-addr=host:port] [-namespace=name] <action> [<args>]\n", os.Args[0])
 		fmt.Fprintf(w, "\n")
 		fmt.Fprintf(w, "Action and args must be one of:\n")
 		fmt.Fprintf(w, "  - ranges\n")
@@ -31,8 +49,37 @@ func main() {
 		fmt.Fprintf(w, "  - nodes\n")
 		fmt.Fprintf(w, "  - node <nodeID>\n")
 		fmt.Fprintf(w, "  - move <rangeID> [<nodeID>]\n")
-		fmt.Fprintf(w, "  - split <rangeID> <boundary> [<nodeID>] [<nodeID>]\n")
+		fmt.Fprintf(w, "  - split <rangeID> <boundary>[,<boundary>...] [<nodeID>...]\n")
 		fmt.Fprintf(w, "  - join <rangeID> <rangeID> [<nodeID>]\n")
+		fmt.Fprintf(w, "  - join <rangeID>,<rangeID>[,<rangeID>...] [<nodeID>]\n")
+		fmt.Fprintf(w, "  - abort <rangeID>\n")
+		fmt.Fprintf(w, "  - untaint <rangeID> <nodeID>\n")
+		fmt.Fprintf(w, "  - clear-failures <rangeID> <nodeID> [<action>...]\n")
+		fmt.Fprintf(w, "  - drop-placement <rangeID> <nodeID>\n")
+		fmt.Fprintf(w, "  - cordon <nodeID>\n")
+		fmt.Fprintf(w, "  - drain <nodeID>\n")
+		fmt.Fprintf(w, "  - uncordon <nodeID>\n")
+		fmt.Fprintf(w, "  - ops\n")
+		fmt.Fprintf(w, "  - op <operationID>\n")
+		fmt.Fprintf(w, "  - wait <operationID>\n")
+		fmt.Fprintf(w, "  - cancel <operationID>\n")
+		fmt.Fprintf(w, "  - replication <rangeID> <config>\n")
+		fmt.Fprintf(w, "  - replication-span <start> <end> <config>\n")
+		fmt.Fprintf(w, "  - policy <rangeID> <policy>\n")
+		fmt.Fprintf(w, "  - policy-span <start> <end> <policy>\n")
+		fmt.Fprintf(w, "  - ack <rangeID> [<rangeID>...]\n")
+		fmt.Fprintf(w, "  - lineage <rangeID>\n")
+		fmt.Fprintf(w, "  - range-at <key> [<time>]\n")
+		fmt.Fprintf(w, "  - watch [<revision>]\n")
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "Config must be R1, R3, default, or <target>,<min>,<max>,<minPlacements>,<maxPlacements>.\n")
+		fmt.Fprintf(w, "Any but default may be followed by :<label> to spread placements across its values, e.g. R3:zone.\n")
+		fmt.Fprintf(w, "Policy must be none, or semicolon-separated require=<selector>, prefer=<selector>, avoid=<selector>,\n")
+		fmt.Fprintf(w, "and colocate, e.g. 'require=pool=a;avoid=canary'. Selectors are comma-separated key=value, key!=value, key, or !key.\n")
+		fmt.Fprintf(w, "Action must be Prepare, Activate, Deactivate, or Drop, and defaults to all of them.\n")
+		fmt.Fprintf(w, "Time must be RFC3339, and defaults to now.\n")
+		fmt.Fprintf(w, "Keys and boundaries are parsed by the -keys codec, unless prefixed with b64: (base64),\n")
+		fmt.Fprintf(w, "hex: (hex), or hash: (to hash them like a hash-partitioned keyspace does).\n")
 		fmt.Fprintf(w, "\n")
 		fmt.Fprintf(w, "Flags:\n")
 		flag.PrintDefaults()
@@ -41,12 +88,25 @@ func main() {
 	addr := flag.String("addr", "localhost:5000", "controller address")
 	printReq := flag.Bool("request", false, "print gRPC request instead of sending it")
 	render := flag.Bool("render", false, "render results using graphviz")
+	flag.StringVar(&namespace, "namespace", "", "namespace (keyspace) to operate on")
+	flag.BoolVar(&dryRun, "dry-run", false, "print the plan for move, split, and join without doing anything")
+	flag.BoolVar(&async, "async", false, "return operation ID from move, split, and join (or return from drain) without waiting for it to finish")
+	keys := flag.String("keys", "", "format and parse keys as raw, hex, base64, uint64, or tuple:<type>,... (default: raw, but output as base64)")
 	flag.Parse()
 
 	if flag.NArg() == 0 {
 		flag.Usage()
 		os.Exit(1)
 	}
+	if *keys != "" {
+		var err error
+		keyCodec, err = api.KeyCodecByName(*keys)
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// TODO: Catch signals for cancellation.
 	ctx := context.Background()
 
@@ -131,24 +191,29 @@ func main() {
 		cmdMove(*printReq, client, ctx, rID, flag.Arg(2))
 
 	case "split", "s":
-		if flag.NArg() < 3 || flag.NArg() > 5 {
-			fmt.Fprintf(w, "Usage: %s split <rangeID> <boundary> [<nodeID>] [<nodeID>]\n", os.Args[0])
+		if flag.NArg() < 3 {
+			fmt.Fprintf(w, "Usage: %s split <rangeID> <boundary>[,<boundary>...] [<nodeID>...]\n", os.Args[0])
 			os.Exit(1)
 		}
 
-		boundary := []byte(flag.Arg(2))
-
-		// If the boundary is prefixed with 'b64:' then decode the rest.
-		// Sometimes we want to split at points which are not printable chars.
-		p := []byte("b64:")
-		if bytes.HasPrefix(boundary, p) {
-			b := bytes.TrimPrefix(boundary, p)
-			_, err = base64.StdEncoding.Decode(boundary, b)
-
+		// Several boundaries can be given, separated by commas, to split the
+		// range into more than two parts.
+		boundaries := [][]byte{}
+		for _, s := range strings.Split(flag.Arg(2), ",") {
+			boundary, err := parseKey(s)
 			if err != nil {
-				fmt.Fprintf(w, "Invalid base64-encoded boundary: %v\n", b)
+				fmt.Fprintf(w, "Invalid boundary: %v\n", err)
 				os.Exit(1)
 			}
+
+			boundaries = append(boundaries, boundary)
+		}
+
+		// One node per part, at most.
+		nIDs := flag.Args()[3:]
+		if len(nIDs) > len(boundaries)+1 {
+			fmt.Fprintf(w, "Too many nodeIDs: got %d, want at most %d\n", len(nIDs), len(boundaries)+1)
+			os.Exit(1)
 		}
 
 		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
@@ -158,16 +223,35 @@ func main() {
 		}
 
 		client := pb.NewOrchestratorClient(conn)
-		cmdSplit(*printReq, client, ctx, rID, boundary, flag.Arg(3), flag.Arg(4))
+		cmdSplit(*printReq, client, ctx, rID, boundaries, nIDs)
 
 	case "join", "j":
-		if flag.NArg() < 3 || flag.NArg() > 4 {
+		usage := func() {
 			fmt.Fprintf(w, "Usage: %s join <rangeID> <rangeID> [<nodeID>]\n", os.Args[0])
+			fmt.Fprintf(w, "       %s join <rangeID>,<rangeID>[,<rangeID>...] [<nodeID>]\n", os.Args[0])
 			os.Exit(1)
 		}
 
-		rIDs := [2]uint64{}
-		for i, s := range []string{flag.Arg(1), flag.Arg(2)} {
+		// To join more than two ranges, they're given separated by commas.
+		// Otherwise, the left and right ranges are separate args.
+		var args []string
+		var nID string
+		if flag.NArg() >= 2 && strings.Contains(flag.Arg(1), ",") {
+			if flag.NArg() > 3 {
+				usage()
+			}
+			args = strings.Split(flag.Arg(1), ",")
+			nID = flag.Arg(2)
+		} else {
+			if flag.NArg() < 3 || flag.NArg() > 4 {
+				usage()
+			}
+			args = []string{flag.Arg(1), flag.Arg(2)}
+			nID = flag.Arg(3)
+		}
+
+		rIDs := make([]uint64, len(args))
+		for i, s := range args {
 			rID, err := strconv.ParseUint(s, 10, 64)
 			if err != nil {
 				fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
@@ -177,7 +261,310 @@ func main() {
 		}
 
 		client := pb.NewOrchestratorClient(conn)
-		cmdJoin(*printReq, client, ctx, rIDs[0], rIDs[1], flag.Arg(3))
+		cmdJoin(*printReq, client, ctx, rIDs, nID)
+
+	case "abort":
+		if flag.NArg() != 2 {
+			fmt.Fprintf(w, "Usage: %s abort <rangeID>\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+			os.Exit(1)
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdAbort(*printReq, client, ctx, rID)
+
+	case "untaint", "drop-placement":
+		if flag.NArg() != 3 {
+			fmt.Fprintf(w, "Usage: %s %s <rangeID> <nodeID>\n", os.Args[0], action)
+			os.Exit(1)
+		}
+
+		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+			os.Exit(1)
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		if action == "untaint" {
+			cmdUntaint(*printReq, client, ctx, rID, flag.Arg(2))
+		} else {
+			cmdDropPlacement(*printReq, client, ctx, rID, flag.Arg(2))
+		}
+
+	case "clear-failures":
+		if flag.NArg() < 3 {
+			fmt.Fprintf(w, "Usage: %s clear-failures <rangeID> <nodeID> [<action>...]\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+			os.Exit(1)
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdClearFailures(*printReq, client, ctx, rID, flag.Arg(2), flag.Args()[3:])
+
+	case "cordon", "drain", "uncordon":
+		if flag.NArg() != 2 {
+			fmt.Fprintf(w, "Usage: %s %s <nodeID>\n", os.Args[0], action)
+			os.Exit(1)
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		switch action {
+		case "cordon":
+			cmdCordon(*printReq, client, ctx, flag.Arg(1))
+		case "drain":
+			cmdDrain(*printReq, client, ctx, flag.Arg(1))
+		case "uncordon":
+			cmdUncordon(*printReq, client, ctx, flag.Arg(1))
+		}
+
+	case "ops":
+		if flag.NArg() != 1 {
+			fmt.Fprintf(w, "Usage: %s ops\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdOps(*printReq, client, ctx)
+
+	case "op", "wait", "cancel":
+		if flag.NArg() != 2 {
+			fmt.Fprintf(w, "Usage: %s %s <operationID>\n", os.Args[0], action)
+			os.Exit(1)
+		}
+
+		opID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid operationID: %v\n", err)
+			os.Exit(1)
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		switch action {
+		case "op":
+			cmdOp(*printReq, client, ctx, opID)
+		case "wait":
+			cmdWait(*printReq, client, ctx, opID)
+		case "cancel":
+			cmdCancel(*printReq, client, ctx, opID)
+		}
+
+	case "replication":
+		if flag.NArg() != 3 {
+			fmt.Fprintf(w, "Usage: %s replication <rangeID> <config>\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+			os.Exit(1)
+		}
+
+		rc, err := parseReplicationConfig(flag.Arg(2))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid config: %v\n", err)
+			os.Exit(1)
+		}
+
+		req := &pb.SetReplicationRequest{
+			Namespace: namespace,
+			Range:     rID,
+			Config:    rc,
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdSetReplication(*printReq, client, ctx, req)
+
+	case "replication-span":
+		if flag.NArg() != 4 {
+			fmt.Fprintf(w, "Usage: %s replication-span <start> <end> <config>\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		// Empty start or end keys mean unbounded, like range boundaries.
+		start, err := parseKey(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid start: %v\n", err)
+			os.Exit(1)
+		}
+
+		end, err := parseKey(flag.Arg(2))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid end: %v\n", err)
+			os.Exit(1)
+		}
+
+		rc, err := parseReplicationConfig(flag.Arg(3))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid config: %v\n", err)
+			os.Exit(1)
+		}
+
+		req := &pb.SetReplicationRequest{
+			Namespace: namespace,
+			Start:     start,
+			End:       end,
+			Config:    rc,
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdSetReplication(*printReq, client, ctx, req)
+
+	case "policy":
+		if flag.NArg() != 3 {
+			fmt.Fprintf(w, "Usage: %s policy <rangeID> <policy>\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+			os.Exit(1)
+		}
+
+		pp, err := parsePlacementPolicy(flag.Arg(2))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid policy: %v\n", err)
+			os.Exit(1)
+		}
+
+		req := &pb.SetPlacementPolicyRequest{
+			Namespace: namespace,
+			Range:     rID,
+			Policy:    pp,
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdSetPlacementPolicy(*printReq, client, ctx, req)
+
+	case "policy-span":
+		if flag.NArg() != 4 {
+			fmt.Fprintf(w, "Usage: %s policy-span <start> <end> <policy>\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		start, err := parseKey(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid start: %v\n", err)
+			os.Exit(1)
+		}
+
+		end, err := parseKey(flag.Arg(2))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid end: %v\n", err)
+			os.Exit(1)
+		}
+
+		pp, err := parsePlacementPolicy(flag.Arg(3))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid policy: %v\n", err)
+			os.Exit(1)
+		}
+
+		req := &pb.SetPlacementPolicyRequest{
+			Namespace: namespace,
+			Start:     start,
+			End:       end,
+			Policy:    pp,
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdSetPlacementPolicy(*printReq, client, ctx, req)
+
+	case "ack":
+		if flag.NArg() < 2 {
+			fmt.Fprintf(w, "Usage: %s ack <rangeID> [<rangeID>...]\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		req := &pb.AckObsoleteRequest{
+			Namespace: namespace,
+			Ranges:    make([]uint64, flag.NArg()-1),
+		}
+
+		for i := range req.Ranges {
+			rID, err := strconv.ParseUint(flag.Arg(i+1), 10, 64)
+			if err != nil {
+				fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+				os.Exit(1)
+			}
+			req.Ranges[i] = rID
+		}
+
+		client := pb.NewOrchestratorClient(conn)
+		cmdAckObsolete(*printReq, client, ctx, req)
+
+	case "lineage":
+		if flag.NArg() != 2 {
+			fmt.Fprintf(w, "Usage: %s lineage <rangeID>\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		rID, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid rangeID: %v\n", err)
+			os.Exit(1)
+		}
+
+		client := pb.NewDebugClient(conn)
+		cmdLineage(*printReq, client, ctx, rID)
+
+	case "range-at":
+		if flag.NArg() < 2 || flag.NArg() > 3 {
+			fmt.Fprintf(w, "Usage: %s range-at <key> [<time>]\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		key, err := parseKey(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintf(w, "Invalid key: %v\n", err)
+			os.Exit(1)
+		}
+
+		req := &pb.RangeAtRequest{Key: key, Namespace: namespace}
+
+		if flag.NArg() == 3 {
+			t, err := time.Parse(time.RFC3339Nano, flag.Arg(2))
+			if err != nil {
+				fmt.Fprintf(w, "Invalid time: %v\n", err)
+				os.Exit(1)
+			}
+			req.Time = t.UnixNano()
+		}
+
+		client := pb.NewDebugClient(conn)
+		cmdRangeAt(*printReq, client, ctx, req)
+
+	case "watch":
+		if flag.NArg() > 2 {
+			fmt.Fprintf(w, "Usage: %s watch [<revision>]\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		req := &pb.WatchRequest{Namespace: namespace}
+
+		if flag.NArg() == 2 {
+			rev, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+			if err != nil {
+				fmt.Fprintf(w, "Invalid revision: %v\n", err)
+				os.Exit(1)
+			}
+			req.Revision = rev
+		}
+
+		client := pb.NewDebugClient(conn)
+		cmdWatch(*printReq, client, ctx, req)
 
 	default:
 		flag.Usage()
@@ -191,7 +578,7 @@ func cmdRanges(printReq bool, render bool, client pb.DebugClient, ctx context.Co
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	req := &pb.RangesListRequest{}
+	req := &pb.RangesListRequest{Namespace: namespace}
 
 	if printReq {
 		output(req)
@@ -220,7 +607,7 @@ func cmdRange(printReq bool, client pb.DebugClient, ctx context.Context, rID uin
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	req := &pb.RangeRequest{Range: rID}
+	req := &pb.RangeRequest{Range: rID, Namespace: namespace}
 
 	if printReq {
 		output(req)
@@ -243,7 +630,7 @@ func cmdNodes(printReq bool, client pb.DebugClient, ctx context.Context) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	req := &pb.NodesListRequest{}
+	req := &pb.NodesListRequest{Namespace: namespace}
 
 	if printReq {
 		output(req)
@@ -267,7 +654,8 @@ func cmdNode(printReq bool, client pb.DebugClient, ctx context.Context, nID stri
 	defer cancel()
 
 	req := &pb.NodeRequest{
-		Node: nID,
+		Namespace: namespace,
+		Node:      nID,
 	}
 
 	if printReq {
@@ -292,8 +680,10 @@ func cmdMove(printReq bool, client pb.OrchestratorClient, ctx context.Context, r
 	defer cancel()
 
 	req := &pb.MoveRequest{
-		Range: rID,
-		Node:  nID,
+		Namespace: namespace,
+		Range:     rID,
+		Node:      nID,
+		DryRun:    dryRun,
 	}
 
 	if printReq {
@@ -308,20 +698,39 @@ func cmdMove(printReq bool, client pb.OrchestratorClient, ctx context.Context, r
 		os.Exit(1)
 	}
 
-	output(res)
+	if async || dryRun {
+		output(res)
+		return
+	}
+
+	waitOperation(client, ctx, res.Operation)
 }
 
-func cmdSplit(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID uint64, boundary []byte, nID1, nID2 string) {
+func cmdSplit(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID uint64, boundaries [][]byte, nIDs []string) {
 	w := flag.CommandLine.Output()
 
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req := &pb.SplitRequest{
+		Namespace: namespace,
 		Range:     rID,
-		Boundary:  boundary,
-		NodeLeft:  nID1,
-		NodeRight: nID2,
+		DryRun:    dryRun,
+	}
+
+	// Use the old single-boundary fields when splitting in two, so this still
+	// works with older controllers.
+	if len(boundaries) == 1 {
+		req.Boundary = boundaries[0]
+		if len(nIDs) > 0 {
+			req.NodeLeft = nIDs[0]
+		}
+		if len(nIDs) > 1 {
+			req.NodeRight = nIDs[1]
+		}
+	} else {
+		req.Boundaries = boundaries
+		req.Nodes = nIDs
 	}
 
 	if printReq {
@@ -336,19 +745,33 @@ func cmdSplit(printReq bool, client pb.OrchestratorClient, ctx context.Context,
 		os.Exit(1)
 	}
 
-	output(res)
+	if async || dryRun {
+		output(res)
+		return
+	}
+
+	waitOperation(client, ctx, res.Operation)
 }
 
-func cmdJoin(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID1, rID2 uint64, nID string) {
+func cmdJoin(printReq bool, client pb.OrchestratorClient, ctx context.Context, rIDs []uint64, nID string) {
 	w := flag.CommandLine.Output()
 
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req := &pb.JoinRequest{
-		RangeLeft:  rID1,
-		RangeRight: rID2,
-		Node:       nID,
+		Namespace: namespace,
+		Node:      nID,
+		DryRun:    dryRun,
+	}
+
+	// Use the old left/right fields when joining two ranges, so this still
+	// works with older controllers.
+	if len(rIDs) == 2 {
+		req.RangeLeft = rIDs[0]
+		req.RangeRight = rIDs[1]
+	} else {
+		req.Ranges = rIDs
 	}
 
 	if printReq {
@@ -363,38 +786,701 @@ func cmdJoin(printReq bool, client pb.OrchestratorClient, ctx context.Context, r
 		os.Exit(1)
 	}
 
-	output(res)
-}
-
-func output(res protoreflect.ProtoMessage) {
-	opts := protojson.MarshalOptions{
-		Multiline:       true,
-		UseProtoNames:   true,
-		EmitUnpopulated: true,
+	if async || dryRun {
+		output(res)
+		return
 	}
 
-	fmt.Println(opts.Format(res))
+	waitOperation(client, ctx, res.Operation)
 }
 
-func renderRangesOutput(w io.Writer, res *pb.RangesListResponse) {
-	fmt.Fprint(w, "digraph G {\n")
+func cmdAbort(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID uint64) {
+	w := flag.CommandLine.Output()
 
-	for _, r := range res.Ranges {
-		placements := []string{}
-		for _, p := range r.Placements {
-			placements = append(placements, fmt.Sprintf("%s:%s", p.Placement.Node, p.Placement.State))
-		}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-		// Not an attr because it's an HTML string, so needs angle bracket delimiters.
-		label := fmt.Sprintf("<b>R%d</b><br/>%s<br/>%s<br/>%s", r.Meta.Ident, renderRange(r.Meta.Start, r.Meta.End), r.State, strings.Join(placements, "\\n"))
+	req := &pb.AbortRequest{
+		Namespace: namespace,
+		Range:     rID,
+	}
 
-		attrs := map[string]string{
-			"shape": "rect",
-			"style": "filled",
+	if printReq {
+		output(req)
+		return
+	}
 
-			// Defaults for unknown states; probably changed below.
-			"color":     "black",
-			"fontcolor": "black",
+	res, err := client.Abort(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Abort returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdUntaint(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID uint64, nID string) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.UntaintRequest{
+		Namespace: namespace,
+		Range:     rID,
+		Node:      nID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.Untaint(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Untaint returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdClearFailures(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID uint64, nID string, actions []string) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.ClearFailuresRequest{
+		Namespace: namespace,
+		Range:     rID,
+		Node:      nID,
+		Actions:   actions,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.ClearFailures(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.ClearFailures returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdDropPlacement(printReq bool, client pb.OrchestratorClient, ctx context.Context, rID uint64, nID string) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.DropPlacementRequest{
+		Namespace: namespace,
+		Range:     rID,
+		Node:      nID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.DropPlacement(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.DropPlacement returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdCordon(printReq bool, client pb.OrchestratorClient, ctx context.Context, nID string) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.CordonRequest{
+		Namespace: namespace,
+		Node:      nID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.Cordon(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Cordon returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdUncordon(printReq bool, client pb.OrchestratorClient, ctx context.Context, nID string) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.UncordonRequest{
+		Namespace: namespace,
+		Node:      nID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.Uncordon(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Uncordon returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+// cmdDrain asks the controller to drain the given node, and then (unless the
+// -async flag was given) reports how many placements are left on it until
+// there are none. This can be run again to resume watching a drain.
+func cmdDrain(printReq bool, client pb.OrchestratorClient, ctx context.Context, nID string) {
+	w := flag.CommandLine.Output()
+
+	req := &pb.DrainRequest{
+		Namespace: namespace,
+		Node:      nID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	ctxDr, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	res, err := client.Drain(ctxDr, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Drain returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	if async {
+		output(res)
+		return
+	}
+
+	for {
+		ctxSt, cancel := context.WithTimeout(ctx, 10*time.Second)
+		st, err := client.DrainStatus(ctxSt, &pb.DrainStatusRequest{
+			Namespace: namespace,
+			Node:      nID,
+		})
+		cancel()
+
+		if err != nil {
+			fmt.Fprintf(w, "Debug.DrainStatus returned: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Someone else uncordoned the node while we were waiting.
+		if st.State != pb.AdminState_AS_DRAINING {
+			fmt.Fprintf(w, "Node is no longer draining (state: %s)\n", st.State)
+			os.Exit(1)
+		}
+
+		if st.Placements == 0 {
+			fmt.Printf("Drained: %s\n", nID)
+			return
+		}
+
+		fmt.Printf("Draining: %s (%d placements remaining)\n", nID, st.Placements)
+		time.Sleep(time.Second)
+	}
+}
+
+// waitOperation waits for the given operation to finish and outputs it, then
+// exits nonzero unless it succeeded.
+func waitOperation(client pb.OrchestratorClient, ctx context.Context, opID uint64) {
+	w := flag.CommandLine.Output()
+
+	res, err := client.WaitOperation(ctx, &pb.WaitOperationRequest{
+		Namespace: namespace,
+		Operation: opID,
+	})
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.WaitOperation returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+
+	if res.Operation.State != pb.OperationState_OPERATION_STATE_SUCCEEDED {
+		os.Exit(1)
+	}
+}
+
+func cmdOps(printReq bool, client pb.OrchestratorClient, ctx context.Context) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.ListOperationsRequest{
+		Namespace: namespace,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.ListOperations(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.ListOperations returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdOp(printReq bool, client pb.OrchestratorClient, ctx context.Context, opID uint64) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.GetOperationRequest{
+		Namespace: namespace,
+		Operation: opID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.GetOperation(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.GetOperation returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdWait(printReq bool, client pb.OrchestratorClient, ctx context.Context, opID uint64) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if printReq {
+		output(&pb.WaitOperationRequest{
+			Namespace: namespace,
+			Operation: opID,
+		})
+		return
+	}
+
+	waitOperation(client, ctx, opID)
+}
+
+func cmdCancel(printReq bool, client pb.OrchestratorClient, ctx context.Context, opID uint64) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req := &pb.CancelOperationRequest{
+		Namespace: namespace,
+		Operation: opID,
+	}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.CancelOperation(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.CancelOperation returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdSetReplication(printReq bool, client pb.OrchestratorClient, ctx context.Context, req *pb.SetReplicationRequest) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.SetReplication(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.SetReplication returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdSetPlacementPolicy(printReq bool, client pb.OrchestratorClient, ctx context.Context, req *pb.SetPlacementPolicyRequest) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.SetPlacementPolicy(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Orchestrator.SetPlacementPolicy returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdAckObsolete(printReq bool, client pb.OrchestratorClient, ctx context.Context, req *pb.AckObsoleteRequest) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.AckObsolete(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Orchestrator.AckObsolete returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdLineage(printReq bool, client pb.DebugClient, ctx context.Context, rID uint64) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.LineageRequest{Range: rID, Namespace: namespace}
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.Lineage(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Lineage returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+func cmdRangeAt(printReq bool, client pb.DebugClient, ctx context.Context, req *pb.RangeAtRequest) {
+	w := flag.CommandLine.Output()
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	res, err := client.RangeAt(ctx, req)
+
+	if err != nil {
+		fmt.Fprintf(w, "Debug.RangeAt returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	output(res)
+}
+
+// cmdWatch prints each event on its own line, until the controller goes away or
+// the command is interrupted. Unlike the others, there's no timeout.
+func cmdWatch(printReq bool, client pb.DebugClient, ctx context.Context, req *pb.WatchRequest) {
+	w := flag.CommandLine.Output()
+
+	if printReq {
+		output(req)
+		return
+	}
+
+	stream, err := client.Watch(ctx, req)
+	if err != nil {
+		fmt.Fprintf(w, "Debug.Watch returned: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			fmt.Fprintf(w, "Debug.Watch returned: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(opts.Format(res))
+	}
+}
+
+// parseKey returns the given key as bytes. If it's prefixed with 'b64:' then
+// the rest is decoded. Sometimes we want keys which are not printable chars,
+// or which contain commas. If it's prefixed with 'hash:' then the rest is
+// parsed and then hashed, to find a client key in a hash-partitioned keyspace.
+// Otherwise it's parsed by the key codec.
+func parseKey(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "hash:") {
+		key, err := parseKey(strings.TrimPrefix(s, "hash:"))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(api.HashKey(api.Key(key))), nil
+	}
+
+	key := []byte(s)
+
+	p := []byte("b64:")
+	if !bytes.HasPrefix(key, p) {
+		c := keyCodec
+		if c == nil {
+			c = api.RawCodec{}
+		}
+
+		k, err := api.ParseKey(c, s)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(k), nil
+	}
+
+	b := bytes.TrimPrefix(key, p)
+	key = make([]byte, base64.StdEncoding.DecodedLen(len(b)))
+	n, err := base64.StdEncoding.Decode(key, b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %s", b)
+	}
+
+	return key[:n], nil
+}
+
+// parseReplicationConfig returns the replication config described by the given
+// string, or nil (meaning the keyspace default) if it's "default". The config
+// may be followed by a colon and a label key to spread placements by.
+func parseReplicationConfig(s string) (*pb.ReplicationConfig, error) {
+	s, spreadBy, _ := strings.Cut(s, ":")
+
+	switch strings.ToLower(s) {
+	case "default":
+		if spreadBy != "" {
+			return nil, fmt.Errorf("default can't be spread by a label")
+		}
+		return nil, nil
+	case "r1":
+		s = "1,0,1,1,2"
+	case "r3":
+		s = "3,3,4,3,5"
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("expected five numbers, got %d", len(parts))
+	}
+
+	n := make([]int32, len(parts))
+	for i := range parts {
+		v, err := strconv.ParseInt(parts[i], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = int32(v)
+	}
+
+	return &pb.ReplicationConfig{
+		TargetActive:  n[0],
+		MinActive:     n[1],
+		MaxActive:     n[2],
+		MinPlacements: n[3],
+		MaxPlacements: n[4],
+		SpreadBy:      spreadBy,
+	}, nil
+}
+
+// parsePlacementPolicy returns the placement policy described by the given
+// string, or nil (meaning no policy) if it's "none". The selectors are checked
+// by the controller, so aren't validated here.
+func parsePlacementPolicy(s string) (*pb.PlacementPolicy, error) {
+	if strings.ToLower(s) == "none" {
+		return nil, nil
+	}
+
+	pp := &pb.PlacementPolicy{}
+	for _, part := range strings.Split(s, ";") {
+		k, v, _ := strings.Cut(part, "=")
+
+		switch k {
+		case "require":
+			pp.Require = v
+		case "prefer":
+			pp.Prefer = v
+		case "avoid":
+			pp.Avoid = v
+		case "colocate":
+			pp.ColocateAdjacent = true
+		default:
+			return nil, fmt.Errorf("unknown policy rule: %q", part)
+		}
+	}
+
+	return pp, nil
+}
+
+func output(res protoreflect.ProtoMessage) {
+	opts := protojson.MarshalOptions{
+		Multiline:       true,
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}
+
+	if keyCodec == nil {
+		fmt.Println(opts.Format(res))
+		return
+	}
+
+	// Round-trip through a map, so that keys can be replaced with strings.
+	b, err := opts.Marshal(res)
+	if err != nil {
+		panic(err)
+	}
+
+	v := map[string]interface{}{}
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		panic(err)
+	}
+
+	formatKeys(res.ProtoReflect(), v)
+
+	b, err = json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(b))
+}
+
+// formatKeys replaces every bytes field (all of which are keys) in the given
+// JSON representation of the given message with the key formatted by the key
+// codec, rather than base64.
+func formatKeys(m protoreflect.Message, v map[string]interface{}) {
+	fields := m.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+
+		jv, ok := v[name]
+		if !ok || jv == nil || fd.IsMap() {
+			continue
+		}
+
+		switch fd.Kind() {
+		case protoreflect.BytesKind:
+			if !fd.IsList() {
+				v[name] = api.FormatKey(keyCodec, api.Key(m.Get(fd).Bytes()))
+				continue
+			}
+
+			list := m.Get(fd).List()
+			out := make([]interface{}, list.Len())
+			for j := range out {
+				out[j] = api.FormatKey(keyCodec, api.Key(list.Get(j).Bytes()))
+			}
+			v[name] = out
+
+		case protoreflect.MessageKind:
+			if !fd.IsList() {
+				if jm, ok := jv.(map[string]interface{}); ok {
+					formatKeys(m.Get(fd).Message(), jm)
+				}
+				continue
+			}
+
+			list := m.Get(fd).List()
+			jl, ok := jv.([]interface{})
+			if !ok {
+				continue
+			}
+			for j := 0; j < list.Len() && j < len(jl); j++ {
+				if jm, ok := jl[j].(map[string]interface{}); ok {
+					formatKeys(list.Get(j).Message(), jm)
+				}
+			}
+		}
+	}
+}
+
+func renderRangesOutput(w io.Writer, res *pb.RangesListResponse) {
+	fmt.Fprint(w, "digraph G {\n")
+
+	for _, r := range res.Ranges {
+		placements := []string{}
+		for _, p := range r.Placements {
+			placements = append(placements, fmt.Sprintf("%s:%s", p.Placement.Node, p.Placement.State))
+		}
+
+		// Not an attr because it's an HTML string, so needs angle bracket delimiters.
+		label := fmt.Sprintf("<b>R%d</b><br/>%s<br/>%s<br/>%s", r.Meta.Ident, renderRange(r.Meta.Start, r.Meta.End), r.State, strings.Join(placements, "\\n"))
+
+		attrs := map[string]string{
+			"shape": "rect",
+			"style": "filled",
+
+			// Defaults for unknown states; probably changed below.
+			"color":     "black",
+			"fontcolor": "black",
 			"fillcolor": "white",
 		}
 
@@ -410,6 +1496,14 @@ func renderRangesOutput(w io.Writer, res *pb.RangesListResponse) {
 		case pb.RangeState_RS_OBSOLETE:
 			attrs["color"] = "#cccccc"
 			attrs["fontcolor"] = "#cccccc"
+		case pb.RangeState_RS_NEW:
+			attrs["color"] = "#000060"
+			attrs["fontcolor"] = "#000060"
+			attrs["fillcolor"] = "#eeeeff"
+		case pb.RangeState_RS_ABORTED:
+			attrs["color"] = "#cccccc"
+			attrs["fontcolor"] = "#cccccc"
+			attrs["style"] = "filled,dashed"
 		}
 
 		fmt.Fprintf(w, "  R%d [label=<%s>", r.Meta.Ident, label)
@@ -448,16 +1542,21 @@ func renderRangesOutput(w io.Writer, res *pb.RangesListResponse) {
 func renderRange(start, end []byte) string {
 	var s, e string
 
+	c := keyCodec
+	if c == nil {
+		c = api.RawCodec{}
+	}
+
 	if len(start) == 0 {
 		s = "[-inf"
 	} else {
-		s = fmt.Sprintf("(%s", start)
+		s = fmt.Sprintf("(%s", api.FormatKey(c, api.Key(start)))
 	}
 
 	if len(end) == 0 {
 		e = "+inf]"
 	} else {
-		e = fmt.Sprintf("%s]", end)
+		e = fmt.Sprintf("%s]", api.FormatKey(c, api.Key(end)))
 	}
 
 	return fmt.Sprintf("%s, %s", s, e)
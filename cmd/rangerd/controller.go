@@ -2,38 +2,145 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"time"
 
 	"github.com/adammck/ranger/pkg/actuator"
 	rpc_actuator "github.com/adammck/ranger/pkg/actuator/rpc"
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/balancer"
+	"github.com/adammck/ranger/pkg/election"
 	"github.com/adammck/ranger/pkg/keyspace"
 	"github.com/adammck/ranger/pkg/orchestrator"
+	"github.com/adammck/ranger/pkg/persister"
 	"github.com/adammck/ranger/pkg/ranje"
 	"github.com/adammck/ranger/pkg/roster"
+	"github.com/adammck/ranger/pkg/util/wake"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	consuldisc "github.com/adammck/ranger/pkg/discovery/consul"
+	consulelect "github.com/adammck/ranger/pkg/election/consul"
 	consulpers "github.com/adammck/ranger/pkg/persister/consul"
 	consulapi "github.com/hashicorp/consul/api"
 )
 
+// Namespace configures one of the independent keyspaces which the controller
+// serves. Each has its own nodes, and stores its ranges under its own prefix in
+// Consul.
+type Namespace struct {
+	Name string
+
+	// The service name which the nodes serving this namespace register with.
+	Service string
+
+	// The default replication config, and the boundaries to split the keyspace
+	// at if it's empty. See keyspace.NewWithBootstrap.
+	Replication ranje.ReplicationConfig
+	Bootstrap   keyspace.Bootstrap
+}
+
+// DefaultNamespace returns the namespace which the controller serves when not
+// configured with any others. This is compatible with controllers from before
+// namespaces existed: nodes register as "node", and ranges are stored at the
+// root of the Consul KV store.
+func DefaultNamespace(repl ranje.ReplicationConfig, boot keyspace.Bootstrap) Namespace {
+	return Namespace{
+		Name:        orchestrator.DefaultNamespace,
+		Service:     roster.DefaultService,
+		Replication: repl,
+		Bootstrap:   boot,
+	}
+}
+
+// namespace is the running state of a Namespace. The orchestration, actuation,
+// probing, and GC loops run separately for each one.
+type namespace struct {
+	name string
+	cfg  Namespace
+	pers *consulpers.Persister
+	ks   *keyspace.Keyspace
+	rost *roster.Roster
+	act  *actuator.Actuator
+	orch *orchestrator.Orchestrator
+	bal  *balancer.Balancer // nil if disabled
+
+	// Notified whenever a node is probed (or expired), to wake the
+	// orchestrator once this controller is the leader. See drainProbes.
+	probed *wake.Signal
+}
+
+// standbyInterval is how often a standby reloads the keyspace from the store,
+// so that the Debug RPCs which it serves aren't too stale.
+const standbyInterval = 10 * time.Second
+
+// Config configures a Controller. Most fields correspond to a flag.
+type Config struct {
+	// The address to listen for RPCs on, and the address which other nodes
+	// should use to reach this controller.
+	Addr    string
+	PubAddr string
+
+	// How often to run the orchestration and actuation loops, when nothing
+	// wakes them sooner.
+	Interval time.Duration
+
+	// Run one rebalance cycle and exit.
+	Once bool
+
+	Namespaces []Namespace
+
+	// Which obsolete ranges to keep, and how often to collect the others. Zero
+	// GCInterval disables collection.
+	Retention  keyspace.Retention
+	GCInterval time.Duration
+
+	// How long splits and joins can take before they're aborted. Zero means
+	// never. See Orchestrator.SetOpTimeout.
+	OpTimeout time.Duration
+
+	// How to balance ranges, and how often. Zero BalanceInterval disables the
+	// balancer.
+	Balance         balancer.Config
+	BalanceInterval time.Duration
+
+	// The Consul key to elect a leader with. Empty means that this is the only
+	// controller, so there's no election.
+	ElectionKey string
+
+	// The name of the placement policy. See roster.NewPlacementPolicy.
+	Placement string
+}
+
 type Controller struct {
 	addrLis  string
 	addrPub  string // do we actually need this? maybe only discovery does.
 	interval time.Duration
 	once     bool // run one rebalance cycle and exit
 
-	srv  *grpc.Server
-	ks   *keyspace.Keyspace
-	rost *roster.Roster
-	act  *actuator.Actuator
-	orch *orchestrator.Orchestrator
+	// How often to garbage collect obsolete ranges. Zero disables it.
+	gcInterval time.Duration
+
+	// How often to run the balancer. Zero disables it.
+	balInterval time.Duration
+	balCfg      balancer.Config
+
+	ret       keyspace.Retention
+	opTimeout time.Duration
+
+	// Nil unless several controllers are run, in which case only the elected
+	// leader orchestrates, and the others are standbys.
+	elector election.Elector
+
+	srv        *grpc.Server
+	router     *orchestrator.Namespaces
+	namespaces []*namespace
 }
 
-func New(addrLis, addrPub string, interval time.Duration, once bool) (*Controller, error) {
+func New(cfg Config) (*Controller, error) {
 	var opts []grpc.ServerOption
 	srv := grpc.NewServer(opts...)
 
@@ -47,40 +154,238 @@ func New(addrLis, addrPub string, interval time.Duration, once bool) (*Controlle
 		return nil, err
 	}
 
-	api, err := consulapi.NewClient(consulapi.DefaultConfig())
+	client, err := consulapi.NewClient(consulapi.DefaultConfig())
 	if err != nil {
 		return nil, err
 	}
 
-	pers := consulpers.New(api)
+	c := &Controller{
+		addrLis:     cfg.Addr,
+		addrPub:     cfg.PubAddr,
+		interval:    cfg.Interval,
+		once:        cfg.Once,
+		gcInterval:  cfg.GCInterval,
+		balInterval: cfg.BalanceInterval,
+		balCfg:      cfg.Balance,
+		ret:         cfg.Retention,
+		opTimeout:   cfg.OpTimeout,
+		srv:         srv,
+
+		// Route the Orchestrator and Debug RPCs to the namespace in each
+		// request.
+		router: orchestrator.NewNamespaces(srv),
+	}
+
+	if cfg.ElectionKey != "" {
+		c.elector = consulelect.New(client, cfg.ElectionKey, cfg.PubAddr)
+	}
+
+	for _, nsCfg := range cfg.Namespaces {
+		info := make(chan roster.NodeInfo)
+		ns := &namespace{
+			name:   nsCfg.Name,
+			cfg:    nsCfg,
+			pers:   consulpers.NewForNamespace(client, nsCfg.Name),
+			probed: wake.New(),
+
+			// TODO: Hook up the callbacks (or replace with channels)
+			rost: roster.NewForService(disc, nsCfg.Service, nil, nil, info),
+		}
+
+		// Each namespace gets its own policy, since they can have state.
+		ns.rost.Policy, err = roster.NewPlacementPolicy(cfg.Placement)
+		if err != nil {
+			return nil, err
+		}
+
+		go ns.drainProbes(info)
+
+		// This loads the ranges from storage, so will fail if the persister
+		// (e.g. Consul) isn't available. When there might be a leader already,
+		// the store is only read, and is loaded again if this controller is
+		// elected. See campaign.
+		if c.elector == nil {
+			err = c.load(ns, ns.pers, true)
+		} else {
+			err = c.load(ns, readOnly{ns.pers}, false)
+		}
+		if err != nil && !errors.Is(err, errReadOnly) {
+			return nil, fmt.Errorf("namespace %q: %w", nsCfg.Name, err)
+		}
+
+		c.namespaces = append(c.namespaces, ns)
+	}
+
+	return c, nil
+}
+
+// store is the persister of a namespace.
+type store interface {
+	persister.Persister
+	persister.OpPersister
+	persister.NodePersister
+}
 
-	// This loads the ranges from storage, so will fail if the persister (e.g.
-	// Consul) isn't available. Starting with an empty keyspace should be rare.
-	// TODO: Allow replication to be customized via flags or config.
-	ks, err := keyspace.New(pers, ranje.R1)
+var errReadOnly = errors.New("store is read-only on standby")
+
+// readOnly is a store which refuses all writes, so that standbys can load the
+// keyspace without any risk of persisting anything.
+type readOnly struct {
+	store
+}
+
+func (readOnly) PutRanges([]*ranje.Range) error            { return errReadOnly }
+func (readOnly) DeleteRanges([]*ranje.Range) error         { return errReadOnly }
+func (readOnly) PutOps([]*ranje.Op) error                  { return errReadOnly }
+func (readOnly) DeleteOps([]*ranje.Op) error               { return errReadOnly }
+func (readOnly) PutNodeAdmins([]*ranje.NodeAdmin) error    { return errReadOnly }
+func (readOnly) DeleteNodeAdmins([]*ranje.NodeAdmin) error { return errReadOnly }
+
+// load (re)loads the keyspace and node admin state of the given namespace from
+// the given store, and routes requests for it to a new orchestrator. Ops are
+// only loaded by the leader, since standbys don't serve the Orchestrator RPCs.
+func (c *Controller) load(ns *namespace, st store, leader bool) error {
+
+	// Starting with an empty keyspace should be rare, and is the only time that
+	// the bootstrap config is used. Standbys can't bootstrap, so return
+	// errReadOnly, and wait for the leader to. Individual ranges can override
+	// the default replication config at runtime, via the SetReplication RPC.
+	ks, err := keyspace.NewWithBootstrap(st, ns.cfg.Replication, ns.cfg.Bootstrap)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// TODO: Hook up the callbacks (or replace with channels)
-	rost := roster.New(disc, nil, nil, nil)
+	ks.SetRetention(c.ret)
 
-	actImpl := rpc_actuator.New(ks, rost)
-	act := actuator.New(ks, rost, time.Duration(3*time.Second), actImpl)
+	// Nodes which were cordoned or drained by an operator stay that way.
+	err = ns.rost.LoadAdmin(st)
+	if err != nil {
+		return fmt.Errorf("error loading node admin state: %w", err)
+	}
+
+	orch := orchestrator.New(ks, ns.rost, nil)
+	orch.SetOpTimeout(c.opTimeout)
+
+	var act *actuator.Actuator
+	var bal *balancer.Balancer
+
+	if leader {
+
+		// Ops which were submitted via the Orchestrator API before the last
+		// restart (or by the previous leader) are queued again, or tracked
+		// until they finish.
+		err = orch.LoadOps(st)
+		if err != nil {
+			return fmt.Errorf("error loading ops: %w", err)
+		}
 
-	orch := orchestrator.New(ks, rost, srv)
+		actImpl := rpc_actuator.New(ks, ns.rost)
+		act = actuator.New(ks, ns.rost, time.Duration(3*time.Second), actImpl)
 
-	return &Controller{
-		addrLis:  addrLis,
-		addrPub:  addrPub,
-		interval: interval,
-		once:     once,
-		srv:      srv,
-		ks:       ks,
-		rost:     rost,
-		act:      act,
-		orch:     orch,
-	}, nil
+		// Every tick might want something actuated, and every command which
+		// completes might let the orchestrator take the next step. Commands
+		// which fail might have been applied anyway, so probe again to check.
+		orch.OnTick(act.Wake)
+		act.OnCommand(func(cmd api.Command, err error) {
+			orch.Wake()
+			if err != nil {
+				ns.rost.Wake()
+			}
+		})
+
+		// The balancer initiates splits, joins, and moves via the orchestrator,
+		// just like an operator would.
+		if c.balInterval > 0 {
+			bal, err = balancer.New(ks, ns.rost, orch, c.balCfg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	ns.ks = ks
+	ns.act = act
+	ns.orch = orch
+	ns.bal = bal
+	c.router.Replace(ns.name, orch)
+
+	return nil
+}
+
+// campaign serves as a standby until this controller is elected leader, or the
+// context is cancelled (in which case it returns a nil term). When elected, it
+// fences the store of each namespace with the new epoch, so the old leader can
+// no longer write to it, and then loads everything again, since the old leader
+// may have changed it.
+func (c *Controller) campaign(ctx context.Context) (election.Term, error) {
+	c.setStandby()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	terms := make(chan election.Term, 1)
+	errs := make(chan error, 1)
+	go func() {
+		t, err := c.elector.Campaign(ctx)
+		if err != nil {
+			errs <- err
+			return
+		}
+		terms <- t
+	}()
+
+	ticker := time.NewTicker(standbyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("error campaigning: %w", err)
+
+		case <-ticker.C:
+			c.setStandby()
+			for _, ns := range c.namespaces {
+				err := c.load(ns, readOnly{ns.pers}, false)
+				if err != nil && !errors.Is(err, errReadOnly) {
+					log.Printf("error reloading namespace %q on standby: %v", ns.name, err)
+				}
+			}
+
+		case t := <-terms:
+			log.Printf("elected leader (epoch=%d)", t.Epoch())
+
+			for _, ns := range c.namespaces {
+				err := ns.pers.Fence(t.Epoch())
+				if err == nil {
+					err = c.load(ns, ns.pers, true)
+				}
+				if err != nil {
+					t.Resign()
+					return nil, fmt.Errorf("namespace %q: error taking over: %w", ns.name, err)
+				}
+			}
+
+			c.router.SetLeader()
+			return t, nil
+		}
+	}
+}
+
+// setStandby marks the router as a standby, with the current leader (if any),
+// so it can redirect callers.
+func (c *Controller) setStandby() {
+	leader, err := c.elector.Leader()
+	if err != nil {
+		log.Printf("error fetching leader: %v", err)
+	}
+
+	c.router.SetStandby(leader)
 }
 
 func (c *Controller) Run(ctx context.Context) error {
@@ -109,41 +414,98 @@ func (c *Controller) Run(ctx context.Context) error {
 
 	// Perform a single blocking probe cycle, to ensure that the first rebalance
 	// happens after we have the current state of the nodes.
-	c.rost.Tick()
+	for _, ns := range c.namespaces {
+		ns.rost.Tick()
+	}
+
+	// Periodically probe all nodes to keep their state up to date. Standbys do
+	// this too, so that they're ready to take over.
+	if !c.once {
+		for _, ns := range c.namespaces {
+			go ns.rost.Run(time.NewTicker(1 * time.Second))
+		}
+	}
+
+	// Block until elected, if there might be another leader. The term is over
+	// when the process exits, at the latest.
+	var lost <-chan struct{}
+	if c.elector != nil {
+		t, err := c.campaign(ctx)
+		if err != nil {
+			c.srv.Stop()
+			return err
+		}
+		if t == nil {
+			return c.stop(errChan)
+		}
+
+		defer t.Resign()
+		lost = t.Lost()
+	}
 
 	if c.once {
-		c.orch.Tick()
-		c.act.Tick()
+		for _, ns := range c.namespaces {
+			if ns.bal != nil {
+				ns.bal.Tick()
+			}
+			ns.orch.Tick()
+			ns.act.Tick()
+		}
 
 	} else {
+		for _, ns := range c.namespaces {
 
-		// Periodically probe all nodes to keep their state up to date.
-		ticker := time.NewTicker(1 * time.Second)
-		go c.rost.Run(ticker)
+			// Start orchestration and actuation loops. These run whenever
+			// something happens which they might need to react to, so the
+			// interval is only a safety net.
+			go ns.orch.Run(time.NewTicker(c.interval))
+			go ns.act.Run(time.NewTicker(c.interval))
 
-		// Start rebalancing loop.
-		go c.orch.Run(time.NewTicker(c.interval))
+			// Orchestrate as soon as a probe finds that a node has changed.
+			go func(ns *namespace) {
+				for range ns.probed.C() {
+					ns.orch.Wake()
+				}
+			}(ns)
 
-		// Start incredibly actuation loop. The interval only affects how soon
-		// it will notice a pending actuation. Failed actuations should retry
-		// slower than this.
-		go c.act.Run(time.NewTicker(500 * time.Millisecond))
+			// Periodically garbage collect obsolete ranges, according to the
+			// retention policy. This does nothing with the default policy.
+			if c.gcInterval > 0 {
+				go ns.runGC(ctx, time.NewTicker(c.gcInterval))
+			}
+
+			// Periodically split, join, and move ranges according to load.
+			if ns.bal != nil {
+				go ns.bal.Run(time.NewTicker(c.balInterval))
+			}
+		}
 
 		// Block until context is cancelled, indicating that caller wants
-		// shutdown.
-		if !c.once {
-			<-ctx.Done()
+		// shutdown. If leadership is lost first, exit immediately rather than
+		// waiting for anything. The store is fenced, so this controller can't
+		// persist anything anyway, and will crash if it tries.
+		select {
+		case <-ctx.Done():
+		case <-lost:
+			c.srv.Stop()
+			return errors.New("lost leadership")
 		}
 	}
 
 	// Let in-flight commands finish. This isn't strictly necessary, but allows
 	// us to minmize the stuff which will need reconciling at next startup.
-	c.act.Wait()
+	for _, ns := range c.namespaces {
+		ns.act.Wait()
+	}
+
+	return c.stop(errChan)
+}
 
-	// Let in-flight incoming RPCs finish and then stop. errChan will contain
-	// the error returned by srv.Serve (above) or be closed with no error.
+// stop lets in-flight incoming RPCs finish and then stops the server. errChan
+// will contain the error returned by srv.Serve or be closed with no error.
+func (c *Controller) stop(errChan chan error) error {
 	c.srv.GracefulStop()
-	err = <-errChan
+	err := <-errChan
 	if err != nil {
 		log.Printf("Error from srv.Serve: %v", err)
 		return err
@@ -151,3 +513,27 @@ func (c *Controller) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// drainProbes receives every probe result from the roster of the namespace, and
+// notifies probed. The roster blocks until they're received, so this must run
+// for as long as it does, even while this controller is a standby.
+func (ns *namespace) drainProbes(info <-chan roster.NodeInfo) {
+	for range info {
+		ns.probed.Notify()
+	}
+}
+
+func (ns *namespace) runGC(ctx context.Context, t *time.Ticker) {
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			_, err := ns.ks.GC()
+			if err != nil {
+				log.Printf("error collecting obsolete ranges in namespace %q: %v", ns.name, err)
+			}
+		}
+	}
+}
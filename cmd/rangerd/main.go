@@ -2,19 +2,46 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"regexp"
+	"strings"
 	"syscall"
 	"time"
+
+	"github.com/adammck/ranger/pkg/balancer"
+	"github.com/adammck/ranger/pkg/keyspace"
+	"github.com/adammck/ranger/pkg/ranje"
 )
 
 func main() {
 	addrLis := flag.String("addr", "localhost:8000", "address to start grpc server on")
 	addrPub := flag.String("pub-addr", "", "address for other nodes to reach this (default: same as -addr)")
-	interval := flag.Duration("interval", 250*time.Millisecond, "frequency of orchestration loop")
+	interval := flag.Duration("interval", 5*time.Second, "frequency of orchestration and actuation loops when nothing wakes them sooner")
 	once := flag.Bool("once", false, "perform one rebalance cycle and exit")
+	retainGens := flag.Int("retain-generations", 0, "generations of obsolete ranges to keep (default: no limit)")
+	retainAge := flag.Duration("retain-age", 0, "minimum time to keep obsolete ranges (default: no limit)")
+	retainAck := flag.Bool("retain-ack", false, "keep obsolete ranges until the service acks them via AckObsolete")
+	gcInterval := flag.Duration("gc-interval", time.Minute, "frequency of obsolete range garbage collection")
+	opTimeout := flag.Duration("op-timeout", 0, "abort splits and joins which take longer than this (default: never)")
+	replName := flag.String("replication", "R1", "default replication config for ranges (R1 or R3, optionally followed by :<label> to spread across, e.g. R3:zone)")
+	bootPath := flag.String("bootstrap", "", "JSON file of boundaries (and nodes) to split an empty keyspace at (default: one range)")
+	balInterval := flag.Duration("balance-interval", 0, "frequency of load balancing (default: never)")
+	balMetric := flag.String("balance-metric", "keys", "load metric to balance by: keys, bytes, qps, cpu, memory, or a custom metric")
+	balSplit := flag.Float64("balance-split", 100, "split ranges with more load than this")
+	balJoin := flag.Float64("balance-join", 20, "join adjacent ranges with less combined load than this")
+	balNodeHigh := flag.Float64("balance-node-high", 0, "move ranges off nodes with more load than this (default: never)")
+	balNodeLow := flag.Float64("balance-node-low", 0, "move ranges until the source has less load than this, and never make the destination exceed it")
+	balCycles := flag.Int("balance-cycles", 3, "consecutive balancer cycles a threshold must be exceeded for before acting")
+	balMaxOps := flag.Int("balance-max-ops", 10, "maximum operations to initiate per balancer cycle (0: no limit)")
+	nsPath := flag.String("namespaces", "", "JSON file of namespaces to serve (default: a single unnamed one)")
+	electionKey := flag.String("election-key", "", "Consul key to elect a leader with, to run several controllers as standbys (default: no election)")
+	placement := flag.String("placement-policy", "least-ranges", "how to choose nodes for new placements: least-ranges, least-load, or random-of-two")
 	flag.Parse()
 
 	if *addrPub == "" {
@@ -27,7 +54,65 @@ func main() {
 	log.Default().SetPrefix("")
 	log.Default().SetFlags(0)
 
-	cmd, err := New(*addrLis, *addrPub, *interval, *once)
+	repl, err := parseReplication(*replName)
+	if err != nil {
+		exit(err)
+	}
+
+	// Obsolete ranges are kept forever unless one of the retain flags is set.
+	ret := keyspace.Retention{
+		Generations: *retainGens,
+		Age:         *retainAge,
+		Ack:         *retainAck,
+	}
+
+	var nss []Namespace
+	if *nsPath != "" {
+		if *bootPath != "" {
+			exit(errors.New("-bootstrap can't be used with -namespaces; set bootstrap per namespace instead"))
+		}
+
+		nss, err = loadNamespaces(*nsPath, repl)
+		if err != nil {
+			exit(err)
+		}
+
+	} else {
+		var boot keyspace.Bootstrap
+		if *bootPath != "" {
+			boot, err = loadBootstrap(*bootPath)
+			if err != nil {
+				exit(err)
+			}
+		}
+
+		nss = []Namespace{DefaultNamespace(repl, boot)}
+	}
+
+	bal := balancer.Config{
+		Metric:     *balMetric,
+		SplitAbove: *balSplit,
+		JoinBelow:  *balJoin,
+		NodeAbove:  *balNodeHigh,
+		NodeBelow:  *balNodeLow,
+		Cycles:     *balCycles,
+		MaxOps:     *balMaxOps,
+	}
+
+	cmd, err := New(Config{
+		Addr:            *addrLis,
+		PubAddr:         *addrPub,
+		Interval:        *interval,
+		Once:            *once,
+		Namespaces:      nss,
+		Retention:       ret,
+		GCInterval:      *gcInterval,
+		OpTimeout:       *opTimeout,
+		Balance:         bal,
+		BalanceInterval: *balInterval,
+		ElectionKey:     *electionKey,
+		Placement:       *placement,
+	})
 	if err != nil {
 		exit(err)
 	}
@@ -48,6 +133,109 @@ func main() {
 	}
 }
 
+// loadBootstrap reads a bootstrap config from the given JSON file, which looks
+// like: {"boundaries": ["ggg", "ppp"], "nodes": [["aaa"], ["bbb"], ["ccc"]]}
+func loadBootstrap(path string) (keyspace.Bootstrap, error) {
+	var boot keyspace.Bootstrap
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return boot, err
+	}
+
+	err = json.Unmarshal(b, &boot)
+	if err != nil {
+		return boot, fmt.Errorf("invalid bootstrap config: %w", err)
+	}
+
+	return boot, nil
+}
+
+// parseReplication returns the named replication config, optionally followed
+// by a colon and a label key to spread placements by, e.g. "R3:zone".
+func parseReplication(s string) (ranje.ReplicationConfig, error) {
+	name, spreadBy, _ := strings.Cut(s, ":")
+
+	var rc ranje.ReplicationConfig
+	switch strings.ToUpper(name) {
+	case "R1":
+		rc = ranje.R1
+	case "R3":
+		rc = ranje.R3
+	default:
+		return ranje.ReplicationConfig{}, fmt.Errorf("invalid replication config: %s", s)
+	}
+
+	rc.SpreadBy = spreadBy
+	return rc, nil
+}
+
+// Namespace names are used in Consul keys, so are kept simple.
+var validNamespace = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// loadNamespaces reads the namespaces to serve from the given JSON file, which
+// looks like: [{"name": "foo", "service": "foo-node", "replication": "R3",
+// "bootstrap": {"boundaries": ["ggg"]}}, {"name": "bar"}]. The service defaults
+// to the name, and the replication config defaults to the given one.
+func loadNamespaces(path string, repl ranje.ReplicationConfig) ([]Namespace, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfgs []struct {
+		Name        string
+		Service     string
+		Replication string
+		Bootstrap   keyspace.Bootstrap
+	}
+
+	err = json.Unmarshal(b, &cfgs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaces config: %w", err)
+	}
+
+	if len(cfgs) == 0 {
+		return nil, errors.New("invalid namespaces config: no namespaces")
+	}
+
+	seen := map[string]struct{}{}
+	out := make([]Namespace, len(cfgs))
+
+	for i, cfg := range cfgs {
+		if !validNamespace.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("invalid namespace name: %q", cfg.Name)
+		}
+
+		if _, ok := seen[cfg.Name]; ok {
+			return nil, fmt.Errorf("duplicate namespace: %q", cfg.Name)
+		}
+		seen[cfg.Name] = struct{}{}
+
+		ns := Namespace{
+			Name:        cfg.Name,
+			Service:     cfg.Service,
+			Replication: repl,
+			Bootstrap:   cfg.Bootstrap,
+		}
+
+		if ns.Service == "" {
+			ns.Service = cfg.Name
+		}
+
+		if cfg.Replication != "" {
+			ns.Replication, err = parseReplication(cfg.Replication)
+			if err != nil {
+				return nil, fmt.Errorf("namespace %q: %w", cfg.Name, err)
+			}
+		}
+
+		out[i] = ns
+	}
+
+	return out, nil
+}
+
 func exit(err error) {
 	log.Fatalf("Error: %s", err)
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/keyspace"
+	"github.com/adammck/ranger/pkg/persister"
+	consulpers "github.com/adammck/ranger/pkg/persister/consul"
+	sqlpers "github.com/adammck/ranger/pkg/persister/sql"
+	"github.com/adammck/ranger/pkg/ranje"
+	consulapi "github.com/hashicorp/consul/api"
+	_ "modernc.org/sqlite"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "offer to repair each problem which can be")
+	yes := flag.Bool("yes", false, "accept every repair without asking (implies -repair)")
+	sqlitePath := flag.String("sqlite", "", "read ranges from this SQLite database (default: consul)")
+	namespace := flag.String("namespace", "", "namespace to check, when reading from consul (default: the unnamed one)")
+	flag.Parse()
+
+	log.Default().SetOutput(os.Stderr)
+	log.Default().SetPrefix("")
+	log.Default().SetFlags(0)
+
+	pers, err := newPersister(*sqlitePath, *namespace)
+	if err != nil {
+		exit(err)
+	}
+
+	n, err := run(pers, *repair || *yes, *yes, os.Stdin, os.Stdout)
+	if err != nil {
+		exit(err)
+	}
+
+	if n > 0 {
+		os.Exit(1)
+	}
+}
+
+// newPersister returns a persister for the SQLite database at the given path,
+// or for Consul (like rangerd) if the path is blank.
+func newPersister(path, namespace string) (persister.Persister, error) {
+	if path != "" {
+		db, err := sql.Open("sqlite", path)
+		if err != nil {
+			return nil, err
+		}
+
+		return sqlpers.New(db)
+	}
+
+	client, err := consulapi.NewClient(consulapi.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return consulpers.NewForNamespace(client, namespace), nil
+}
+
+// run checks the ranges in the given persister, printing any problems to out.
+// If repair is true, prompts (via out and in) for each problem which can be
+// repaired, or just repairs it if yes is true, and then writes every changed
+// range back in a single transaction. Returns the number of problems which
+// remain.
+//
+// This must not be run while the controller is running! It will overwrite the
+// repaired ranges, and probably crash when it next restarts.
+func run(pers persister.Persister, repair, yes bool, in io.Reader, out io.Writer) (int, error) {
+	ranges, err := pers.GetRanges()
+	if err != nil {
+		return 0, fmt.Errorf("error loading ranges: %w", err)
+	}
+
+	problems := keyspace.Fsck(ranges)
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "ok (ranges=%d)\n", len(ranges))
+		return 0, nil
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(out, p)
+	}
+
+	if !repair {
+		return len(problems), nil
+	}
+
+	sc := bufio.NewScanner(in)
+	changed := map[api.RangeID]*ranje.Range{}
+
+	for _, p := range problems {
+		if p.Repair == nil {
+			continue
+		}
+
+		if !yes {
+			fmt.Fprintf(out, "Repair %s? [y/N] ", p)
+			if !sc.Scan() || strings.ToLower(strings.TrimSpace(sc.Text())) != "y" {
+				continue
+			}
+		}
+
+		for _, r := range p.Repair() {
+			changed[r.Meta.Ident] = r
+		}
+	}
+
+	if len(changed) == 0 {
+		return len(problems), nil
+	}
+
+	rs := make([]*ranje.Range, 0, len(changed))
+	for _, r := range changed {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Meta.Ident < rs[j].Meta.Ident
+	})
+
+	err = pers.PutRanges(rs)
+	if err != nil {
+		return len(problems), fmt.Errorf("error writing repaired ranges: %w", err)
+	}
+
+	fmt.Fprintf(out, "repaired %d ranges\n", len(rs))
+
+	// Check again, since repairs may have fixed (or revealed) other problems.
+	problems = keyspace.Fsck(ranges)
+	for _, p := range problems {
+		fmt.Fprintf(out, "remaining: %s\n", p)
+	}
+
+	return len(problems), nil
+}
+
+func exit(err error) {
+	log.Fatalf("Error: %s", err)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"math/rand"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -32,6 +34,7 @@ func main() {
 	drain := flag.Bool("drain", false, "node: drain ranges before shutting down")
 	LogReqs := flag.Bool("log-reqs", false, "proxy, node: enable request logging")
 	chaos := flag.Bool("chaos", false, "enable random failures and delays")
+	labels := flag.String("labels", "", "node: comma-separated key=value labels to register with, e.g. zone=a")
 	flag.Parse()
 
 	if *addrPub == "" {
@@ -57,7 +60,11 @@ func main() {
 	var err error
 
 	if *fnod && !*fprx {
-		cmd, err = node.New(*addrLis, *addrPub, *drain, *LogReqs, *chaos)
+		var l map[string]string
+		l, err = parseLabels(*labels)
+		if err == nil {
+			cmd, err = node.New(*addrLis, *addrPub, l, *drain, *LogReqs, *chaos)
+		}
 
 	} else if !*fnod && *fprx {
 		cmd, err = proxy.New(*addrLis, *addrPub, *LogReqs)
@@ -76,6 +83,24 @@ func main() {
 	}
 }
 
+// parseLabels parses a string like "zone=a,rack=b" into a map.
+func parseLabels(s string) (map[string]string, error) {
+	out := map[string]string{}
+	if s == "" {
+		return out, nil
+	}
+
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid label: %q", kv)
+		}
+		out[k] = v
+	}
+
+	return out, nil
+}
+
 func exit(err error) {
 	log.Fatalf("Error: %s", err)
 }
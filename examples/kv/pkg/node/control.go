@@ -21,14 +21,16 @@ func (n *Node) GetLoadInfo(rID api.RangeID) (api.LoadInfo, error) {
 	}
 
 	keys := []string{}
+	bytes := 0
 
 	// Find mid-point in an extremely inefficient manner.
 	// While holding the lock, no less.
 	func() {
 		r.dataMu.RLock()
 		defer r.dataMu.RUnlock()
-		for k := range r.data {
+		for k, v := range r.data {
 			keys = append(keys, k)
+			bytes += len(k) + len(v)
 		}
 	}()
 
@@ -40,6 +42,7 @@ func (n *Node) GetLoadInfo(rID api.RangeID) (api.LoadInfo, error) {
 
 	return api.LoadInfo{
 		Keys:   len(keys),
+		Bytes:  bytes,
 		Splits: []api.Key{split},
 	}, nil
 }
@@ -50,7 +50,7 @@ func init() {
 	var _ api.Node = ns
 }
 
-func New(addrLis, addrPub string, drainBeforeShutdown bool, logReqs bool, chaos bool) (*Node, error) {
+func New(addrLis, addrPub string, labels map[string]string, drainBeforeShutdown bool, logReqs bool, chaos bool) (*Node, error) {
 	var opts []grpc.ServerOption
 	srv := grpc.NewServer(opts...)
 
@@ -63,6 +63,8 @@ func New(addrLis, addrPub string, drainBeforeShutdown bool, logReqs bool, chaos
 		return nil, err
 	}
 
+	disc.SetLabels(labels)
+
 	n := &Node{
 		DrainBeforeShutdown: drainBeforeShutdown,
 		ranges:              map[api.RangeID]*Range{},
@@ -3,6 +3,7 @@ module github.com/adammck/ranger
 go 1.18
 
 require (
+	github.com/google/go-cmp v0.5.9
 	github.com/hashicorp/consul/api v1.12.0
 	github.com/hashicorp/go-multierror v1.1.0
 	github.com/lthibault/jitterbug v2.0.0+incompatible
@@ -15,7 +16,6 @@ require (
 
 require (
 	github.com/dustin/go-humanize v1.0.0 // indirect
-	github.com/google/go-cmp v0.5.9 // indirect
 	github.com/google/uuid v1.3.0 // indirect
 	github.com/hashicorp/errwrap v1.0.0 // indirect
 	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51 // indirect
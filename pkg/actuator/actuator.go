@@ -9,6 +9,7 @@ import (
 	"github.com/adammck/ranger/pkg/keyspace"
 	"github.com/adammck/ranger/pkg/ranje"
 	"github.com/adammck/ranger/pkg/roster"
+	"github.com/adammck/ranger/pkg/util/wake"
 )
 
 type Impl interface {
@@ -38,30 +39,66 @@ type Actuator struct {
 	failuresMu sync.RWMutex
 
 	backoff time.Duration
+
+	// Notified to tick as soon as possible, rather than waiting for the ticker
+	// given to Run. See Wake.
+	sig *wake.Signal
+
+	// When the earliest pending retry of a command which is backing off will
+	// be. See wakeAt.
+	retryAt time.Time
+	retryMu sync.Mutex
+
+	// Called whenever a command completes. See OnCommand.
+	onCommand func(cmd api.Command, err error)
 }
 
 func New(ks *keyspace.Keyspace, ros *roster.Roster, backoff time.Duration, impl Impl) *Actuator {
-	return &Actuator{
+	a := &Actuator{
 		ks:       ks,
 		ros:      ros,
 		Impl:     impl,
 		inFlight: map[api.Command]struct{}{},
 		failures: map[api.Command][]time.Time{},
 		backoff:  backoff,
+		sig:      wake.New(),
 	}
+
+	// Changes to the keyspace often mean that there's something to actuate,
+	// like a new placement to prepare.
+	ks.NotifyOnChange(a.sig)
+
+	return a
 }
 
+// Run calls Tick immediately, and then again whenever the actuator is woken
+// (see Wake), or the given ticker fires, forever. The ticker is only a safety
+// net, so can be quite slow; commands which are backing off after failing wake
+// the actuator when they're due to be retried.
 func (a *Actuator) Run(t *time.Ticker) {
-	// TODO: Replace this with something reactive; maybe chan from keyspace?
-	for ; true; <-t.C {
-		a.Tick()
-	}
+	wake.Loop(a.sig, t, a.Tick)
+}
+
+// Wake makes the next tick happen as soon as possible, rather than waiting for
+// the ticker given to Run. Call it when the desired state of any placement
+// might have changed, e.g. after every orchestrator tick.
+func (a *Actuator) Wake() {
+	a.sig.Notify()
+}
+
+// OnCommand sets a callback to be called whenever a command completes, with the
+// error it returned (if any), e.g. to wake the orchestrator so it can react to
+// the new state of the remote node. It's called from the goroutine which sent
+// the command, so must not block, and must be set before Run is called.
+func (a *Actuator) OnCommand(f func(cmd api.Command, err error)) {
+	a.onCommand = f
 }
 
 // Tick checks every placement, and actuates it (e.g. sends an RPC) if the
 // current state is not the desired state.
 func (a *Actuator) Tick() {
-	rs, unlock := a.ks.Ranges()
+	// Obsolete ranges have no placements, so skip them.
+	rs, unlock := a.ks.RangesInState(api.RsActive, api.RsSubsuming, api.RsNew, api.RsAborted)
 	defer unlock()
 
 	for _, r := range rs {
@@ -120,8 +157,12 @@ func (a *Actuator) consider(p *ranje.Placement) {
 	// backing off
 	// TODO: Use a proper increasing backoff and jitter.
 	// TODO: Also use clockwork to make this testable.
-	if a.backoff > 0 && a.LastFailure(cmd).After(time.Now().Add(-a.backoff)) {
-		return
+	if a.backoff > 0 {
+		retry := a.LastFailure(cmd).Add(a.backoff)
+		if retry.After(time.Now()) {
+			a.wakeAt(retry)
+			return
+		}
 	}
 
 	a.Exec(cmd, p, n)
@@ -163,10 +204,30 @@ func (a *Actuator) Exec(cmd api.Command, p *ranje.Placement, n *roster.Node) {
 		delete(a.inFlight, cmd)
 		a.inFlightMu.Unlock()
 
+		if a.onCommand != nil {
+			a.onCommand(cmd, err)
+		}
+
 		a.wg.Done()
 	}()
 }
 
+// wakeAt arranges for the actuator to be woken at the given time, unless it's
+// already due to be woken before then, so that commands which are backing off
+// are retried as soon as they can be.
+func (a *Actuator) wakeAt(t time.Time) {
+	a.retryMu.Lock()
+	defer a.retryMu.Unlock()
+
+	now := time.Now()
+	if a.retryAt.After(now) && !a.retryAt.After(t) {
+		return
+	}
+
+	a.retryAt = t
+	time.AfterFunc(t.Sub(now), a.Wake)
+}
+
 // TODO: Move this out to some outer actuator.
 type transitions struct {
 	from api.PlacementState
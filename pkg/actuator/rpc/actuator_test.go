@@ -273,7 +273,7 @@ func (ns *NodeServer) Prepare(ctx context.Context, req *pb.PrepareRequest) (*pb.
 			State: 0,
 			Info: &pb.LoadInfo{
 				Keys:   0,
-				Splits: []string{},
+				Splits: [][]byte{},
 			},
 		},
 	}, nil
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeyCodec converts keys to and from the text which operators see and enter,
+// so that services with binary or structured keys don't have to deal with them
+// as raw bytes. Ranger never needs one; keys are still compared as bytes.
+type KeyCodec interface {
+	Format(Key) (string, error)
+	Parse(string) (Key, error)
+}
+
+// FormatKey returns the given key formatted by the given codec. Keys which the
+// codec can't format (e.g. split points chosen by a service which don't fall
+// on a tuple boundary) are returned as hex, prefixed with "hex:", which ParseKey
+// understands regardless of the codec. The zero key is always empty.
+func FormatKey(c KeyCodec, k Key) string {
+	if k == ZeroKey {
+		return ""
+	}
+
+	s, err := c.Format(k)
+	if err != nil {
+		return "hex:" + hex.EncodeToString([]byte(k))
+	}
+
+	return s
+}
+
+// ParseKey returns the key represented by the given string, which is parsed by
+// the given codec unless it's prefixed with "hex:".
+func ParseKey(c KeyCodec, s string) (Key, error) {
+	if strings.HasPrefix(s, "hex:") {
+		return HexCodec{}.Parse(strings.TrimPrefix(s, "hex:"))
+	}
+
+	return c.Parse(s)
+}
+
+// KeyCodecByName returns the codec with the given name, which is one of: raw,
+// hex, base64, uint64, or tuple:<type>,<type>... where each type is string or
+// uint64.
+func KeyCodecByName(name string) (KeyCodec, error) {
+	switch name {
+	case "raw":
+		return RawCodec{}, nil
+	case "hex":
+		return HexCodec{}, nil
+	case "base64":
+		return Base64Codec{}, nil
+	case "uint64":
+		return Uint64Codec{}, nil
+	}
+
+	if strings.HasPrefix(name, "tuple:") {
+		return NewTupleCodec(strings.Split(strings.TrimPrefix(name, "tuple:"), ",")...)
+	}
+
+	return nil, fmt.Errorf("unknown key codec: %s", name)
+}
+
+// RawCodec treats keys as plain text. This is the default.
+type RawCodec struct{}
+
+func (RawCodec) Format(k Key) (string, error) {
+	return string(k), nil
+}
+
+func (RawCodec) Parse(s string) (Key, error) {
+	return Key(s), nil
+}
+
+// HexCodec formats keys as lowercase hex.
+type HexCodec struct{}
+
+func (HexCodec) Format(k Key) (string, error) {
+	return hex.EncodeToString([]byte(k)), nil
+}
+
+func (HexCodec) Parse(s string) (Key, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ZeroKey, fmt.Errorf("invalid hex: %w", err)
+	}
+
+	return Key(b), nil
+}
+
+// Base64Codec formats keys as standard (padded) base64, like the JSON encoding
+// of protobuf bytes fields.
+type Base64Codec struct{}
+
+func (Base64Codec) Format(k Key) (string, error) {
+	return base64.StdEncoding.EncodeToString([]byte(k)), nil
+}
+
+func (Base64Codec) Parse(s string) (Key, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return ZeroKey, fmt.Errorf("invalid base64: %w", err)
+	}
+
+	return Key(b), nil
+}
+
+// Uint64Codec formats keys which are big-endian uint64s as decimal. Encoding
+// them big-endian means that they sort numerically.
+type Uint64Codec struct{}
+
+// Uint64Key returns the given number as a key, like Uint64Codec does. This is
+// useful for services with integer keys to return from GetLoadInfo.
+func Uint64Key(n uint64) Key {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return Key(b)
+}
+
+func (Uint64Codec) Format(k Key) (string, error) {
+	if len(k) != 8 {
+		return "", fmt.Errorf("expected 8 bytes, got %d", len(k))
+	}
+
+	return strconv.FormatUint(binary.BigEndian.Uint64([]byte(k)), 10), nil
+}
+
+func (Uint64Codec) Parse(s string) (Key, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return ZeroKey, err
+	}
+
+	return Uint64Key(n), nil
+}
+
+// TupleCodec formats keys which are made of several typed elements, encoded so
+// that they sort element by element, e.g. (user, timestamp). The text form is
+// the elements separated by slashes, e.g. "adam/1700000000". A key may contain
+// only a prefix of the elements, which is useful for splitting.
+//
+// Strings are encoded with each 0x00 byte escaped as 0x00 0xFF, followed by a
+// 0x00 terminator, so that shorter strings sort first. Uint64s are encoded as
+// eight big-endian bytes. Strings can't contain slashes in the text form.
+type TupleCodec struct {
+	types []string
+}
+
+// NewTupleCodec returns a codec for tuples of the given types, each of which is
+// either "string" or "uint64".
+func NewTupleCodec(types ...string) (*TupleCodec, error) {
+	for _, t := range types {
+		if t != "string" && t != "uint64" {
+			return nil, fmt.Errorf("unknown tuple element type: %q", t)
+		}
+	}
+
+	return &TupleCodec{types: types}, nil
+}
+
+func (c *TupleCodec) Format(k Key) (string, error) {
+	b := []byte(k)
+	elems := []string{}
+
+	for i := 0; len(b) > 0; i++ {
+		if i >= len(c.types) {
+			return "", fmt.Errorf("more than %d elements", len(c.types))
+		}
+
+		switch c.types[i] {
+		case "string":
+			var buf []byte
+			for {
+				n := bytes.IndexByte(b, 0x00)
+				if n < 0 {
+					return "", fmt.Errorf("unterminated string in element %d", i)
+				}
+
+				buf = append(buf, b[:n]...)
+				b = b[n+1:]
+
+				// An escaped 0x00 is part of the string, so keep going.
+				if len(b) > 0 && b[0] == 0xFF {
+					buf = append(buf, 0x00)
+					b = b[1:]
+					continue
+				}
+
+				break
+			}
+			elems = append(elems, string(buf))
+
+		case "uint64":
+			if len(b) < 8 {
+				return "", fmt.Errorf("short uint64 in element %d", i)
+			}
+			elems = append(elems, strconv.FormatUint(binary.BigEndian.Uint64(b[:8]), 10))
+			b = b[8:]
+		}
+	}
+
+	return strings.Join(elems, "/"), nil
+}
+
+func (c *TupleCodec) Parse(s string) (Key, error) {
+	if s == "" {
+		return ZeroKey, nil
+	}
+
+	parts := strings.Split(s, "/")
+	if len(parts) > len(c.types) {
+		return ZeroKey, fmt.Errorf("expected at most %d elements, got %d", len(c.types), len(parts))
+	}
+
+	var b []byte
+	for i, p := range parts {
+		switch c.types[i] {
+		case "string":
+			b = append(b, bytes.ReplaceAll([]byte(p), []byte{0x00}, []byte{0x00, 0xFF})...)
+			b = append(b, 0x00)
+
+		case "uint64":
+			n, err := strconv.ParseUint(p, 10, 64)
+			if err != nil {
+				return ZeroKey, fmt.Errorf("element %d: %w", i, err)
+			}
+			b = append(b, []byte(Uint64Key(n))...)
+		}
+	}
+
+	return Key(b), nil
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCodecs(t *testing.T) {
+	tuple, err := KeyCodecByName("tuple:string,uint64")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		codec KeyCodec
+		text  string
+		key   Key
+	}{
+		{RawCodec{}, "ccc", Key("ccc")},
+		{HexCodec{}, "00ff10", Key("\x00\xff\x10")},
+		{Base64Codec{}, "Y2Nj", Key("ccc")},
+		{Uint64Codec{}, "258", Key("\x00\x00\x00\x00\x00\x00\x01\x02")},
+		{tuple, "adam/258", Key("adam\x00\x00\x00\x00\x00\x00\x00\x01\x02")},
+		{tuple, "adam", Key("adam\x00")},
+		{tuple, "a\x00b", Key("a\x00\xffb\x00")},
+	} {
+		k, err := tc.codec.Parse(tc.text)
+		require.NoError(t, err)
+		require.Equal(t, tc.key, k)
+
+		s, err := tc.codec.Format(tc.key)
+		require.NoError(t, err)
+		require.Equal(t, tc.text, s)
+	}
+}
+
+func TestKeyCodecs_Invalid(t *testing.T) {
+	_, err := KeyCodecByName("tuple:string,float")
+	require.Error(t, err)
+
+	_, err = Uint64Codec{}.Format(Key("ccc"))
+	require.Error(t, err)
+
+	tuple, err := NewTupleCodec("string", "uint64")
+	require.NoError(t, err)
+	_, err = tuple.Format(Key("adam\x00\x01"))
+	require.Error(t, err)
+	_, err = tuple.Parse("adam/1/2")
+	require.Error(t, err)
+
+	// Keys which can't be formatted fall back to hex, which can be parsed by
+	// any codec.
+	require.Equal(t, "hex:636363", FormatKey(Uint64Codec{}, Key("ccc")))
+	k, err := ParseKey(Uint64Codec{}, "hex:636363")
+	require.NoError(t, err)
+	require.Equal(t, Key("ccc"), k)
+	require.Equal(t, "", FormatKey(Uint64Codec{}, ZeroKey))
+}
+
+func TestTupleCodec_Order(t *testing.T) {
+	tuple, err := NewTupleCodec("string", "uint64")
+	require.NoError(t, err)
+
+	// In the order they should sort.
+	texts := []string{
+		"a",
+		"a/2",
+		"a/256",
+		"a\x00",
+		"ab",
+		"ab/1",
+		"b",
+	}
+
+	keys := make([]Key, len(texts))
+	for i := range texts {
+		keys[i], err = tuple.Parse(texts[i])
+		require.NoError(t, err)
+	}
+
+	sorted := make([]Key, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	require.Equal(t, keys, sorted)
+}
+
+func TestMetaFormat(t *testing.T) {
+	m := Meta{Ident: 1, Start: Uint64Key(100), End: Uint64Key(200)}
+	require.Equal(t, "1 (100, 200]", m.Format(Uint64Codec{}))
+	require.Equal(t, "1 [-inf, 200]", Meta{Ident: 1, End: Key("200")}.String())
+}
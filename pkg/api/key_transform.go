@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// KeyTransform maps the keys which clients use into the keys which ranges are
+// defined over. The controller knows nothing about it; it just splits and joins
+// whatever keys it's given. But every rangelet and mirror in a cluster must use
+// the same one, or they will disagree about which range a key is in. Split keys
+// returned via LoadInfo must already be transformed.
+//
+// The nil KeyTransform, which is the default, is the identity.
+type KeyTransform func(Key) Key
+
+// Apply returns the given key after transforming it.
+func (t KeyTransform) Apply(k Key) Key {
+	if t == nil {
+		return k
+	}
+
+	return t(k)
+}
+
+// HashKey is a KeyTransform which replaces each key with a stable 64-bit hash
+// of it, as sixteen hex digits. This spreads sequential keys (e.g. timestamps
+// or auto-increment IDs) evenly across ranges, rather than piling them all into
+// the last one, at the cost of scans across ranges being meaningless.
+//
+// Ranges over hashed keys look like [-inf, 8000000000000000) and so on. Never
+// change this function! Doing so would move every key to some other range.
+func HashKey(k Key) Key {
+	h := fnv.New64a()
+	h.Write([]byte(k))
+	return Key(fmt.Sprintf("%016x", h.Sum64()))
+}
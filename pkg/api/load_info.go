@@ -1,6 +1,178 @@
 package api
 
+import (
+	"math"
+	"sort"
+)
+
+// LoadInfo is the load which a range is applying to the node it's placed on,
+// as reported by the node via Node.GetLoadInfo. It's also used for the total
+// load of a node, and for the capacity of a node (see Rangelet.SetCapacity),
+// in which case Splits is unused.
 type LoadInfo struct {
-	Keys   int
+
+	// Number of keys which the range contains.
+	Keys int
+
+	// Number of bytes which the range is storing, on disk or in memory.
+	Bytes int
+
+	// Number of requests per second which the range is serving.
+	QPS float64
+
+	// Amount of CPU which the range is using, in cores.
+	CPU float64
+
+	// Amount of memory which the range is using, in bytes.
+	Memory int
+
+	// Any other service-specific metrics, by name. These must not have the
+	// same names as the standard ones, above.
+	Metrics map[string]float64
+
+	// Where the node would suggest that the range be split, in order for the
+	// resulting ranges to be evenly loaded.
 	Splits []Key
 }
+
+// Standard metric names, as accepted by Metric.
+const (
+	MetricKeys   = "keys"
+	MetricBytes  = "bytes"
+	MetricQPS    = "qps"
+	MetricCPU    = "cpu"
+	MetricMemory = "memory"
+)
+
+// Metric returns the value of the given metric, which may be one of the
+// standard ones or a custom one. Missing metrics are zero.
+func (li LoadInfo) Metric(name string) float64 {
+	switch name {
+	case MetricKeys:
+		return float64(li.Keys)
+	case MetricBytes:
+		return float64(li.Bytes)
+	case MetricQPS:
+		return li.QPS
+	case MetricCPU:
+		return li.CPU
+	case MetricMemory:
+		return float64(li.Memory)
+	default:
+		return li.Metrics[name]
+	}
+}
+
+// metricNames returns the names of every metric which is non-zero in li, in a
+// stable order.
+func (li LoadInfo) metricNames() []string {
+	out := []string{}
+	for _, name := range []string{MetricKeys, MetricBytes, MetricQPS, MetricCPU, MetricMemory} {
+		if li.Metric(name) != 0 {
+			out = append(out, name)
+		}
+	}
+
+	custom := []string{}
+	for name, v := range li.Metrics {
+		if v != 0 {
+			custom = append(custom, name)
+		}
+	}
+
+	sort.Strings(custom)
+	return append(out, custom...)
+}
+
+// Add returns the sum of li and other. Splits are dropped, since they make no
+// sense for more than one range.
+func (li LoadInfo) Add(other LoadInfo) LoadInfo {
+	out := LoadInfo{
+		Keys:   li.Keys + other.Keys,
+		Bytes:  li.Bytes + other.Bytes,
+		QPS:    li.QPS + other.QPS,
+		CPU:    li.CPU + other.CPU,
+		Memory: li.Memory + other.Memory,
+	}
+
+	for _, m := range []map[string]float64{li.Metrics, other.Metrics} {
+		for name, v := range m {
+			if out.Metrics == nil {
+				out.Metrics = map[string]float64{}
+			}
+			out.Metrics[name] += v
+		}
+	}
+
+	return out
+}
+
+// Max returns the highest of each metric in li and other. Splits are dropped.
+func (li LoadInfo) Max(other LoadInfo) LoadInfo {
+	out := LoadInfo{
+		Keys:   maxInt(li.Keys, other.Keys),
+		Bytes:  maxInt(li.Bytes, other.Bytes),
+		QPS:    math.Max(li.QPS, other.QPS),
+		CPU:    math.Max(li.CPU, other.CPU),
+		Memory: maxInt(li.Memory, other.Memory),
+	}
+
+	for _, m := range []map[string]float64{li.Metrics, other.Metrics} {
+		for name, v := range m {
+			if out.Metrics == nil {
+				out.Metrics = map[string]float64{}
+			}
+			if cur, ok := out.Metrics[name]; !ok || v > cur {
+				out.Metrics[name] = v
+			}
+		}
+	}
+
+	return out
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Scale returns li with every metric multiplied by f, e.g. to estimate the
+// load of one of the children of a split. Splits are dropped.
+func (li LoadInfo) Scale(f float64) LoadInfo {
+	out := LoadInfo{
+		Keys:   int(float64(li.Keys) * f),
+		Bytes:  int(float64(li.Bytes) * f),
+		QPS:    li.QPS * f,
+		CPU:    li.CPU * f,
+		Memory: int(float64(li.Memory) * f),
+	}
+
+	if li.Metrics != nil {
+		out.Metrics = make(map[string]float64, len(li.Metrics))
+		for name, v := range li.Metrics {
+			out.Metrics[name] = v * f
+		}
+	}
+
+	return out
+}
+
+// Utilization returns the highest fraction of the given capacity which li uses
+// of any metric, and which metric that is. Metrics which have no capacity are
+// ignored, so if capacity is zero, it returns false.
+func (li LoadInfo) Utilization(capacity LoadInfo) (float64, string, bool) {
+	var max float64
+	var metric string
+
+	names := capacity.metricNames()
+	for _, name := range names {
+		if u := li.Metric(name) / capacity.Metric(name); metric == "" || u > max {
+			max = u
+			metric = name
+		}
+	}
+
+	return max, metric, len(names) > 0
+}
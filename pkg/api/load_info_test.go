@@ -0,0 +1,28 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadInfo(t *testing.T) {
+	a := LoadInfo{Keys: 10, Bytes: 100, QPS: 1, Metrics: map[string]float64{"conns": 2}, Splits: []Key{"ccc"}}
+	b := LoadInfo{Keys: 30, CPU: 0.5, Metrics: map[string]float64{"conns": 1, "disk": 3}}
+
+	require.Equal(t, LoadInfo{Keys: 40, Bytes: 100, QPS: 1, CPU: 0.5, Metrics: map[string]float64{"conns": 3, "disk": 3}}, a.Add(b))
+	require.Equal(t, LoadInfo{Keys: 30, Bytes: 100, QPS: 1, CPU: 0.5, Metrics: map[string]float64{"conns": 2, "disk": 3}}, a.Max(b))
+	require.Equal(t, LoadInfo{Keys: 5, Bytes: 50, QPS: 0.5, Metrics: map[string]float64{"conns": 1}}, a.Scale(0.5))
+
+	require.Equal(t, 2.0, a.Metric("conns"))
+	require.Equal(t, 0.0, a.Metric("disk"))
+
+	// Metrics without capacity are ignored.
+	u, m, ok := a.Utilization(LoadInfo{Bytes: 400, Metrics: map[string]float64{"conns": 4}})
+	require.True(t, ok)
+	require.Equal(t, 0.5, u)
+	require.Equal(t, "conns", m)
+
+	_, _, ok = a.Utilization(LoadInfo{})
+	require.False(t, ok)
+}
@@ -15,18 +15,24 @@ type Meta struct {
 
 // String returns a string like: 1234 (aaa, bbb]
 func (m Meta) String() string {
+	return m.Format(RawCodec{})
+}
+
+// Format is like String, but formats the start and end keys with the given
+// codec.
+func (m Meta) Format(c KeyCodec) string {
 	var s, e string
 
 	if m.Start == ZeroKey {
 		s = "[-inf"
 	} else {
-		s = fmt.Sprintf("(%s", m.Start)
+		s = fmt.Sprintf("(%s", FormatKey(c, m.Start))
 	}
 
 	if m.End == ZeroKey {
 		e = "+inf]"
 	} else {
-		e = fmt.Sprintf("%s]", m.End)
+		e = fmt.Sprintf("%s]", FormatKey(c, m.End))
 	}
 
 	return fmt.Sprintf("%s %s, %s", m.Ident.String(), s, e)
@@ -48,3 +54,9 @@ func (m Meta) Contains(k Key) bool {
 
 	return true
 }
+
+// ContainsKey returns whether the range contains the given client key, after it
+// has been transformed by the given func.
+func (m Meta) ContainsKey(t KeyTransform, k Key) bool {
+	return m.Contains(t.Apply(k))
+}
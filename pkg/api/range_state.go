@@ -15,6 +15,17 @@ const (
 	// The range has finished being split or joined, has been dropped from all
 	// nodes, and will never be placed on any node again.
 	RsObsolete
+
+	// The range was created by a split or join which is still in progress. It
+	// should be placed like an active range, but unlike an active range, it's
+	// okay to give up on it if the operation is aborted. It becomes active when
+	// the operation completes.
+	RsNew
+
+	// The range was created by a split or join which was aborted before it was
+	// completed. Its placements are dropped, and it will never be placed on any
+	// node again.
+	RsAborted
 )
 
 //go:generate stringer -type=RangeState -output=zzz_range_state.go
@@ -23,6 +23,12 @@ type Remote struct {
 	Ident string
 	Host  string
 	Port  int
+
+	// Arbitrary key/value pairs describing the remote, from discovery. These
+	// are mostly used to describe failure domains, like the zone or rack which
+	// a node is in, so that placements can be spread across them. See
+	// ranje.ReplicationConfig.SpreadBy.
+	Labels map[string]string
 }
 
 // Addr returns an address which can be dialled to connect to the remote.
@@ -30,6 +36,12 @@ func (r Remote) Addr() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
 }
 
+// Label returns the value of the given label, or an empty string if the remote
+// doesn't have it.
+func (r Remote) Label(key string) string {
+	return r.Labels[key]
+}
+
 // NodeID returns the remote ident as a NodeID, since that's most often how it's
 // used, though it isn't one.
 func (r Remote) NodeID() NodeID {
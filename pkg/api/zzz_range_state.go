@@ -12,11 +12,13 @@ func _() {
 	_ = x[RsActive-1]
 	_ = x[RsSubsuming-2]
 	_ = x[RsObsolete-3]
+	_ = x[RsNew-4]
+	_ = x[RsAborted-5]
 }
 
-const _RangeState_name = "RsUnknownRsActiveRsSubsumingRsObsolete"
+const _RangeState_name = "RsUnknownRsActiveRsSubsumingRsObsoleteRsNewRsAborted"
 
-var _RangeState_index = [...]uint8{0, 9, 17, 28, 38}
+var _RangeState_index = [...]uint8{0, 9, 17, 28, 38, 43, 52}
 
 func (i RangeState) String() string {
 	if i >= RangeState(len(_RangeState_index)-1) {
@@ -0,0 +1,532 @@
+package balancer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/keyspace"
+	"github.com/adammck/ranger/pkg/orchestrator"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/adammck/ranger/pkg/roster"
+)
+
+// Config controls when the balancer splits, joins, and moves ranges. The load
+// of a range is the Metric reported by the most loaded of its active
+// placements, and the load of a node is the sum of its active placements.
+type Config struct {
+	// The metric which the load of each range is measured by, e.g. "bytes" or
+	// "cpu", or a custom one. See api.LoadInfo.Metric. Empty means keys. The
+	// thresholds below are all in the units of this metric.
+	Metric string
+
+	// Ranges with a load above this are split at the first split point which
+	// the node suggests. Ranges with no suggested split points are left alone.
+	// Zero disables splitting.
+	SplitAbove float64
+
+	// Adjacent ranges with a combined load below this are joined. This must be
+	// below SplitAbove, so that the joined range isn't immediately split again.
+	// Zero disables joining.
+	JoinBelow float64
+
+	// Nodes with a load above NodeAbove have ranges moved off them until their
+	// load is below NodeBelow. Ranges are only moved to nodes which will still
+	// be below NodeBelow afterwards. Zero disables moving.
+	NodeAbove float64
+	NodeBelow float64
+
+	// How many consecutive cycles a range or node must be over (or under) one
+	// of the thresholds before anything is done about it, so that brief spikes
+	// are ignored. Zero or one means right away.
+	Cycles int
+
+	// The maximum number of operations to initiate in each cycle. Zero means no
+	// limit.
+	MaxOps int
+}
+
+// Validate returns an error if the thresholds don't make sense together.
+func (cfg Config) Validate() error {
+	if cfg.SplitAbove < 0 || cfg.JoinBelow < 0 || cfg.NodeAbove < 0 || cfg.NodeBelow < 0 || cfg.Cycles < 0 || cfg.MaxOps < 0 {
+		return errors.New("balancer config can't be negative")
+	}
+
+	if cfg.SplitAbove > 0 && cfg.JoinBelow >= cfg.SplitAbove {
+		return fmt.Errorf("join threshold (%v) must be below split threshold (%v)", cfg.JoinBelow, cfg.SplitAbove)
+	}
+
+	if cfg.NodeAbove > 0 && (cfg.NodeBelow == 0 || cfg.NodeBelow >= cfg.NodeAbove) {
+		return fmt.Errorf("node low threshold (%v) must be above zero and below high threshold (%v)", cfg.NodeBelow, cfg.NodeAbove)
+	}
+
+	return nil
+}
+
+// Operator is the part of the orchestrator which the balancer initiates
+// operations via.
+type Operator interface {
+	QueueMove(orchestrator.OpMove)
+	QueueSplit(orchestrator.OpSplit)
+	QueueJoin(orchestrator.OpJoin)
+}
+
+// Balancer periodically examines the load of every active range and node, and
+// splits, joins, and moves ranges to keep it within the configured thresholds.
+// The operations are initiated via the orchestrator, just like the ones which
+// operators initiate via rangerctl.
+type Balancer struct {
+	ks   *keyspace.Keyspace
+	rost *roster.Roster
+	op   Operator
+	cfg  Config
+
+	// How many consecutive cycles each condition (e.g. "split R1") has been
+	// observed for. Only touched by Tick.
+	streaks map[string]int
+
+	// Ranges involved in operations which the balancer initiated and which
+	// haven't finished yet. These are left alone until they do.
+	inFlight   map[api.RangeID]struct{}
+	inFlightMu sync.Mutex
+}
+
+func New(ks *keyspace.Keyspace, rost *roster.Roster, op Operator, cfg Config) (*Balancer, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Balancer{
+		ks:       ks,
+		rost:     rost,
+		op:       op,
+		cfg:      cfg,
+		streaks:  map[string]int{},
+		inFlight: map[api.RangeID]struct{}{},
+	}, nil
+}
+
+// Run calls Tick every time the given ticker fires. Unlike the orchestrator, it
+// waits for the first tick, so that nodes have a chance to report their load.
+func (b *Balancer) Run(t *time.Ticker) {
+	for range t.C {
+		b.Tick()
+	}
+}
+
+// op is an operation which the balancer has decided to initiate.
+type op struct {
+	desc  string
+	rIDs  []api.RangeID
+	queue func(chan error)
+}
+
+// Tick examines the current load, and queues any operations which are needed,
+// up to the per-cycle limit. They're initiated by the next orchestrator tick.
+func (b *Balancer) Tick() {
+	for _, o := range b.plan() {
+		b.inFlightMu.Lock()
+		for _, rID := range o.rIDs {
+			b.inFlight[rID] = struct{}{}
+		}
+		b.inFlightMu.Unlock()
+
+		log.Printf("balancer: %s", o.desc)
+
+		ch := make(chan error, 1)
+		o.queue(ch)
+		go b.await(o, ch)
+	}
+}
+
+// await blocks until the given operation finishes, then logs any error and
+// releases the ranges involved, so they can be balanced again.
+func (b *Balancer) await(o op, ch chan error) {
+	for err := range ch {
+		log.Printf("balancer: error from %s: %v", o.desc, err)
+	}
+
+	b.inFlightMu.Lock()
+	defer b.inFlightMu.Unlock()
+	for _, rID := range o.rIDs {
+		delete(b.inFlight, rID)
+	}
+}
+
+// rangeLoad is the load of a single active range.
+type rangeLoad struct {
+	r     *ranje.Range
+	load  float64
+	split api.Key
+	nodes []api.NodeID
+}
+
+// load returns the single number which the balancer compares to thresholds.
+func (cfg Config) load(info api.LoadInfo) float64 {
+	if cfg.Metric == "" {
+		return info.Metric(api.MetricKeys)
+	}
+
+	return info.Metric(cfg.Metric)
+}
+
+// plan returns the operations to initiate this cycle, in the order they should
+// be queued: splits of the hottest ranges first, then moves off the busiest
+// nodes, then joins of the coldest ranges.
+func (b *Balancer) plan() []op {
+	rs, unlock := b.ks.RangesInState(api.RsActive)
+	defer unlock()
+
+	b.rost.RLock()
+	defer b.rost.RUnlock()
+
+	// Ranges which are already involved in an operation (either in flight or
+	// planned this cycle) aren't touched again.
+	busy := map[api.RangeID]struct{}{}
+	func() {
+		b.inFlightMu.Lock()
+		defer b.inFlightMu.Unlock()
+		for rID := range b.inFlight {
+			busy[rID] = struct{}{}
+		}
+	}()
+
+	nodeLoad := map[api.NodeID]float64{}
+	for nID := range b.rost.Nodes {
+		nodeLoad[nID] = 0
+	}
+
+	loads := []*rangeLoad{}
+	for _, r := range rs {
+		rl := &rangeLoad{r: r}
+
+		for _, p := range r.Placements {
+			if p.StateCurrent != api.PsActive {
+				continue
+			}
+
+			n, ok := b.rost.Nodes[p.NodeID]
+			if !ok {
+				continue
+			}
+
+			ri, ok := n.Get(r.Meta.Ident)
+			if !ok {
+				continue
+			}
+
+			l := b.cfg.load(ri.Info)
+			nodeLoad[p.NodeID] += l
+
+			if len(rl.nodes) == 0 || l > rl.load {
+				rl.load = l
+				rl.split = api.ZeroKey
+				if len(ri.Info.Splits) > 0 {
+					rl.split = ri.Info.Splits[0]
+				}
+			}
+
+			rl.nodes = append(rl.nodes, p.NodeID)
+		}
+
+		// No active placements, so no idea what the load is.
+		if len(rl.nodes) == 0 {
+			continue
+		}
+
+		loads = append(loads, rl)
+	}
+
+	c := &cycle{
+		b:        b,
+		loads:    loads,
+		nodeLoad: nodeLoad,
+		busy:     busy,
+		streaks:  map[string]int{},
+	}
+
+	if b.cfg.SplitAbove > 0 {
+		c.planSplits()
+	}
+
+	if b.cfg.NodeAbove > 0 {
+		c.planMoves()
+	}
+
+	if b.cfg.JoinBelow > 0 {
+		c.planJoins()
+	}
+
+	// Conditions which weren't observed this cycle are forgotten, so only
+	// consecutive cycles count towards Config.Cycles.
+	b.streaks = c.streaks
+
+	return c.ops
+}
+
+// cycle is the state of a single call to plan.
+type cycle struct {
+	b        *Balancer
+	loads    []*rangeLoad
+	nodeLoad map[api.NodeID]float64
+	busy     map[api.RangeID]struct{}
+	streaks  map[string]int
+	ops      []op
+}
+
+// sustained records that the given condition was observed this cycle, and
+// returns whether it has been observed for long enough to act on.
+func (c *cycle) sustained(key string) bool {
+	c.streaks[key] = c.b.streaks[key] + 1
+	return c.streaks[key] >= c.b.cfg.Cycles
+}
+
+// act records that the given condition was acted on, so the streak restarts.
+func (c *cycle) act(key string, o op) {
+	for _, rID := range o.rIDs {
+		c.busy[rID] = struct{}{}
+	}
+
+	delete(c.streaks, key)
+	c.ops = append(c.ops, o)
+}
+
+// budget returns whether any more operations can be initiated this cycle.
+func (c *cycle) budget() bool {
+	return c.b.cfg.MaxOps == 0 || len(c.ops) < c.b.cfg.MaxOps
+}
+
+func (c *cycle) isBusy(rIDs ...api.RangeID) bool {
+	for _, rID := range rIDs {
+		if _, ok := c.busy[rID]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *cycle) planSplits() {
+	b := c.b
+
+	hot := []*rangeLoad{}
+	for _, rl := range c.loads {
+		if rl.load > b.cfg.SplitAbove && rl.split != api.ZeroKey {
+			if c.sustained(fmt.Sprintf("split R%s", rl.r.Meta.Ident)) {
+				hot = append(hot, rl)
+			}
+		}
+	}
+
+	sort.SliceStable(hot, func(i, j int) bool {
+		return hot[i].load > hot[j].load
+	})
+
+	for _, rl := range hot {
+		if !c.budget() {
+			break
+		}
+
+		rID := rl.r.Meta.Ident
+		if c.isBusy(rID) {
+			continue
+		}
+
+		split := rl.split
+		c.act(fmt.Sprintf("split R%s", rID), op{
+			desc: fmt.Sprintf("split R%s at %q (load=%v)", rID, split, rl.load),
+			rIDs: []api.RangeID{rID},
+			queue: func(ch chan error) {
+				b.op.QueueSplit(orchestrator.OpSplit{
+					Range: rID,
+					Keys:  []api.Key{split},
+					Err:   ch,
+				})
+			},
+		})
+	}
+}
+
+func (c *cycle) planMoves() {
+	b := c.b
+	nodeLoad := c.nodeLoad
+
+	// Sort by ident first, so that ties are broken the same way every time.
+	nIDs := make([]api.NodeID, 0, len(nodeLoad))
+	for nID := range nodeLoad {
+		nIDs = append(nIDs, nID)
+	}
+	sort.Slice(nIDs, func(i, j int) bool {
+		return nIDs[i] < nIDs[j]
+	})
+
+	overloaded := []api.NodeID{}
+	for _, nID := range nIDs {
+		if nodeLoad[nID] > b.cfg.NodeAbove {
+			if c.sustained(fmt.Sprintf("node %s", nID)) {
+				overloaded = append(overloaded, nID)
+			}
+		}
+	}
+
+	sort.SliceStable(overloaded, func(i, j int) bool {
+		return nodeLoad[overloaded[i]] > nodeLoad[overloaded[j]]
+	})
+
+	for _, src := range overloaded {
+		key := fmt.Sprintf("node %s", src)
+
+		// Move the biggest ranges which fit somewhere first, to get the node
+		// under the threshold in as few moves as possible.
+		candidates := []*rangeLoad{}
+		for _, rl := range c.loads {
+			for _, nID := range rl.nodes {
+				if nID == src {
+					candidates = append(candidates, rl)
+					break
+				}
+			}
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].load > candidates[j].load
+		})
+
+		for _, rl := range candidates {
+			if nodeLoad[src] < b.cfg.NodeBelow || !c.budget() {
+				break
+			}
+
+			rID := rl.r.Meta.Ident
+			if c.isBusy(rID) {
+				continue
+			}
+
+			dest := b.leastLoaded(nIDs, nodeLoad, rl.r)
+			if dest == "" || nodeLoad[dest]+rl.load >= b.cfg.NodeBelow {
+				continue
+			}
+
+			src := src
+			nodeLoad[src] -= rl.load
+			nodeLoad[dest] += rl.load
+
+			c.act(key, op{
+				desc: fmt.Sprintf("move R%s from %s to %s (load=%v)", rID, src, dest, rl.load),
+				rIDs: []api.RangeID{rID},
+				queue: func(ch chan error) {
+					b.op.QueueMove(orchestrator.OpMove{
+						Range: rID,
+						Src:   src,
+						Dest:  dest,
+						Err:   ch,
+					})
+				},
+			})
+		}
+	}
+}
+
+// leastLoaded returns the node with the lowest load which the given range could
+// be moved to, or the zero NodeID if there isn't one. Caller must hold the
+// roster lock.
+func (b *Balancer) leastLoaded(nIDs []api.NodeID, nodeLoad map[api.NodeID]float64, r *ranje.Range) api.NodeID {
+	var best api.NodeID
+
+	for _, nID := range nIDs {
+		if b.rost.Nodes[nID].WantDrain() || b.rost.AdminState(nID).Cordoned() {
+			continue
+		}
+
+		// Skip nodes which already have any placement of the range, not just
+		// an active one.
+		has := false
+		for _, p := range r.Placements {
+			if p.NodeID == nID {
+				has = true
+				break
+			}
+		}
+		if has {
+			continue
+		}
+
+		if best == "" || nodeLoad[nID] < nodeLoad[best] {
+			best = nID
+		}
+	}
+
+	return best
+}
+
+func (c *cycle) planJoins() {
+	sorted := make([]*rangeLoad, len(c.loads))
+	copy(sorted, c.loads)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].r.Meta.Start < sorted[j].r.Meta.Start
+	})
+
+	type pair struct {
+		left, right *rangeLoad
+		key         string
+	}
+
+	cold := []pair{}
+	for i := 0; i < len(sorted)-1; i++ {
+		left, right := sorted[i], sorted[i+1]
+
+		// Ranges without load info are missing from the list, so the next one
+		// might not be the neighbour.
+		if left.r.Meta.End != right.r.Meta.Start {
+			continue
+		}
+
+		if left.load+right.load < c.b.cfg.JoinBelow {
+			key := fmt.Sprintf("join R%s R%s", left.r.Meta.Ident, right.r.Meta.Ident)
+			if c.sustained(key) {
+				cold = append(cold, pair{left, right, key})
+			}
+		}
+	}
+
+	sort.SliceStable(cold, func(i, j int) bool {
+		return cold[i].left.load+cold[i].right.load < cold[j].left.load+cold[j].right.load
+	})
+
+	for _, p := range cold {
+		if !c.budget() {
+			break
+		}
+
+		rIDs := []api.RangeID{p.left.r.Meta.Ident, p.right.r.Meta.Ident}
+		if c.isBusy(rIDs...) {
+			continue
+		}
+
+		c.act(p.key, op{
+			desc: fmt.Sprintf("join %s (load=%v)", rangeIDs(rIDs), p.left.load+p.right.load),
+			rIDs: rIDs,
+			queue: func(ch chan error) {
+				c.b.op.QueueJoin(orchestrator.OpJoin{
+					Ranges: rIDs,
+					Err:    ch,
+				})
+			},
+		})
+	}
+}
+
+func rangeIDs(rIDs []api.RangeID) string {
+	s := make([]string, len(rIDs))
+	for i := range rIDs {
+		s[i] = "R" + rIDs[i].String()
+	}
+
+	return strings.Join(s, " and ")
+}
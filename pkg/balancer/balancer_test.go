@@ -0,0 +1,260 @@
+package balancer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	mock_disc "github.com/adammck/ranger/pkg/discovery/mock"
+	"github.com/adammck/ranger/pkg/keyspace"
+	"github.com/adammck/ranger/pkg/orchestrator"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/adammck/ranger/pkg/roster"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{SplitAbove: 100, Cycles: 2}, []api.NodeID{"aaa"}, []rangeStub{
+		{end: "", node: "aaa", keys: 150, splits: []api.Key{"mmm", "ppp"}},
+	})
+
+	// Not sustained for long enough yet.
+	b.Tick()
+	require.Empty(t, op.ops)
+
+	b.Tick()
+	require.Equal(t, []string{"Split(R1, mmm)"}, op.ops)
+
+	// Still in flight, so left alone.
+	b.Tick()
+	b.Tick()
+	require.Equal(t, []string{"Split(R1, mmm)"}, op.ops)
+}
+
+func TestSplit_Metric(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{Metric: api.MetricCPU, SplitAbove: 0.5, JoinBelow: 0.2}, []api.NodeID{"aaa"}, []rangeStub{
+		{end: "ggg", node: "aaa", keys: 10, cpu: 0.1},
+		{end: "ppp", node: "aaa", keys: 10, cpu: 0.05},
+		{end: "", node: "aaa", keys: 10, cpu: 0.8, splits: []api.Key{"sss"}},
+	})
+
+	// Only the CPU is compared, not the number of keys.
+	b.Tick()
+	require.Equal(t, []string{"Split(R3, sss)", "Join([1 2])"}, op.ops)
+}
+
+func TestSplit_NoSplitPoint(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{SplitAbove: 100}, []api.NodeID{"aaa"}, []rangeStub{
+		{end: "", node: "aaa", keys: 150},
+	})
+
+	b.Tick()
+	require.Empty(t, op.ops)
+}
+
+func TestSplit_Hysteresis(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{SplitAbove: 100, Cycles: 2}, []api.NodeID{"aaa"}, []rangeStub{
+		{end: "", node: "aaa", keys: 150, splits: []api.Key{"mmm"}},
+	})
+
+	// A single cycle over the threshold, then one under it, resets the count.
+	b.Tick()
+	setLoad(t, b, "aaa", 1, 50)
+	b.Tick()
+	setLoad(t, b, "aaa", 1, 150)
+	b.Tick()
+	require.Empty(t, op.ops)
+
+	b.Tick()
+	require.Equal(t, []string{"Split(R1, mmm)"}, op.ops)
+}
+
+func TestJoin(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{SplitAbove: 100, JoinBelow: 20}, []api.NodeID{"aaa"}, []rangeStub{
+		{end: "ggg", node: "aaa", keys: 5},
+		{end: "ppp", node: "aaa", keys: 30},
+		{end: "sss", node: "aaa", keys: 3},
+		{end: "", node: "aaa", keys: 4},
+	})
+
+	// Only the coldest pair; the second pair overlaps with it.
+	b.Tick()
+	require.Equal(t, []string{"Join([3 4])"}, op.ops)
+}
+
+func TestMove(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{NodeAbove: 100, NodeBelow: 80}, []api.NodeID{"aaa", "bbb", "ccc"}, []rangeStub{
+		{end: "ggg", node: "aaa", keys: 60},
+		{end: "ppp", node: "aaa", keys: 50},
+		{end: "sss", node: "bbb", keys: 10},
+		{end: "", node: "ccc", keys: 30},
+	})
+
+	// Moving R1 to the least loaded node is enough to get aaa under NodeBelow.
+	b.Tick()
+	require.Equal(t, []string{"Move(R1, aaa, bbb)"}, op.ops)
+}
+
+func TestMove_NoRoom(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{NodeAbove: 100, NodeBelow: 80}, []api.NodeID{"aaa", "bbb"}, []rangeStub{
+		{end: "ggg", node: "aaa", keys: 90},
+		{end: "", node: "aaa", keys: 90},
+	})
+
+	// Moving either range would just make bbb overloaded instead.
+	b.Tick()
+	require.Empty(t, op.ops)
+}
+
+func TestMaxOps(t *testing.T) {
+	op := &fakeOperator{}
+	b := setup(t, op, Config{SplitAbove: 100, MaxOps: 2}, []api.NodeID{"aaa"}, []rangeStub{
+		{end: "ggg", node: "aaa", keys: 110, splits: []api.Key{"ccc"}},
+		{end: "ppp", node: "aaa", keys: 300, splits: []api.Key{"jjj"}},
+		{end: "", node: "aaa", keys: 200, splits: []api.Key{"sss"}},
+	})
+
+	// Hottest first.
+	b.Tick()
+	require.Equal(t, []string{"Split(R2, jjj)", "Split(R3, sss)"}, op.ops)
+
+	// Once an operation finishes, its range can be balanced again. (In reality
+	// it would be obsolete by now, but the balancer doesn't care.)
+	close(op.errs[0])
+	require.Eventually(t, func() bool {
+		b.inFlightMu.Lock()
+		defer b.inFlightMu.Unlock()
+		return len(b.inFlight) == 1
+	}, time.Second, time.Millisecond)
+
+	b.Tick()
+	require.Equal(t, []string{"Split(R2, jjj)", "Split(R3, sss)", "Split(R2, jjj)", "Split(R1, ccc)"}, op.ops)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{SplitAbove: 100, JoinBelow: 20, NodeAbove: 100, NodeBelow: 80}.Validate())
+	require.EqualError(t, Config{SplitAbove: 100, JoinBelow: 100}.Validate(), "join threshold (100) must be below split threshold (100)")
+	require.EqualError(t, Config{NodeAbove: 100}.Validate(), "node low threshold (0) must be above zero and below high threshold (100)")
+	require.EqualError(t, Config{MaxOps: -1}.Validate(), "balancer config can't be negative")
+}
+
+// ----
+
+type rangeStub struct {
+	end    api.Key // empty means +inf
+	node   api.NodeID
+	keys   int
+	cpu    float64
+	splits []api.Key
+}
+
+// setup returns a balancer for a keyspace containing one active range per stub,
+// in order, each with a single active placement on the given node reporting
+// the given load.
+func setup(t *testing.T, op Operator, cfg Config, nIDs []api.NodeID, stubs []rangeStub) *Balancer {
+	ranges := make([]*ranje.Range, len(stubs))
+	for i, stub := range stubs {
+		r := ranje.NewRange(api.RangeID(i+1), nil)
+		r.State = api.RsActive
+		r.Meta.End = stub.end
+		if i > 0 {
+			r.Meta.Start = stubs[i-1].end
+		}
+
+		r.Placements = []*ranje.Placement{{
+			NodeID:       stub.node,
+			StateCurrent: api.PsActive,
+			StateDesired: api.PsActive,
+		}}
+
+		ranges[i] = r
+	}
+
+	ks, err := keyspace.New(&fakePersister{ranges: ranges}, ranje.R1)
+	require.NoError(t, err)
+
+	disc := mock_disc.NewDiscoverer()
+	for _, nID := range nIDs {
+		disc.Add("node", api.Remote{
+			Ident: string(nID),
+			Host:  fmt.Sprintf("host-%s", nID),
+			Port:  1,
+		})
+	}
+
+	rost := roster.New(disc, nil, nil, nil)
+	rost.Discover()
+
+	for i, stub := range stubs {
+		rost.Nodes[stub.node].UpdateRangeInfo(&api.RangeInfo{
+			Meta:  ranges[i].Meta,
+			State: api.NsActive,
+			Info: api.LoadInfo{
+				Keys:   stub.keys,
+				CPU:    stub.cpu,
+				Splits: stub.splits,
+			},
+		})
+	}
+
+	b, err := New(ks, rost, op, cfg)
+	require.NoError(t, err)
+
+	return b
+}
+
+func setLoad(t *testing.T, b *Balancer, nID api.NodeID, rID api.RangeID, keys int) {
+	n, err := b.rost.NodeByIdent(nID)
+	require.NoError(t, err)
+
+	ri, ok := n.Get(rID)
+	require.True(t, ok)
+
+	ri.Info.Keys = keys
+	n.UpdateRangeInfo(&ri)
+}
+
+type fakeOperator struct {
+	ops  []string
+	errs []chan error
+}
+
+func (o *fakeOperator) QueueMove(op orchestrator.OpMove) {
+	o.ops = append(o.ops, fmt.Sprintf("Move(R%s, %s, %s)", op.Range, op.Src, op.Dest))
+	o.errs = append(o.errs, op.Err)
+}
+
+func (o *fakeOperator) QueueSplit(op orchestrator.OpSplit) {
+	o.ops = append(o.ops, fmt.Sprintf("Split(R%s, %s)", op.Range, op.Keys[0]))
+	o.errs = append(o.errs, op.Err)
+}
+
+func (o *fakeOperator) QueueJoin(op orchestrator.OpJoin) {
+	o.ops = append(o.ops, fmt.Sprintf("Join(%v)", op.Ranges))
+	o.errs = append(o.errs, op.Err)
+}
+
+type fakePersister struct {
+	ranges []*ranje.Range
+}
+
+func (fp *fakePersister) GetRanges() ([]*ranje.Range, error) {
+	return fp.ranges, nil
+}
+
+func (fp *fakePersister) PutRanges([]*ranje.Range) error {
+	return nil
+}
+
+func (fp *fakePersister) DeleteRanges([]*ranje.Range) error {
+	return nil
+}
@@ -1,6 +1,7 @@
 package consul
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -82,15 +83,19 @@ func (dg *discoveryGetter) tick() error {
 		svcID := r.ServiceID
 		seen[svcID] = struct{}{}
 
-		// Already known
-		if _, ok := dg.remotes[svcID]; ok {
+		// Already known. Only the labels can change without the service ID
+		// changing, so update them without calling add.
+		if rem, ok := dg.remotes[svcID]; ok {
+			rem.Labels = labels(r)
+			dg.remotes[svcID] = rem
 			continue
 		}
 
 		rem := api.Remote{
-			Ident: svcID,
-			Host:  r.Address, // https://github.com/hashicorp/consul/issues/2076
-			Port:  r.ServicePort,
+			Ident:  svcID,
+			Host:   r.Address, // https://github.com/hashicorp/consul/issues/2076
+			Port:   r.ServicePort,
+			Labels: labels(r),
 		}
 
 		// New remote
@@ -129,6 +134,24 @@ func (dg *discoveryGetter) tick() error {
 	return nil
 }
 
+// labels returns the labels of the given service, from its metadata, and from
+// any of its tags which look like key=value. Metadata wins if both are present.
+func labels(svc *consulapi.CatalogService) map[string]string {
+	out := map[string]string{}
+
+	for _, tag := range svc.ServiceTags {
+		if k, v, ok := strings.Cut(tag, "="); ok {
+			out[k] = v
+		}
+	}
+
+	for k, v := range svc.ServiceMeta {
+		out[k] = v
+	}
+
+	return out
+}
+
 func (dg *discoveryGetter) run() {
 	ticker := time.NewTicker(1 * time.Second)
 
@@ -18,6 +18,7 @@ type Discovery struct {
 	port    int
 	consul  *consulapi.Client
 	hs      *health.Server
+	labels  map[string]string
 }
 
 func (d *Discovery) getIdent() string {
@@ -61,10 +62,18 @@ func New(serviceName, addr string, cfg *consulapi.Config, srv *grpc.Server) (*Di
 	return d, nil
 }
 
+// SetLabels sets the labels which the service is registered with, as service
+// metadata, e.g. to tell the controller which zone a node is in. See
+// api.Remote.Labels. This must be called before Start.
+func (d *Discovery) SetLabels(labels map[string]string) {
+	d.labels = labels
+}
+
 func (d *Discovery) Start() error {
 	def := &consulapi.AgentServiceRegistration{
 		Name: d.svcName,
 		ID:   d.getIdent(),
+		Meta: d.labels,
 
 		// How other nodes should call the service.
 		Address: d.host,
@@ -0,0 +1,212 @@
+package consul
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/adammck/ranger/pkg/election"
+	capi "github.com/hashicorp/consul/api"
+)
+
+// DefaultTTL is how long the session of a leader which stops renewing it (e.g.
+// because it crashed, or was partitioned from Consul) lasts, before the lock is
+// released and a standby can be elected.
+const DefaultTTL = 15 * time.Second
+
+// Elector campaigns by acquiring a lock on a Consul key, with a session which
+// is renewed for as long as the term lasts. The value of the key is the address
+// of the leader.
+type Elector struct {
+	client *capi.Client
+	key    string
+	addr   string
+	ttl    time.Duration
+}
+
+func New(client *capi.Client, key, addr string) *Elector {
+	return &Elector{
+		client: client,
+		key:    key,
+		addr:   addr,
+		ttl:    DefaultTTL,
+	}
+}
+
+// interface
+
+func (e *Elector) Campaign(ctx context.Context) (election.Term, error) {
+	sess := e.client.Session()
+
+	id, _, err := sess.Create(&capi.SessionEntry{
+		Name:     fmt.Sprintf("%s (%s)", e.key, e.addr),
+		TTL:      e.ttl.String(),
+		Behavior: capi.SessionBehaviorRelease,
+	}, (&capi.WriteOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	// Keep the session alive while campaigning, and then for the duration of
+	// the term. Closing done destroys the session, which releases the lock.
+	done := make(chan struct{})
+	renewed := make(chan error, 1)
+	go func() {
+		renewed <- sess.RenewPeriodic(e.ttl.String(), id, nil, done)
+	}()
+
+	kv := e.client.KV()
+	var idx uint64
+
+	for {
+		ok, _, err := kv.Acquire(&capi.KVPair{
+			Key:     e.key,
+			Value:   []byte(e.addr),
+			Session: id,
+		}, (&capi.WriteOptions{}).WithContext(ctx))
+		if err != nil {
+			close(done)
+			return nil, fmt.Errorf("error acquiring lock: %w", err)
+		}
+
+		if ok {
+			// The index at which the lock was acquired is the epoch. Raft
+			// indices only go up, so every later term will have a greater one.
+			pair, _, err := kv.Get(e.key, (&capi.QueryOptions{RequireConsistent: true}).WithContext(ctx))
+			if err != nil {
+				close(done)
+				return nil, fmt.Errorf("error reading lock: %w", err)
+			}
+
+			if pair != nil && pair.Session == id {
+				t := &term{
+					kv:      kv,
+					key:     e.key,
+					session: id,
+					epoch:   pair.ModifyIndex,
+					done:    done,
+					lost:    make(chan struct{}),
+				}
+
+				go t.monitor(renewed, pair.ModifyIndex)
+				return t, nil
+			}
+
+			// Lost the lock already. Campaign again.
+		}
+
+		// Wait until the key changes, which it will when the lock is released,
+		// or until the session expires.
+		_, meta, err := kv.Get(e.key, (&capi.QueryOptions{WaitIndex: idx}).WithContext(ctx))
+		if err != nil {
+			close(done)
+			return nil, fmt.Errorf("error watching lock: %w", err)
+		}
+		idx = meta.LastIndex
+
+		select {
+		case err := <-renewed:
+			return nil, fmt.Errorf("session expired while campaigning: %v", err)
+		default:
+		}
+	}
+}
+
+func (e *Elector) Leader() (string, error) {
+	pair, _, err := e.client.KV().Get(e.key, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if pair == nil || pair.Session == "" {
+		return "", nil
+	}
+
+	return string(pair.Value), nil
+}
+
+type term struct {
+	kv      *capi.KV
+	key     string
+	session string
+	epoch   uint64
+
+	// closed to destroy the session.
+	done chan struct{}
+
+	// closed when the term is over.
+	lost chan struct{}
+	once sync.Once
+}
+
+func (t *term) Epoch() uint64 {
+	return t.epoch
+}
+
+func (t *term) Lost() <-chan struct{} {
+	return t.lost
+}
+
+func (t *term) Check() error {
+	select {
+	case <-t.lost:
+		return election.ErrDeposed
+	default:
+		return nil
+	}
+}
+
+func (t *term) Resign() error {
+	t.end()
+	return nil
+}
+
+func (t *term) end() {
+	t.once.Do(func() {
+		close(t.done)
+		close(t.lost)
+	})
+}
+
+// monitor ends the term when the session can't be renewed, or the lock is no
+// longer held by it, e.g. because an operator deleted the key.
+func (t *term) monitor(renewed <-chan error, idx uint64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case err := <-renewed:
+			if err != nil {
+				log.Printf("leadership lost: error renewing session: %v", err)
+			}
+			t.end()
+		case <-t.lost:
+		}
+		cancel()
+	}()
+
+	for {
+		pair, meta, err := t.kv.Get(t.key, (&capi.QueryOptions{WaitIndex: idx}).WithContext(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Probably transient. If Consul stays unavailable for long enough,
+			// the session will expire, and the term will end anyway.
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if pair == nil || pair.Session != t.session {
+			log.Printf("leadership lost: lock no longer held: %s", t.key)
+			t.end()
+			return
+		}
+
+		idx = meta.LastIndex
+	}
+}
@@ -0,0 +1,50 @@
+// Package election elects a single leader among several controllers, so that
+// only one of them orchestrates (and persists) the keyspace at a time. The
+// others are standbys, ready to take over if the leader goes away.
+
+package election
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeposed is returned by Term.Check once the term is over.
+var ErrDeposed = errors.New("no longer the leader")
+
+// Elector is an interface to campaign for leadership. Each candidate has its
+// own Elector, identified by the address which it serves the controller RPCs
+// at, so that standbys can tell callers where to find the leader.
+type Elector interface {
+
+	// Campaign blocks until this candidate is elected leader, or the context
+	// is cancelled. It must not be called again until the returned term is
+	// over.
+	Campaign(ctx context.Context) (Term, error)
+
+	// Leader returns the address of the current leader, which might be this
+	// candidate, or an empty string if there is none.
+	Leader() (string, error)
+}
+
+// Term is a single period of leadership, which ends when Resign is called, or
+// when leadership is lost, e.g. because the candidate was partitioned from the
+// other candidates for too long.
+type Term interface {
+
+	// Epoch returns the leadership epoch of this term. Every term is assigned
+	// a greater epoch than the terms before it, so that writes can be fenced
+	// to prevent a deposed leader from persisting anything. See
+	// persister.Fencer.
+	Epoch() uint64
+
+	// Lost returns a channel which is closed when the term is over.
+	Lost() <-chan struct{}
+
+	// Check returns ErrDeposed if the term is over. Note that a term can end
+	// at any moment, so this is only a hint; fencing is what makes it safe.
+	Check() error
+
+	// Resign ends the term, so that another candidate can be elected.
+	Resign() error
+}
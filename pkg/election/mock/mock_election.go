@@ -0,0 +1,135 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/adammck/ranger/pkg/election"
+)
+
+// Election is an in-process election, for tests. Candidates are elected in the
+// order that they campaign, and every term gets the next epoch.
+type Election struct {
+	leader *term
+	epoch  uint64
+
+	// closed and replaced whenever a term ends.
+	ended chan struct{}
+
+	sync.Mutex
+}
+
+func New() *Election {
+	return &Election{
+		ended: make(chan struct{}),
+	}
+}
+
+// Candidate returns an elector which campaigns in this election, identified by
+// the given address.
+func (e *Election) Candidate(addr string) election.Elector {
+	return &candidate{e: e, addr: addr}
+}
+
+// test helpers
+
+// Depose ends the current term, if any, as if the leader had been partitioned
+// from the other candidates for too long.
+func (e *Election) Depose() {
+	e.Lock()
+	t := e.leader
+	e.Unlock()
+
+	if t != nil {
+		e.end(t)
+	}
+}
+
+func (e *Election) end(t *term) {
+	e.Lock()
+	defer e.Unlock()
+
+	if e.leader != t {
+		return
+	}
+
+	e.leader = nil
+	close(t.lost)
+	close(e.ended)
+	e.ended = make(chan struct{})
+}
+
+type candidate struct {
+	e    *Election
+	addr string
+}
+
+// interface
+
+func (c *candidate) Campaign(ctx context.Context) (election.Term, error) {
+	for {
+		c.e.Lock()
+		if c.e.leader == nil {
+			c.e.epoch++
+			t := &term{
+				e:     c.e,
+				addr:  c.addr,
+				epoch: c.e.epoch,
+				lost:  make(chan struct{}),
+			}
+			c.e.leader = t
+			c.e.Unlock()
+			return t, nil
+		}
+		ended := c.e.ended
+		c.e.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ended:
+		}
+	}
+}
+
+func (c *candidate) Leader() (string, error) {
+	c.e.Lock()
+	defer c.e.Unlock()
+
+	if c.e.leader == nil {
+		return "", nil
+	}
+
+	return c.e.leader.addr, nil
+}
+
+type term struct {
+	e     *Election
+	addr  string
+	epoch uint64
+	lost  chan struct{}
+}
+
+func (t *term) Epoch() uint64 {
+	return t.epoch
+}
+
+func (t *term) Lost() <-chan struct{} {
+	return t.lost
+}
+
+func (t *term) Check() error {
+	t.e.Lock()
+	defer t.e.Unlock()
+
+	if t.e.leader != t {
+		return election.ErrDeposed
+	}
+
+	return nil
+}
+
+func (t *term) Resign() error {
+	t.e.end(t)
+	return nil
+}
@@ -0,0 +1,71 @@
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adammck/ranger/pkg/election"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElection(t *testing.T) {
+	e := New()
+	a := e.Candidate("aaa:1")
+	b := e.Candidate("bbb:1")
+	ctx := context.Background()
+
+	leader, err := b.Leader()
+	require.NoError(t, err)
+	require.Equal(t, "", leader)
+
+	ta, err := a.Campaign(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), ta.Epoch())
+	require.NoError(t, ta.Check())
+
+	leader, err = b.Leader()
+	require.NoError(t, err)
+	require.Equal(t, "aaa:1", leader)
+
+	// The standby campaigns until the leader is deposed.
+	tbc := make(chan election.Term)
+	go func() {
+		tb, _ := b.Campaign(ctx)
+		tbc <- tb
+	}()
+
+	select {
+	case <-tbc:
+		t.Fatal("standby elected while leader's term is ongoing")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	e.Depose()
+	require.ErrorIs(t, ta.Check(), election.ErrDeposed)
+	<-ta.Lost()
+
+	tb := <-tbc
+	require.NotNil(t, tb)
+	require.Equal(t, uint64(2), tb.Epoch())
+	require.NoError(t, tb.Check())
+
+	// Resigning doesn't affect later terms.
+	require.NoError(t, ta.Resign())
+	require.NoError(t, tb.Check())
+
+	require.NoError(t, tb.Resign())
+	<-tb.Lost()
+}
+
+func TestCampaign_Cancel(t *testing.T) {
+	e := New()
+	_, err := e.Candidate("aaa:1").Campaign(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = e.Candidate("bbb:1").Campaign(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,304 @@
+package keyspace
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// Problem is an inconsistency in the persisted state of a keyspace, found by
+// Fsck.
+type Problem struct {
+	// Range is the range which the problem was found in, or zero if it spans
+	// the whole keyspace.
+	Range api.RangeID
+	Desc  string
+
+	// Repair fixes the problem by mutating the ranges passed to Fsck, and
+	// returns those which were changed, and so must be persisted. It's nil if
+	// the problem can't be repaired automatically, because there's no way to
+	// know which of the conflicting ranges is correct.
+	Repair func() []*ranje.Range
+}
+
+func (p Problem) String() string {
+	if p.Range == api.ZeroRange {
+		return p.Desc
+	}
+	return fmt.Sprintf("R%s: %s", p.Range, p.Desc)
+}
+
+// Fsck checks the given ranges, which should be straight from the persister,
+// for inconsistencies which would cause New to fail or the orchestrator to
+// misbehave. Unlike sanityCheck, it doesn't stop at the first problem, and
+// offers to repair some of them.
+func Fsck(ranges []*ranje.Range) []Problem {
+	problems := []Problem{}
+
+	byID := map[api.RangeID]*ranje.Range{}
+	dupes := false
+	for _, r := range ranges {
+		if _, ok := byID[r.Meta.Ident]; ok {
+			problems = append(problems, Problem{
+				Range: r.Meta.Ident,
+				Desc:  "duplicate range ID",
+			})
+			dupes = true
+			continue
+		}
+		byID[r.Meta.Ident] = r
+	}
+
+	for _, r := range ranges {
+		if r.State == api.RsUnknown {
+			problems = append(problems, Problem{
+				Range: r.Meta.Ident,
+				Desc:  "range in unknown state",
+			})
+		}
+	}
+
+	problems = append(problems, checkLinks(ranges, byID)...)
+	problems = append(problems, checkLineage(ranges, byID)...)
+	problems = append(problems, checkPlacements(ranges)...)
+
+	// The leaf check needs the index, which can't hold duplicate IDs.
+	if !dupes {
+		ks := &Keyspace{ranges: ranges, idx: newRangeIndex()}
+		for _, r := range ranges {
+			ks.idx.add(r)
+		}
+		// This is often just a symptom of one of the problems above, and so
+		// goes away once they're repaired.
+		if err := ks.checkLeaves(); err != nil {
+			problems = append(problems, Problem{Desc: err.Error()})
+		}
+	}
+
+	return problems
+}
+
+// checkLinks checks that the parent and child links between ranges point at
+// ranges which exist, agree with each other, and would not be reused when new
+// ranges are created.
+func checkLinks(ranges []*ranje.Range, byID map[api.RangeID]*ranje.Range) []Problem {
+	problems := []Problem{}
+
+	// The keyspace assigns new idents after the highest one it loads, so any
+	// reference to a range beyond that would end up pointing at a new range.
+	var maxIdent api.RangeID
+	for _, r := range ranges {
+		if r.Meta.Ident > maxIdent {
+			maxIdent = r.Meta.Ident
+		}
+	}
+
+	for _, r := range ranges {
+		r := r
+
+		for _, pID := range r.Parents {
+			pID := pID
+			p, ok := byID[pID]
+
+			// Parents are removed from their children before they're garbage
+			// collected, so this is left over from something else. Do the
+			// same as GC would have.
+			if !ok {
+				desc := fmt.Sprintf("parent does not exist (parent=%s)", pID)
+				if pID > maxIdent {
+					desc = fmt.Sprintf("parent is beyond maxIdent, so would be reused (parent=%s, maxIdent=%s)", pID, maxIdent)
+				}
+
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  desc,
+					Repair: func() []*ranje.Range {
+						r.Parents = withoutRangeID(r.Parents, pID)
+						return []*ranje.Range{r}
+					},
+				})
+				continue
+			}
+
+			if pID >= r.Meta.Ident {
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  fmt.Sprintf("parent was created after child (parent=%s)", pID),
+				})
+			}
+
+			// Aborting an operation removes the children from their parents,
+			// but leaves the parents on the children. Otherwise, there's no way
+			// to know whether the parent or the child is wrong; adding the
+			// child back to an active parent would stop it being a leaf.
+			if !containsRangeID(p.Children, r.Meta.Ident) && r.State != api.RsAborted {
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  fmt.Sprintf("parent does not list range as child (parent=%s)", pID),
+				})
+			}
+		}
+
+		for _, cID := range r.Children {
+			c, ok := byID[cID]
+
+			// Children are never collected before their parents, so there is
+			// no way to know what the child looked like.
+			if !ok {
+				desc := fmt.Sprintf("child does not exist (child=%s)", cID)
+				if cID > maxIdent {
+					desc = fmt.Sprintf("child is beyond maxIdent, so would be reused (child=%s, maxIdent=%s)", cID, maxIdent)
+				}
+
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  desc,
+				})
+				continue
+			}
+
+			// A child which doesn't list an obsolete parent is fine. GC removes
+			// the parent from its children before deleting it, and may have
+			// crashed in between.
+			if !containsRangeID(c.Parents, r.Meta.Ident) && r.State != api.RsObsolete {
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  fmt.Sprintf("child does not list range as parent (child=%s)", cID),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkLineage checks that the boundaries of ranges agree with those of their
+// parents and children, i.e. that the children of a split exactly cover their
+// parent, and that the parents of a join exactly cover their child. Ranges
+// for which some of the others have been garbage collected are skipped.
+func checkLineage(ranges []*ranje.Range, byID map[api.RangeID]*ranje.Range) []Problem {
+	problems := []Problem{}
+
+	for _, r := range ranges {
+
+		// A single child can't be checked from this direction, since it might
+		// be the child of a join whose other parents were garbage collected.
+		if len(r.Children) > 1 {
+			if desc, ok := covers(r, r.Children, byID); !ok {
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  fmt.Sprintf("children do not cover range: %s", desc),
+				})
+			}
+		}
+
+		if len(r.Parents) > 1 {
+			if desc, ok := covers(r, r.Parents, byID); !ok {
+				problems = append(problems, Problem{
+					Range: r.Meta.Ident,
+					Desc:  fmt.Sprintf("parents do not cover range: %s", desc),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// covers returns whether the given ranges are contiguous and start and end at
+// the same keys as the given range, and if not, a description of why. Returns
+// true if any of the others are missing, since there's nothing to check.
+func covers(r *ranje.Range, rIDs []api.RangeID, byID map[api.RangeID]*ranje.Range) (string, bool) {
+	rs := make([]*ranje.Range, 0, len(rIDs))
+	for _, rID := range rIDs {
+		rr, ok := byID[rID]
+		if !ok {
+			return "", true
+		}
+		rs = append(rs, rr)
+	}
+
+	// Unbounded start keys sort first, which is what we want. Unbounded end
+	// keys don't matter, since they can only be last if valid.
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Meta.Start < rs[j].Meta.Start
+	})
+
+	if rs[0].Meta.Start != r.Meta.Start {
+		return fmt.Sprintf("start %q != %q (rID=%s)", rs[0].Meta.Start, r.Meta.Start, rs[0].Meta.Ident), false
+	}
+
+	for i := 1; i < len(rs); i++ {
+		if rs[i].Meta.Start != rs[i-1].Meta.End {
+			return fmt.Sprintf("gap or overlap between %s and %s", rs[i-1].Meta.Ident, rs[i].Meta.Ident), false
+		}
+	}
+
+	if last := rs[len(rs)-1]; last.Meta.End != r.Meta.End {
+		return fmt.Sprintf("end %q != %q (rID=%s)", last.Meta.End, r.Meta.End, last.Meta.Ident), false
+	}
+
+	return "", true
+}
+
+// checkPlacements checks that obsolete ranges have no placements, and that no
+// range has more than one placement on the same node.
+func checkPlacements(ranges []*ranje.Range) []Problem {
+	problems := []Problem{}
+
+	for _, r := range ranges {
+		r := r
+
+		// Obsolete ranges only become so once all of their placements have
+		// been dropped, so these were forgotten about. The nodes have probably
+		// dropped them too; if not, the orchestrator won't tell them to.
+		if r.State == api.RsObsolete && len(r.Placements) > 0 {
+			problems = append(problems, Problem{
+				Range: r.Meta.Ident,
+				Desc:  fmt.Sprintf("obsolete range has placements (n=%d)", len(r.Placements)),
+				Repair: func() []*ranje.Range {
+					r.Placements = nil
+					return []*ranje.Range{r}
+				},
+			})
+			continue
+		}
+
+		seen := map[api.NodeID]struct{}{}
+		for _, p := range r.Placements {
+			p := p
+			if _, ok := seen[p.NodeID]; !ok {
+				seen[p.NodeID] = struct{}{}
+				continue
+			}
+
+			// Keep the first one, which is the oldest.
+			problems = append(problems, Problem{
+				Range: r.Meta.Ident,
+				Desc:  fmt.Sprintf("duplicate placement on node (node=%s, state=%s)", p.NodeID, p.StateCurrent),
+				Repair: func() []*ranje.Range {
+					for i := range r.Placements {
+						if r.Placements[i] == p {
+							r.Placements = append(r.Placements[:i], r.Placements[i+1:]...)
+							break
+						}
+					}
+					return []*ranje.Range{r}
+				},
+			})
+		}
+	}
+
+	return problems
+}
+
+func containsRangeID(rIDs []api.RangeID, rID api.RangeID) bool {
+	for _, id := range rIDs {
+		if id == rID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package keyspace
+
+import (
+	"testing"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/stretchr/testify/require"
+)
+
+func descs(problems []Problem) []string {
+	s := make([]string, len(problems))
+	for i := range problems {
+		s[i] = problems[i].String()
+	}
+	return s
+}
+
+func TestFsck_Clean(t *testing.T) {
+	ks, _ := retentionFixture(t)
+	require.Empty(t, Fsck(ks.ranges))
+
+	// Collecting some of the history doesn't leave anything behind.
+	ks.SetRetention(Retention{Generations: 1})
+	_, err := ks.GC()
+	require.NoError(t, err)
+	require.Empty(t, Fsck(ks.ranges))
+}
+
+func TestFsck_Repair(t *testing.T) {
+	ks, _ := retentionFixture(t)
+	r := rangeGetter(t, ks)
+
+	r(1).NewPlacement("aaa")
+	r(4).Parents = append(r(4).Parents, 99)
+	r(5).NewPlacement("bbb")
+	r(5).NewPlacement("ccc")
+	r(5).NewPlacement("bbb")
+
+	problems := Fsck(ks.ranges)
+	require.Equal(t, []string{
+		"R4: parent is beyond maxIdent, so would be reused (parent=99, maxIdent=6)",
+		"R1: obsolete range has placements (n=1)",
+		"R5: duplicate placement on node (node=bbb, state=PsPending)",
+	}, descs(problems))
+
+	changed := []api.RangeID{}
+	for _, p := range problems {
+		if p.Repair == nil {
+			continue
+		}
+		for _, rr := range p.Repair() {
+			changed = append(changed, rr.Meta.Ident)
+		}
+	}
+
+	require.Equal(t, []api.RangeID{4, 1, 5}, changed)
+	require.Equal(t, []api.RangeID{2, 3}, r(4).Parents)
+	require.Empty(t, r(1).Placements)
+	require.Len(t, r(5).Placements, 2)
+	require.Empty(t, Fsck(ks.ranges))
+}
+
+func TestFsck_Unrepairable(t *testing.T) {
+	ks, _ := retentionFixture(t)
+	r := rangeGetter(t, ks)
+
+	r(6).Meta.Start = api.Key("ddd")
+	r(3).Children = []api.RangeID{4, 6}
+	r(5).Children = []api.RangeID{7}
+	r(2).Children = []api.RangeID{5}
+
+	problems := Fsck(ks.ranges)
+	require.Equal(t, []string{
+		"R4: parent does not list range as child (parent=2)",
+		"R5: child is beyond maxIdent, so would be reused (child=7, maxIdent=6)",
+		"R3: children do not cover range: start \"\" != \"ccc\" (rID=4)",
+		"R4: children do not cover range: gap or overlap between 5 and 6",
+		"first leaf range did not start with zero key (rID=6)",
+	}, descs(problems))
+
+	for _, p := range problems {
+		require.Nil(t, p.Repair)
+	}
+}
+
+func TestFsck_Aborted(t *testing.T) {
+	ks, _ := retentionFixture(t)
+	r := rangeGetter(t, ks)
+
+	// Split range 5 and give up.
+	r(5).NewPlacement("aaa").StateCurrent = api.PsActive
+	_, _, err := ks.Split(r(5), api.Key("bbb"))
+	require.NoError(t, err)
+	abortOp(t, ks)
+	require.Empty(t, Fsck(ks.ranges))
+
+	// Same for a join of ranges 5 and 6.
+	r(6).NewPlacement("bbb").StateCurrent = api.PsActive
+	_, err = ks.JoinTwo(r(5), r(6))
+	require.NoError(t, err)
+	abortOp(t, ks)
+	require.Empty(t, Fsck(ks.ranges))
+
+	// The parents are still the only leaves.
+	require.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsObsolete} {3 (ccc, +inf] RsObsolete} {4 [-inf, +inf] RsObsolete} {5 [-inf, ccc] RsActive p0=aaa:PsActive} {6 (ccc, +inf] RsActive p0=bbb:PsActive} {7 [-inf, bbb] RsAborted} {8 (bbb, ccc] RsAborted} {9 [-inf, +inf] RsAborted}", ks.LogString())
+}
+
+func TestFsck_NoLeaves(t *testing.T) {
+	r1 := &ranje.Range{State: api.RsObsolete, Meta: api.Meta{Ident: 1}, Children: []api.RangeID{2}}
+	r2 := &ranje.Range{State: api.RsAborted, Meta: api.Meta{Ident: 2}, Parents: []api.RangeID{1}}
+
+	require.Equal(t, []string{
+		"no leaf ranges",
+	}, descs(Fsck([]*ranje.Range{r1, r2})))
+}
+
+func abortOp(t *testing.T, ks *Keyspace) {
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	require.NoError(t, ops[0].Abort(ks))
+}
+
+func TestFsck_Duplicates(t *testing.T) {
+	r1 := &ranje.Range{State: api.RsActive, Meta: api.Meta{Ident: 1}}
+	r2 := &ranje.Range{State: api.RsUnknown, Meta: api.Meta{Ident: 1}}
+
+	require.Equal(t, []string{
+		"R1: duplicate range ID",
+		"R1: range in unknown state",
+	}, descs(Fsck([]*ranje.Range{r1, r2})))
+}
@@ -0,0 +1,128 @@
+package keyspace
+
+import (
+	"sort"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// rangeIndex keeps track of the ranges in the keyspace by ID, by state, and (for
+// leaf ranges) by key, so that the orchestrator doesn't have to churn through
+// the entire range history every tick. It's owned by the Keyspace, and guarded
+// by its rangesMu.
+//
+// The index doesn't notice when ranges change by itself. Whenever the state or
+// children of a range are changed, update must be called.
+type rangeIndex struct {
+	byID    map[api.RangeID]*ranje.Range
+	byState map[api.RangeState]map[api.RangeID]*ranje.Range
+
+	// Leaf ranges, i.e. those with no children (except aborted ranges). These
+	// should always cover the whole keyspace with no gaps or overlaps.
+	leaves intervalTree
+
+	// What we last indexed each range as, so we know what to remove when it
+	// changes.
+	entries map[api.RangeID]indexEntry
+}
+
+type indexEntry struct {
+	state api.RangeState
+	leaf  bool
+}
+
+func newRangeIndex() *rangeIndex {
+	return &rangeIndex{
+		byID:    map[api.RangeID]*ranje.Range{},
+		byState: map[api.RangeState]map[api.RangeID]*ranje.Range{},
+		entries: map[api.RangeID]indexEntry{},
+	}
+}
+
+// add indexes a range which the index hasn't seen before.
+func (idx *rangeIndex) add(r *ranje.Range) {
+	idx.byID[r.Meta.Ident] = r
+	idx.update(r)
+}
+
+// update reindexes the given range after its state or children have changed.
+func (idx *rangeIndex) update(r *ranje.Range) {
+	rID := r.Meta.Ident
+	next := indexEntry{
+		state: r.State,
+
+		// Aborted ranges have no children either, but they were never really
+		// part of the keyspace, so they aren't leaves.
+		leaf: len(r.Children) == 0 && r.State != api.RsAborted,
+	}
+
+	prev, ok := idx.entries[rID]
+	if ok && prev == next {
+		return
+	}
+
+	if ok {
+		delete(idx.byState[prev.state], rID)
+		if prev.leaf {
+			idx.leaves.Delete(r)
+		}
+	}
+
+	m, ok := idx.byState[next.state]
+	if !ok {
+		m = map[api.RangeID]*ranje.Range{}
+		idx.byState[next.state] = m
+	}
+	m[rID] = r
+
+	if next.leaf {
+		idx.leaves.Insert(r)
+	}
+
+	idx.entries[rID] = next
+}
+
+// remove forgets about the given range entirely. It's only used when obsolete
+// ranges are garbage collected.
+func (idx *rangeIndex) remove(r *ranje.Range) {
+	rID := r.Meta.Ident
+
+	if prev, ok := idx.entries[rID]; ok {
+		delete(idx.byState[prev.state], rID)
+		if prev.leaf {
+			idx.leaves.Delete(r)
+		}
+	}
+
+	delete(idx.entries, rID)
+	delete(idx.byID, rID)
+}
+
+// inState returns the ranges in any of the given states, ordered by ident.
+func (idx *rangeIndex) inState(states ...api.RangeState) []*ranje.Range {
+	n := 0
+	for _, s := range states {
+		n += len(idx.byState[s])
+	}
+
+	out := make([]*ranje.Range, 0, n)
+	for _, s := range states {
+		for _, r := range idx.byState[s] {
+			out = append(out, r)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Meta.Ident < out[j].Meta.Ident
+	})
+
+	return out
+}
+
+// live returns the ranges which may have placements, i.e. everything which
+// isn't obsolete. Aborted ranges are excluded too, because their placements
+// (if any) are on the way out and don't serve anything.
+func (idx *rangeIndex) live() []*ranje.Range {
+	return idx.inState(api.RsActive, api.RsSubsuming, api.RsNew)
+}
@@ -0,0 +1,242 @@
+package keyspace
+
+import (
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// intervalTree is an augmented AVL tree of ranges, ordered by start key (and
+// then by ident, to break ties), where each node also tracks the greatest end
+// key in its subtree. This allows us to find every range containing some key
+// without visiting the whole tree.
+//
+// Note that ZeroKey means negative infinity when it's a start key, and positive
+// infinity when it's an end key. The helpers below take care of that, so don't
+// compare keys directly in here.
+//
+// Ranges must not be mutated (at least not their Meta) while they're in the
+// tree. That's fine, since Meta is immutable after construction.
+type intervalTree struct {
+	root *itNode
+	size int
+}
+
+type itNode struct {
+	r      *ranje.Range
+	maxEnd api.Key
+	height int
+	left   *itNode
+	right  *itNode
+}
+
+// endLess returns true if end key a is less than end key b.
+func endLess(a, b api.Key) bool {
+	if a == api.ZeroKey {
+		return false
+	}
+	if b == api.ZeroKey {
+		return true
+	}
+	return a < b
+}
+
+// nodeLess returns true if range a sorts before range b in the tree.
+func nodeLess(a, b *ranje.Range) bool {
+	if a.Meta.Start != b.Meta.Start {
+		return a.Meta.Start < b.Meta.Start
+	}
+
+	return a.Meta.Ident < b.Meta.Ident
+}
+
+func (n *itNode) h() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// fix recalculates the height and maxEnd of the node from its children. Must be
+// called bottom-up after any structural change.
+func (n *itNode) fix() {
+	n.height = 1 + maxInt(n.left.h(), n.right.h())
+	n.maxEnd = n.r.Meta.End
+
+	for _, c := range []*itNode{n.left, n.right} {
+		if c != nil && endLess(n.maxEnd, c.maxEnd) {
+			n.maxEnd = c.maxEnd
+		}
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func rotateRight(n *itNode) *itNode {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.fix()
+	l.fix()
+	return l
+}
+
+func rotateLeft(n *itNode) *itNode {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.fix()
+	r.fix()
+	return r
+}
+
+func balance(n *itNode) *itNode {
+	n.fix()
+
+	switch bf := n.left.h() - n.right.h(); {
+	case bf > 1:
+		if n.left.left.h() < n.left.right.h() {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+
+	case bf < -1:
+		if n.right.right.h() < n.right.left.h() {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+// Insert adds the given range to the tree. It's a no-op if the range is already
+// present.
+func (t *intervalTree) Insert(r *ranje.Range) {
+	var ok bool
+	t.root, ok = insert(t.root, r)
+	if ok {
+		t.size += 1
+	}
+}
+
+func insert(n *itNode, r *ranje.Range) (*itNode, bool) {
+	if n == nil {
+		nn := &itNode{r: r}
+		nn.fix()
+		return nn, true
+	}
+
+	var ok bool
+	switch {
+	case n.r == r:
+		return n, false
+	case nodeLess(r, n.r):
+		n.left, ok = insert(n.left, r)
+	default:
+		n.right, ok = insert(n.right, r)
+	}
+
+	return balance(n), ok
+}
+
+// Delete removes the given range from the tree. It's a no-op if the range isn't
+// present.
+func (t *intervalTree) Delete(r *ranje.Range) {
+	var ok bool
+	t.root, ok = remove(t.root, r)
+	if ok {
+		t.size -= 1
+	}
+}
+
+func remove(n *itNode, r *ranje.Range) (*itNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var ok bool
+	switch {
+	case n.r == r:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+
+		// Two children: replace this node's range with its successor, and
+		// remove that from the right subtree instead.
+		s := n.right
+		for s.left != nil {
+			s = s.left
+		}
+		n.r = s.r
+		n.right, _ = remove(n.right, s.r)
+		ok = true
+
+	case nodeLess(r, n.r):
+		n.left, ok = remove(n.left, r)
+	default:
+		n.right, ok = remove(n.right, r)
+	}
+
+	return balance(n), ok
+}
+
+// Len returns the number of ranges in the tree.
+func (t *intervalTree) Len() int {
+	return t.size
+}
+
+// Containing returns every range in the tree which contains the given key, in
+// tree order.
+func (t *intervalTree) Containing(k api.Key) []*ranje.Range {
+	out := []*ranje.Range{}
+	containing(t.root, k, &out)
+	return out
+}
+
+func containing(n *itNode, k api.Key, out *[]*ranje.Range) {
+	if n == nil {
+		return
+	}
+
+	// Nothing in this subtree ends after the key, so nothing can contain it.
+	if n.maxEnd != api.ZeroKey && k >= n.maxEnd {
+		return
+	}
+
+	containing(n.left, k, out)
+
+	// Everything to the right starts at or after this node, so if this node
+	// starts after the key, so does everything to the right.
+	if n.r.Meta.Start != api.ZeroKey && k < n.r.Meta.Start {
+		return
+	}
+
+	if n.r.Meta.Contains(k) {
+		*out = append(*out, n.r)
+	}
+
+	containing(n.right, k, out)
+}
+
+// Walk calls the given func for every range in the tree, in order.
+func (t *intervalTree) Walk(f func(r *ranje.Range)) {
+	walk(t.root, f)
+}
+
+func walk(n *itNode, f func(r *ranje.Range)) {
+	if n == nil {
+		return
+	}
+
+	walk(n.left, f)
+	f(n.r)
+	walk(n.right, f)
+}
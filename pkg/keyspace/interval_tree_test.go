@@ -0,0 +1,107 @@
+package keyspace
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalTree_Containing(t *testing.T) {
+	tree := intervalTree{}
+
+	// Overlapping ranges, like a keyspace mid-split.
+	rs := []*ranje.Range{
+		{Meta: api.Meta{Ident: 1, Start: api.ZeroKey, End: api.ZeroKey}},
+		{Meta: api.Meta{Ident: 2, Start: api.ZeroKey, End: "ccc"}},
+		{Meta: api.Meta{Ident: 3, Start: "ccc", End: api.ZeroKey}},
+		{Meta: api.Meta{Ident: 4, Start: "ccc", End: "ddd"}},
+		{Meta: api.Meta{Ident: 5, Start: "ddd", End: api.ZeroKey}},
+	}
+
+	for _, r := range rs {
+		tree.Insert(r)
+	}
+
+	require.Equal(t, 5, tree.Len())
+
+	for k, expected := range map[api.Key][]api.RangeID{
+		api.ZeroKey: {1, 2},
+		"aaa":       {1, 2},
+		"ccc":       {1, 3, 4},
+		"ccd":       {1, 3, 4},
+		"ddd":       {1, 3, 5},
+		"zzz":       {1, 3, 5},
+	} {
+		require.ElementsMatch(t, expected, idents(tree.Containing(k)), "k=%q", k)
+	}
+
+	tree.Delete(rs[0])
+	tree.Delete(rs[2])
+	require.Equal(t, 3, tree.Len())
+	require.ElementsMatch(t, []api.RangeID{4}, idents(tree.Containing("ccc")))
+	require.ElementsMatch(t, []api.RangeID{5}, idents(tree.Containing("zzz")))
+
+	// Deleting something which isn't there is a no-op.
+	tree.Delete(rs[0])
+	require.Equal(t, 3, tree.Len())
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	key := func() api.Key {
+		if rnd.Intn(10) == 0 {
+			return api.ZeroKey
+		}
+		return api.Key(fmt.Sprintf("%03d", rnd.Intn(1000)))
+	}
+
+	tree := intervalTree{}
+	all := map[*ranje.Range]struct{}{}
+
+	for i := 1; i <= 2000; i++ {
+		s, e := key(), key()
+		if s != api.ZeroKey && e != api.ZeroKey && e <= s {
+			s, e = e, s
+		}
+
+		r := &ranje.Range{Meta: api.Meta{Ident: api.RangeID(i), Start: s, End: e}}
+		tree.Insert(r)
+		all[r] = struct{}{}
+
+		// Delete something every now and then, to exercise rebalancing.
+		if i%3 == 0 {
+			for rr := range all {
+				tree.Delete(rr)
+				delete(all, rr)
+				break
+			}
+		}
+	}
+
+	require.Equal(t, len(all), tree.Len())
+
+	for i := 0; i < 200; i++ {
+		k := key()
+
+		expected := []api.RangeID{}
+		for r := range all {
+			if r.Meta.Contains(k) {
+				expected = append(expected, r.Meta.Ident)
+			}
+		}
+
+		require.ElementsMatch(t, expected, idents(tree.Containing(k)), "k=%q", k)
+	}
+}
+
+func idents(rs []*ranje.Range) []api.RangeID {
+	out := make([]api.RangeID, len(rs))
+	for i := range rs {
+		out[i] = rs[i].Meta.Ident
+	}
+	return out
+}
@@ -1,11 +1,14 @@
 package keyspace
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/adammck/ranger/pkg/api"
 	"github.com/adammck/ranger/pkg/persister"
@@ -20,28 +23,114 @@ type Keyspace struct {
 	pers persister.Persister
 
 	// Every known range for all of history, including those which have been
-	// obsoleted. (We don't currently garbage collect them, because when it's
-	// safe to do that is up to the service. For some it's safe as soon as the
-	// range is obsolete, others need the range start/end keys indefinitely to
-	// recover state from cold storage.)
+	// obsoleted. Obsolete ranges are kept until they're garbage collected by
+	// GC, according to the retention policy. By default that's never, because
+	// when it's safe to do that is up to the service.
 	//
-	// TODO: Use a better data structure (like an interval tree) for this.
+	// This is only for the history, in the order the ranges were created. Use
+	// the index to look ranges up, so we don't have to scan all of this.
 	ranges   []*ranje.Range
+	idx      *rangeIndex
 	rangesMu sync.RWMutex
 
+	// Ranges which have been changed since they were last persisted, or which
+	// might still change (see mustPersistDirtyRanges). Keyed by ID so that the
+	// same range isn't written twice in a single transaction.
+	dirty map[api.RangeID]*ranje.Range
+
 	// The highest RangeID of any known range. Increment this before creating a
 	// new range.
 	maxIdent api.RangeID
 
 	// The default replication config. Ranges spawned by this keyspace will
-	// inherit this. It's currently hard to change.
+	// use this, unless they (or their parents) have been given their own via
+	// SetReplication. It's currently hard to change.
 	replication ranje.ReplicationConfig
+
+	// When obsolete ranges should be garbage collected, and the state needed
+	// to decide that. See retention.go.
+	retention  Retention
+	obsoleteAt map[api.RangeID]time.Time
+
+	// Receives events from every range, for Watch and NotifyOnChange. See
+	// watch.go.
+	watchers *watchers
+}
+
+// Bootstrap describes the ranges that a keyspace should start with, if there
+// are none in the store. The zero value is a single range covering all keys.
+type Bootstrap struct {
+
+	// The keys at which the keyspace should be split, in ascending order. N
+	// keys result in N+1 contiguous ranges. Like Split, each key is the end of
+	// one range (inclusive) and the start of the next (exclusive).
+	Boundaries []api.Key
+
+	// The nodes which each of the ranges should initially be placed on, in the
+	// same order as the ranges. This is optional, and may be shorter than the
+	// number of ranges; any others are placed by the orchestrator as usual.
+	Nodes [][]api.NodeID
+}
+
+// Validate returns an error if the bootstrap config can't be used with the
+// given replication config.
+func (b *Bootstrap) Validate(repl ranje.ReplicationConfig) error {
+	for i, k := range b.Boundaries {
+		if k == api.ZeroKey {
+			return fmt.Errorf("boundary %d is empty", i)
+		}
+
+		if i > 0 && k <= b.Boundaries[i-1] {
+			return fmt.Errorf("boundaries not in ascending order: %q, %q", b.Boundaries[i-1], k)
+		}
+	}
+
+	if n := len(b.Boundaries) + 1; len(b.Nodes) > n {
+		return fmt.Errorf("more node lists than ranges: %d > %d", len(b.Nodes), n)
+	}
+
+	for i, nIDs := range b.Nodes {
+		if len(nIDs) > repl.MinPlacements {
+			return fmt.Errorf("too many nodes for range %d: %d > MinPlacements=%d", i, len(nIDs), repl.MinPlacements)
+		}
+
+		seen := map[api.NodeID]struct{}{}
+		for _, nID := range nIDs {
+			if _, ok := seen[nID]; ok {
+				return fmt.Errorf("duplicate node for range %d: %s", i, nID)
+			}
+			seen[nID] = struct{}{}
+		}
+	}
+
+	return nil
 }
 
 func New(persister persister.Persister, replication ranje.ReplicationConfig) (*Keyspace, error) {
+	return NewWithBootstrap(persister, replication, Bootstrap{})
+}
+
+// NewWithBootstrap is like New, but if the store is empty, seeds the keyspace
+// with the ranges (and optionally placements) described by the given bootstrap
+// config. They're all persisted together. If the store isn't empty, the config
+// is ignored.
+func NewWithBootstrap(persister persister.Persister, replication ranje.ReplicationConfig, boot Bootstrap) (*Keyspace, error) {
+	if err := replication.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid replication config: %w", err)
+	}
+
+	if err := boot.Validate(replication); err != nil {
+		return nil, fmt.Errorf("invalid bootstrap config: %w", err)
+	}
+
 	ks := &Keyspace{
 		pers:        persister,
+		idx:         newRangeIndex(),
+		dirty:       map[api.RangeID]*ranje.Range{},
 		replication: replication,
+		retention:   RetainForever,
+		obsoleteAt:  map[api.RangeID]time.Time{},
+		watchers:    newWatchers(),
 	}
 
 	ranges, err := persister.GetRanges()
@@ -50,19 +139,25 @@ func New(persister persister.Persister, replication ranje.ReplicationConfig) (*K
 	}
 
 	// Special case: There are no ranges in the store. We are bootstrapping the
-	// keyspace from scratch, so start with a singe range that covers all keys.
+	// keyspace from scratch, so start with contiguous ranges that cover all
+	// keys. By default that's a single range.
 	if len(ranges) == 0 {
-		r := ks.newRange()
-		ks.ranges = []*ranje.Range{r}
-		ks.pers.PutRanges(ks.ranges)
+		if err := ks.bootstrap(boot); err != nil {
+			return nil, err
+		}
 		return ks, nil
 	}
 
+	if len(boot.Boundaries) > 0 || len(boot.Nodes) > 0 {
+		log.Printf("ignoring bootstrap config; store already has %d ranges", len(ranges))
+	}
+
 	ks.ranges = ranges
 	for _, r := range ks.ranges {
 
 		// Repair the range.
 		r.Repair(&ks.replication)
+		r.OnEvent(ks.watchers.publish)
 
 		// Repair the placements
 		for _, p := range r.Placements {
@@ -73,6 +168,18 @@ func New(persister persister.Persister, replication ranje.ReplicationConfig) (*K
 		if r.Meta.Ident > ks.maxIdent {
 			ks.maxIdent = r.Meta.Ident
 		}
+
+		// Start the retention clock from when the range became obsolete, or
+		// from now if it predates that being recorded.
+		if r.State == api.RsObsolete || r.State == api.RsAborted {
+			t := time.Now()
+			if n := len(r.History); n > 0 && r.History[n-1].State == r.State {
+				t = r.History[n-1].When
+			}
+			ks.obsoleteAt[r.Meta.Ident] = t
+		}
+
+		ks.idx.add(r)
 	}
 
 	// Sanity-check the ranges for no gaps and no overlaps.
@@ -85,6 +192,35 @@ func New(persister persister.Persister, replication ranje.ReplicationConfig) (*K
 	return ks, nil
 }
 
+// bootstrap creates the initial ranges of an empty keyspace, and persists them
+// in a single transaction.
+func (ks *Keyspace) bootstrap(boot Bootstrap) error {
+	ks.ranges = make([]*ranje.Range, len(boot.Boundaries)+1)
+
+	for i := range ks.ranges {
+		r := ks.newRange(api.RsActive)
+
+		if i > 0 {
+			r.Meta.Start = boot.Boundaries[i-1]
+		}
+
+		if i < len(boot.Boundaries) {
+			r.Meta.End = boot.Boundaries[i]
+		}
+
+		if i < len(boot.Nodes) {
+			for _, nID := range boot.Nodes[i] {
+				r.NewPlacement(nID)
+			}
+		}
+
+		ks.ranges[i] = r
+		ks.idx.add(r)
+	}
+
+	return ks.pers.PutRanges(ks.ranges)
+}
+
 // LogString is only used by tests.
 // TODO: Move it to the tests!
 func (ks *Keyspace) LogString() string {
@@ -98,8 +234,8 @@ func (ks *Keyspace) LogString() string {
 }
 
 // sanityCheck returns an error if the curernt range state isn't sane. It does
-// nothing to try to rectify the situaton. This method mostly compensates for
-// the fact that I'm storing all these ranges in a single flat slice.
+// nothing to try to rectify the situaton. It's only called at startup, so it's
+// fine that it scans the whole history.
 //
 // TODO: More tests for this.
 func (ks *Keyspace) sanityCheck() error {
@@ -121,38 +257,37 @@ func (ks *Keyspace) sanityCheck() error {
 		}
 	}
 
-	// TODO: Check that the start/end of each range is valid with respect to its
-	//       parents and children. I missed a bad test fixture here.
-
-	// Check that leaf ranges (i.e. those with no children) cover the entire
-	// keyspace with no overlaps. This must always be the case; we only persist
-	// valid configurations, and do it transactionally.
-
-	leafs := []*ranje.Range{}
-	for i := range ks.ranges {
-		if len(ks.ranges[i].Children) == 0 {
-			leafs = append(leafs, ks.ranges[i])
-		}
+	// Check that the start/end of each range is valid with respect to its
+	// parents and children.
+	byID := make(map[api.RangeID]*ranje.Range, len(ks.ranges))
+	for _, r := range ks.ranges {
+		byID[r.Meta.Ident] = r
+	}
+	if problems := checkLineage(ks.ranges, byID); len(problems) > 0 {
+		return fmt.Errorf("%s (rID=%d)", problems[0].Desc, problems[0].Range)
 	}
 
-	sort.Slice(leafs, func(i, j int) bool {
-		m1 := leafs[i].Meta
-		m2 := leafs[j].Meta
-
-		if m1.Start != m2.Start {
-			return m1.Start < m2.Start
-		}
+	return ks.checkLeaves()
+}
 
-		if m1.End != m2.End {
-			return m1.End < m2.End
-		}
+// checkLeaves returns an error unless the leaf ranges (i.e. those with no
+// children) cover the entire keyspace with no overlaps. This must always be the
+// case; we only persist valid configurations, and do it transactionally.
+func (ks *Keyspace) checkLeaves() error {
 
-		// We know there's no duplicate Idents already.
-		return m1.Ident < m2.Ident
+	// The index keeps the leaf ranges ordered by start key, so there's no need
+	// to sort them here.
+	leafs := make([]*ranje.Range, 0, ks.idx.leaves.Len())
+	ks.idx.leaves.Walk(func(r *ranje.Range) {
+		leafs = append(leafs, r)
 	})
 
+	if len(leafs) == 0 {
+		return fmt.Errorf("no leaf ranges")
+	}
+
 	for i, r := range leafs {
-		if r.State != api.RsActive {
+		if r.State != api.RsActive && r.State != api.RsNew {
 			return fmt.Errorf("non-active leaf range with no children (rID=%v)", r.Meta.Ident)
 		}
 
@@ -182,75 +317,174 @@ func (ks *Keyspace) sanityCheck() error {
 	return nil
 }
 
+// Ranges returns every range in the keyspace, including obsolete ones, in the
+// order they were created, along with a func to release the keyspace lock. The
+// caller must call it when done.
 func (ks *Keyspace) Ranges() ([]*ranje.Range, func()) {
 	ks.rangesMu.Lock()
 	return ks.ranges, ks.rangesMu.Unlock
 }
 
+// RangesInState returns the ranges which are currently in any of the given
+// states, ordered by ident, along with a func to release the keyspace lock,
+// like Ranges. This is much cheaper than filtering the result of Ranges, since
+// there are (usually) far more obsolete ranges than others.
+func (ks *Keyspace) RangesInState(states ...api.RangeState) ([]*ranje.Range, func()) {
+	ks.rangesMu.Lock()
+	return ks.idx.inState(states...), ks.rangesMu.Unlock
+}
+
+// Copy returns a deep copy of the keyspace, which can be changed freely, e.g.
+// to see what an operation would do, without affecting this one. Nothing is
+// ever persisted by the copy. Callbacks and placement failures aren't copied.
+func (ks *Keyspace) Copy() (*Keyspace, error) {
+	ranges, unlock := ks.Ranges()
+	b, err := json.Marshal(ranges)
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip through JSON, like the ranges were loaded from the store.
+	var cp []*ranje.Range
+	err = json.Unmarshal(b, &cp)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(&discardPersister{ranges: cp}, ks.replication)
+}
+
+// discardPersister returns the given ranges, and drops every write.
+type discardPersister struct {
+	ranges []*ranje.Range
+}
+
+func (dp *discardPersister) GetRanges() ([]*ranje.Range, error) {
+	return dp.ranges, nil
+}
+
+func (dp *discardPersister) PutRanges([]*ranje.Range) error {
+	return nil
+}
+
+func (dp *discardPersister) DeleteRanges([]*ranje.Range) error {
+	return nil
+}
+
+// Find returns the leaf range (i.e. the range with no children) which contains
+// the given key. There is always exactly one of these. Callers must hold the
+// keyspace lock.
+func (ks *Keyspace) Find(k api.Key) (*ranje.Range, error) {
+	rs := ks.idx.leaves.Containing(k)
+	if len(rs) != 1 {
+		// This should never happen, because sanityCheck checks that the leaf
+		// ranges cover the whole keyspace with no overlaps.
+		return nil, fmt.Errorf("expected one leaf range containing key %q, found %d", k, len(rs))
+	}
+
+	return rs[0], nil
+}
+
+// Split splits the given range in two at the given key. It's a shortcut for
+// SplitN with a single key.
 func (ks *Keyspace) Split(r *ranje.Range, k api.Key) (one *ranje.Range, two *ranje.Range, err error) {
-	if k == api.ZeroKey {
-		err = fmt.Errorf("can't split on zero key")
+	rs, err := ks.SplitN(r, []api.Key{k})
+	if err != nil {
 		return
 	}
 
-	if r.State != api.RsActive {
-		err = errors.New("can't split non-active range")
-		return
+	one, two = rs[0], rs[1]
+	return
+}
+
+// SplitN splits the given range at each of the given keys, which must be in
+// ascending order, and returns the len(keys)+1 new child ranges, ordered by
+// start key. The parent range is moved to RsSubsuming, the children start in
+// RsNew, and all of the ranges are persisted in a single transaction.
+func (ks *Keyspace) SplitN(r *ranje.Range, keys []api.Key) ([]*ranje.Range, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("can't split without any keys")
 	}
 
-	// This should not be possible. Panic?
-	if len(r.Children) > 0 {
-		err = fmt.Errorf("range %s already has %d children", r, len(r.Children))
-		return
+	for i, k := range keys {
+		if k == api.ZeroKey {
+			return nil, fmt.Errorf("can't split on zero key")
+		}
+
+		if !r.Meta.Contains(k) {
+			return nil, fmt.Errorf("range %s does not contain key: %s", r, k)
+		}
+
+		if k == r.Meta.Start {
+			return nil, fmt.Errorf("range %s starts with key: %s", r, k)
+		}
+
+		if i > 0 && k <= keys[i-1] {
+			return nil, fmt.Errorf("split keys not in ascending order: %s, %s", keys[i-1], k)
+		}
 	}
 
-	if !r.Meta.Contains(k) {
-		err = fmt.Errorf("range %s does not contain key: %s", r, k)
-		return
+	if r.State != api.RsActive {
+		return nil, errors.New("can't split non-active range")
 	}
 
-	if k == r.Meta.Start {
-		err = fmt.Errorf("range %s starts with key: %s", r, k)
-		return
+	// This should not be possible. Panic?
+	if len(r.Children) > 0 {
+		return nil, fmt.Errorf("range %s already has %d children", r, len(r.Children))
 	}
 
 	// Change the state of the splitting range directly via Range.toState rather
 	// than Keyspace.ToState as (usually!) recommended, because we don't want
-	// to persist the change until the two new ranges have been created, below.
-	err = r.ToState(api.RsSubsuming)
+	// to persist the change until the new ranges have been created, below.
+	err := r.ToState(api.RsSubsuming)
 	if err != nil {
 		// The error is clear enough, no need to wrap it.
-		return
+		return nil, err
 	}
 
-	// TODO: Child ranges should inherit their replication configs from their
-	//       parent range(s). This is currently okay because there's no way to
-	//       change configs for individual ranges.
+	ks.markDirty(r)
+	ks.idx.update(r)
+
+	children := make([]*ranje.Range, len(keys)+1)
+	r.Children = make([]api.RangeID, len(children))
 
-	one = ks.newRange()
-	one.Meta.Start = r.Meta.Start
-	one.Meta.End = k
-	one.Parents = []api.RangeID{r.Meta.Ident}
+	for i := range children {
+		c := ks.newRange(api.RsNew)
+		c.Parents = []api.RangeID{r.Meta.Ident}
+		c.Replication = r.Replication
+		c.Policy = r.Policy
 
-	two = ks.newRange()
-	two.Meta.Start = k
-	two.Meta.End = r.Meta.End
-	two.Parents = []api.RangeID{r.Meta.Ident}
+		if i == 0 {
+			c.Meta.Start = r.Meta.Start
+		} else {
+			c.Meta.Start = keys[i-1]
+		}
+
+		if i == len(keys) {
+			c.Meta.End = r.Meta.End
+		} else {
+			c.Meta.End = keys[i]
+		}
 
-	// append to the end of the ranges
-	// TODO: Insert the children after the parent, not at the end!
-	ks.ranges = append(ks.ranges, one)
-	ks.ranges = append(ks.ranges, two)
+		// append to the end of the ranges
+		// TODO: Insert the children after the parent, not at the end!
+		ks.ranges = append(ks.ranges, c)
+
+		r.Children[i] = c.Meta.Ident
+		children[i] = c
+	}
 
-	r.Children = []api.RangeID{
-		one.Meta.Ident,
-		two.Meta.Ident,
+	// The parent is no longer a leaf.
+	ks.idx.update(r)
+	for _, c := range children {
+		ks.idx.add(c)
 	}
 
-	// Persist all three ranges.
+	// Persist the parent and all of the children.
 	ks.mustPersistDirtyRanges()
 
-	return
+	return children, nil
 }
 
 // GetRange returns a Range by its ID, or an error if no such range exists. The
@@ -266,21 +500,38 @@ func (ks *Keyspace) Split(r *ranje.Range, k api.Key) (one *ranje.Range, two *ran
 //       this interface sucks and should be replaced as suggested above.
 //
 func (ks *Keyspace) GetRange(rID api.RangeID) (*ranje.Range, error) {
-	for _, r := range ks.ranges {
-		if r.Meta.Ident == rID {
-			return r, nil
-		}
+	if r, ok := ks.idx.byID[rID]; ok {
+		return r, nil
 	}
 
 	return nil, fmt.Errorf("no such range: %s", rID.String())
 }
 
-// newRange returns a new range with the next available ident. This is the only
-// way that a Range should be constructed. Callers are responsible for calling
-// mustPersistDirtyRanges to persist the new range after mutating it.
-func (ks *Keyspace) newRange() *ranje.Range {
+// newRange returns a new range in the given state with the next available
+// ident. This is the only way that a Range should be constructed. Callers are
+// responsible for calling mustPersistDirtyRanges to persist the new range after
+// mutating it.
+func (ks *Keyspace) newRange(state api.RangeState) *ranje.Range {
 	ks.maxIdent += 1
-	return ranje.NewRange(ks.maxIdent, &ks.replication)
+	r := ranje.NewRange(ks.maxIdent, &ks.replication)
+	r.State = state
+	r.History = []ranje.StateChange{{State: state, When: time.Now()}}
+	r.OnEvent(ks.watchers.publish)
+	ks.markDirty(r)
+
+	ks.watchers.publish(ranje.Event{
+		Type:       ranje.EvRangeState,
+		Range:      r.Meta.Ident,
+		RangeState: state,
+	})
+
+	return r
+}
+
+// markDirty records that the given range has been changed, and must be written
+// by the next call to mustPersistDirtyRanges.
+func (ks *Keyspace) markDirty(r *ranje.Range) {
+	ks.dirty[r.Meta.Ident] = r
 }
 
 type PBNID struct {
@@ -293,7 +544,7 @@ type PBNID struct {
 // the given nodeID.
 //
 // This is intended for debugging. If you are using this during rebalancing,
-// you're probably doing something very wrong. It's currently extremely slow.
+// you're probably doing something very wrong. It's currently quite slow.
 //
 // Note that the placements are pointers, so may mutate after returning! Don't
 // fuck around with them.
@@ -303,8 +554,8 @@ func (ks *Keyspace) PlacementsByNodeID(nID api.NodeID) []PBNID {
 	ks.rangesMu.Lock()
 	defer ks.rangesMu.Unlock()
 
-	// TODO: Wow this is dumb! Keep an index of this somewhere.
-	for _, r := range ks.ranges {
+	// TODO: Keep an index of placements by node, too.
+	for _, r := range ks.idx.live() {
 		for i, p := range r.Placements {
 			if p != nil {
 				if p.NodeID == nID {
@@ -330,6 +581,13 @@ func (ks *Keyspace) RangeToState(r *ranje.Range, state api.RangeState) error {
 		return err
 	}
 
+	ks.markDirty(r)
+	ks.idx.update(r)
+
+	if state == api.RsObsolete {
+		ks.obsoleteAt[r.Meta.Ident] = time.Now()
+	}
+
 	return ks.mustPersistDirtyRanges()
 }
 
@@ -341,6 +599,8 @@ func (ks *Keyspace) PlacementToState(p *ranje.Placement, state api.PlacementStat
 		panic(fmt.Sprintf("toState: %v", err))
 	}
 
+	ks.markDirty(p.Range())
+
 	err = ks.mustPersistDirtyRanges()
 	if err != nil {
 		panic(fmt.Sprintf("mustPersistDirtyRanges: %v", err))
@@ -350,29 +610,63 @@ func (ks *Keyspace) PlacementToState(p *ranje.Placement, state api.PlacementStat
 }
 
 // TODO: Return an error instead of panicking, and rename.
+//
+// Ranges stay in the dirty set until they've been persisted as obsolete (or as
+// aborted with no placements), since placements can be created and destroyed
+// without going through the keyspace, so we can't tell whether a live range has
+// changed. Obsolete ranges never change again, so there's no need to keep
+// writing them.
 func (ks *Keyspace) mustPersistDirtyRanges() error {
-	ranges := []*ranje.Range{}
-	for _, r := range ks.ranges {
-		if r.Dirty() {
-			ranges = append(ranges, r)
-		}
+	ranges := make([]*ranje.Range, 0, len(ks.dirty))
+	for _, r := range ks.dirty {
+		ranges = append(ranges, r)
 	}
 
+	// Write them in the order they were created, like we used to.
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Meta.Ident < ranges[j].Meta.Ident
+	})
+
 	err := ks.pers.PutRanges(ranges)
 	if err != nil {
 		panic(fmt.Sprintf("failed to persist ranges: %v", err))
 		//return err
 	}
 
+	ks.notifyChanged()
+
+	for _, r := range ranges {
+		if r.State == api.RsObsolete {
+			delete(ks.dirty, r.Meta.Ident)
+		}
+
+		// Aborted ranges never change again either, once their placements
+		// have all been dropped.
+		if r.State == api.RsAborted && len(r.Placements) == 0 {
+			delete(ks.dirty, r.Meta.Ident)
+		}
+	}
+
 	return nil
 }
 
-// Caller must hold rangesMu.
-//
-// TODO: Make this Join an arbitrary number of ranges. I think that's supported
-//       by the new operations thing now.
+// JoinTwo joins the two given adjacent ranges into one. It's a shortcut for
+// JoinN with two ranges. Caller must hold rangesMu.
 func (ks *Keyspace) JoinTwo(one *ranje.Range, two *ranje.Range) (*ranje.Range, error) {
-	for _, r := range []*ranje.Range{one, two} {
+	return ks.JoinN([]*ranje.Range{one, two})
+}
+
+// JoinN joins the given ranges, which must be active, contiguous, in order of
+// their start keys, and have the same replication config, into a single new
+// range in RsNew, which is returned. The parents are moved to RsSubsuming, and
+// all of the ranges are persisted in a single transaction. Caller must hold
+// rangesMu.
+func (ks *Keyspace) JoinN(rs []*ranje.Range) (*ranje.Range, error) {
+	if len(rs) < 2 {
+		return nil, fmt.Errorf("can't join fewer than two ranges (got %d)", len(rs))
+	}
+
+	for _, r := range rs {
 		if r.State != api.RsActive {
 			return nil, errors.New("can't join non-active ranges")
 		}
@@ -383,32 +677,363 @@ func (ks *Keyspace) JoinTwo(one *ranje.Range, two *ranje.Range) (*ranje.Range, e
 		}
 	}
 
-	// TODO: Probably fine to transparently flip them around in this case.
-	if one.Meta.End != two.Meta.Start {
-		return nil, fmt.Errorf("not adjacent: %s, %s", one, two)
+	for i := 1; i < len(rs); i++ {
+		if rs[i-1].Meta.End != rs[i].Meta.Start {
+			return nil, fmt.Errorf("not adjacent: %s, %s", rs[i-1], rs[i])
+		}
+
+		// The new range will have the same replication config as its parents,
+		// so they must all agree. Otherwise the join could deadlock, waiting
+		// for a number of placements that some parent will never reach.
+		if rs[i].ReplicationConfig() != rs[0].ReplicationConfig() {
+			return nil, fmt.Errorf("incompatible replication configs: %s, %s", rs[0], rs[i])
+		}
+
+		// Likewise the placement policy, since the child can only have one.
+		if rs[i].PlacementPolicy() != rs[0].PlacementPolicy() {
+			return nil, fmt.Errorf("incompatible placement policies: %s, %s", rs[0], rs[i])
+		}
 	}
 
-	for _, r := range []*ranje.Range{one, two} {
-		err := r.ToState(api.RsSubsuming)
+	// Check that all ranges can be subsumed before changing any of them, so we
+	// don't leave some half-joined.
+	for _, r := range rs {
+		err := ranje.CanTransitionRange(r.State, api.RsSubsuming)
 		if err != nil {
 			// The error is clear enough, no need to wrap it.
 			return nil, err
 		}
 	}
 
-	three := ks.newRange()
-	three.Meta.Start = one.Meta.Start
-	three.Meta.End = two.Meta.End
-	three.Parents = []api.RangeID{one.Meta.Ident, two.Meta.Ident}
+	for _, r := range rs {
+		err := r.ToState(api.RsSubsuming)
+		if err != nil {
+			// This should never happen, because we checked above.
+			panic(fmt.Sprintf("ToState: %v", err))
+		}
+
+		ks.markDirty(r)
+		ks.idx.update(r)
+	}
+
+	parents := make([]api.RangeID, len(rs))
+	for i, r := range rs {
+		parents[i] = r.Meta.Ident
+	}
+
+	child := ks.newRange(api.RsNew)
+	child.Meta.Start = rs[0].Meta.Start
+	child.Meta.End = rs[len(rs)-1].Meta.End
+	child.Parents = parents
+
+	// The parents all have the same effective config (see above), but might
+	// not all have it overridden. Just take the first.
+	child.Replication = rs[0].Replication
+	child.Policy = rs[0].Policy
 
 	// Insert new range at the end.
-	ks.ranges = append(ks.ranges, three)
+	ks.ranges = append(ks.ranges, child)
+
+	// The parents are no longer leaves.
+	for _, r := range rs {
+		r.Children = []api.RangeID{child.Meta.Ident}
+		ks.idx.update(r)
+	}
 
-	one.Children = []api.RangeID{three.Meta.Ident}
-	two.Children = []api.RangeID{three.Meta.Ident}
+	ks.idx.add(child)
 
-	// Persist all three ranges atomically.
+	// Persist all of the ranges atomically.
 	ks.mustPersistDirtyRanges()
 
-	return three, nil
+	return child, nil
+}
+
+// SetReplication overrides the replication config of the given range, or, if
+// rc is nil, reverts it to the keyspace default. The range must be active. The
+// orchestrator will add or remove placements to converge on the new config.
+func (ks *Keyspace) SetReplication(rID api.RangeID, rc *ranje.ReplicationConfig) error {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	r, err := ks.GetRange(rID)
+	if err != nil {
+		return err
+	}
+
+	return ks.setReplication([]*ranje.Range{r}, rc)
+}
+
+// SetReplicationSpan is like SetReplication, but applies to every leaf range
+// which overlaps the given span of keys, and returns their idents. Ranges which
+// are only partly in the span are included, so split them first to avoid that.
+// A zero start or end key means unbounded, like range boundaries.
+func (ks *Keyspace) SetReplicationSpan(start, end api.Key, rc *ranje.ReplicationConfig) ([]api.RangeID, error) {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	rs, err := ks.spanLeaves(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ks.setReplication(rs, rc)
+	if err != nil {
+		return nil, err
+	}
+
+	return rangeIDs(rs), nil
+}
+
+// spanLeaves returns the leaf ranges which overlap the given span of keys.
+// Caller must hold rangesMu.
+func (ks *Keyspace) spanLeaves(start, end api.Key) ([]*ranje.Range, error) {
+	if start != api.ZeroKey && end != api.ZeroKey && start >= end {
+		return nil, fmt.Errorf("invalid span: start=%s, end=%s", start, end)
+	}
+
+	rs := []*ranje.Range{}
+	ks.idx.leaves.Walk(func(r *ranje.Range) {
+		if overlaps(r.Meta, start, end) {
+			rs = append(rs, r)
+		}
+	})
+
+	return rs, nil
+}
+
+func rangeIDs(rs []*ranje.Range) []api.RangeID {
+	out := make([]api.RangeID, len(rs))
+	for i := range rs {
+		out[i] = rs[i].Meta.Ident
+	}
+
+	return out
+}
+
+// setReplication replaces the replication config of all of the given ranges,
+// and persists them in a single transaction. None are changed if any of them
+// can't be. Caller must hold rangesMu.
+func (ks *Keyspace) setReplication(rs []*ranje.Range, rc *ranje.ReplicationConfig) error {
+	if rc != nil {
+		if err := rc.Validate(); err != nil {
+			return fmt.Errorf("invalid replication config: %w", err)
+		}
+
+		// Copy, so the caller can't mutate it later.
+		tmp := *rc
+		rc = &tmp
+	}
+
+	// Ranges which are being split or joined (or are the result of that) are
+	// waiting for a specific number of placements, so leave them alone.
+	for _, r := range rs {
+		if r.State != api.RsActive {
+			return fmt.Errorf("can't change replication of non-active range: %s", r)
+		}
+	}
+
+	for _, r := range rs {
+		r.Replication = rc
+		ks.markDirty(r)
+	}
+
+	return ks.mustPersistDirtyRanges()
+}
+
+// SetPlacementPolicy replaces the placement policy of the given range, or, if
+// pp is nil, removes it. The range must be active. The policy only affects
+// placements created from now on; existing ones aren't moved.
+func (ks *Keyspace) SetPlacementPolicy(rID api.RangeID, pp *ranje.PlacementPolicy) error {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	r, err := ks.GetRange(rID)
+	if err != nil {
+		return err
+	}
+
+	return ks.setPlacementPolicy([]*ranje.Range{r}, pp)
+}
+
+// SetPlacementPolicySpan is like SetPlacementPolicy, but applies to every leaf
+// range which overlaps the given span of keys, like SetReplicationSpan.
+func (ks *Keyspace) SetPlacementPolicySpan(start, end api.Key, pp *ranje.PlacementPolicy) ([]api.RangeID, error) {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	rs, err := ks.spanLeaves(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ks.setPlacementPolicy(rs, pp)
+	if err != nil {
+		return nil, err
+	}
+
+	return rangeIDs(rs), nil
+}
+
+// setPlacementPolicy replaces the placement policy of all of the given ranges,
+// and persists them in a single transaction. None are changed if any of them
+// can't be. Caller must hold rangesMu.
+func (ks *Keyspace) setPlacementPolicy(rs []*ranje.Range, pp *ranje.PlacementPolicy) error {
+	if pp != nil {
+		if err := pp.Validate(); err != nil {
+			return fmt.Errorf("invalid placement policy: %w", err)
+		}
+
+		// Copy, so the caller can't mutate it later.
+		tmp := *pp
+		pp = &tmp
+	}
+
+	// Ranges which are being split or joined have already chosen the nodes
+	// for their children, which inherit their policy, so leave them alone.
+	for _, r := range rs {
+		if r.State != api.RsActive {
+			return fmt.Errorf("can't change placement policy of non-active range: %s", r)
+		}
+	}
+
+	for _, r := range rs {
+		r.Policy = pp
+		ks.markDirty(r)
+	}
+
+	return ks.mustPersistDirtyRanges()
+}
+
+// Adjacent returns the leaf ranges immediately before and after the span of
+// the given range, or nil at either end of the keyspace. Callers must hold the
+// keyspace lock.
+func (ks *Keyspace) Adjacent(r *ranje.Range) (prev *ranje.Range, next *ranje.Range) {
+	if r.Meta.End != api.ZeroKey {
+		if rs := ks.idx.leaves.Containing(r.Meta.End); len(rs) == 1 {
+			next = rs[0]
+		}
+	}
+
+	if r.Meta.Start != api.ZeroKey {
+		ks.idx.leaves.Walk(func(rr *ranje.Range) {
+			if rr.Meta.End == r.Meta.Start {
+				prev = rr
+			}
+		})
+	}
+
+	return
+}
+
+// placement returns the placement of the given range on the given node, or an
+// error if there isn't one. Caller must hold rangesMu.
+func (ks *Keyspace) placement(rID api.RangeID, nID api.NodeID) (*ranje.Placement, error) {
+	r, err := ks.GetRange(rID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range r.Placements {
+		if p.NodeID == nID {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no placement of range %s on node %s", rID, nID)
+}
+
+// Untaint clears the taint from the placement of the given range on the given
+// node, so that it won't be deactivated and dropped unless something else
+// (like a move) taints it again.
+func (ks *Keyspace) Untaint(rID api.RangeID, nID api.NodeID) error {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	p, err := ks.placement(rID, nID)
+	if err != nil {
+		return err
+	}
+
+	if !p.Tainted {
+		return fmt.Errorf("placement is not tainted: %s", p.LogString())
+	}
+
+	p.Tainted = false
+	ks.markDirty(p.Range())
+
+	return ks.mustPersistDirtyRanges()
+}
+
+// ClearFailures clears the given actions (or all of them, if none are given)
+// from the failures of the placement of the given range on the given node, so
+// that the actuator will attempt them again. Returns the actions which had
+// failed. Failures aren't persisted, so neither is this.
+func (ks *Keyspace) ClearFailures(rID api.RangeID, nID api.NodeID, actions []api.Action) ([]api.Action, error) {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	p, err := ks.placement(rID, nID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(actions) == 0 {
+		actions = p.Failures()
+	}
+
+	out := []api.Action{}
+	for _, a := range actions {
+		if p.Failed(a) {
+			p.SetFailed(a, false)
+			out = append(out, a)
+		}
+	}
+
+	// Failures aren't persisted, but the actions should be retried promptly.
+	if len(out) > 0 {
+		ks.notifyChanged()
+	}
+
+	return out, nil
+}
+
+// DropPlacement moves the placement of the given range on the given node to
+// PsMissing, as if the node had lost it. The orchestrator will then destroy
+// it (and replace it, if the range needs another placement) without sending
+// any more commands to the node, so this is only safe if the node really has
+// lost it, or will never serve it again.
+func (ks *Keyspace) DropPlacement(rID api.RangeID, nID api.NodeID) error {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	p, err := ks.placement(rID, nID)
+	if err != nil {
+		return err
+	}
+
+	if p.StateCurrent == api.PsMissing || p.StateCurrent == api.PsDropped {
+		return fmt.Errorf("placement is already being dropped: %s", p.LogString())
+	}
+
+	err = p.ToState(api.PsMissing)
+	if err != nil {
+		return err
+	}
+
+	ks.markDirty(p.Range())
+
+	return ks.mustPersistDirtyRanges()
+}
+
+// overlaps returns true if the given range overlaps the given span of keys.
+func overlaps(m api.Meta, start, end api.Key) bool {
+	if end != api.ZeroKey && m.Start != api.ZeroKey && m.Start >= end {
+		return false
+	}
+
+	if start != api.ZeroKey && m.End != api.ZeroKey && m.End <= start {
+		return false
+	}
+
+	return true
 }
@@ -54,6 +54,52 @@ func TestNoOperations(t *testing.T) {
 	require.Empty(t, ops)
 }
 
+func TestNewWithBootstrap(t *testing.T) {
+	pers := &FakePersister{}
+	ks, err := NewWithBootstrap(pers, ranje.R3, Bootstrap{
+		Boundaries: []api.Key{"ggg", "ppp"},
+		Nodes: [][]api.NodeID{
+			{"aaa", "bbb", "ccc"},
+			{"ddd"},
+		},
+	})
+	require.NoError(t, err)
+
+	// The nodes are only a starting point. The orchestrator will place the
+	// rest of the placements as usual.
+	require.Equal(t, "{1 [-inf, ggg] RsActive p0=aaa:PsPending p1=bbb:PsPending p2=ccc:PsPending} {2 (ggg, ppp] RsActive p0=ddd:PsPending} {3 (ppp, +inf] RsActive}", ks.LogString())
+	require.NoError(t, ks.sanityCheck())
+
+	// All persisted together.
+	require.Equal(t, [][]api.RangeID{{1, 2, 3}}, pers.puts)
+
+	// Bootstrap config is ignored when the store already has ranges.
+	pers = &FakePersister{ranges: []*ranje.Range{{
+		State: api.RsActive,
+		Meta:  api.Meta{Ident: 1},
+	}}}
+	ks, err = NewWithBootstrap(pers, ranje.R3, Bootstrap{
+		Boundaries: []api.Key{"mmm"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "{1 [-inf, +inf] RsActive}", ks.LogString())
+	require.Empty(t, pers.puts)
+}
+
+func TestNewWithBootstrap_Invalid(t *testing.T) {
+	for _, boot := range []Bootstrap{
+		{Boundaries: []api.Key{"ppp", "ggg"}},
+		{Boundaries: []api.Key{"ggg", "ggg"}},
+		{Boundaries: []api.Key{""}},
+		{Boundaries: []api.Key{"ggg"}, Nodes: [][]api.NodeID{{"aaa"}, {"bbb"}, {"ccc"}}},
+		{Nodes: [][]api.NodeID{{"aaa", "bbb"}}},
+		{Nodes: [][]api.NodeID{{"aaa", "aaa"}}},
+	} {
+		_, err := NewWithBootstrap(&FakePersister{}, ranje.R1, boot)
+		require.Error(t, err, "%+v", boot)
+	}
+}
+
 func TestSplitIntoThree(t *testing.T) {
 
 	//          ┌─────┐
@@ -425,17 +471,561 @@ func TestPlacementMayBeDeactivated(t *testing.T) {
 	}
 }
 
+func TestIndex_Split(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	one, two, err := ks.Split(r1, "ccc")
+	require.NoError(t, err)
+
+	for k, expected := range map[api.Key]*ranje.Range{
+		api.ZeroKey: one,
+		"ccb":       one,
+		"ccc":       two,
+		"zzz":       two,
+	} {
+		actual, err := ks.Find(k)
+		require.NoError(t, err, "k=%q", k)
+		require.Same(t, expected, actual, "k=%q", k)
+	}
+
+	requireInState(t, ks, api.RsNew, one, two)
+	requireInState(t, ks, api.RsSubsuming, r1)
+	requireInState(t, ks, api.RsObsolete)
+
+	completeOp(t, ks, r1)
+	requireInState(t, ks, api.RsNew)
+	requireInState(t, ks, api.RsActive, one, two)
+	requireInState(t, ks, api.RsSubsuming)
+	requireInState(t, ks, api.RsObsolete, r1)
+}
+
+func TestSplitN(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	rs, err := ks.SplitN(r1, []api.Key{"bbb", "ccc", "ddd"})
+	require.NoError(t, err)
+	require.Len(t, rs, 4)
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming} {2 [-inf, bbb] RsNew} {3 (bbb, ccc] RsNew} {4 (ccc, ddd] RsNew} {5 (ddd, +inf] RsNew}", ks.LogString())
+
+	for _, r := range rs {
+		require.Equal(t, []api.RangeID{1}, r.Parents)
+	}
+	require.Equal(t, []api.RangeID{2, 3, 4, 5}, r1.Children)
+
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	require.Equal(t, "{Split 1 -> 2,3,4,5}", ops[0].TestString())
+
+	for k, expected := range map[api.Key]*ranje.Range{
+		api.ZeroKey: rs[0],
+		"bbb":       rs[1],
+		"ccb":       rs[1],
+		"ccc":       rs[2],
+		"zzz":       rs[3],
+	} {
+		actual, err := ks.Find(k)
+		require.NoError(t, err, "k=%q", k)
+		require.Same(t, expected, actual, "k=%q", k)
+	}
+}
+
+func TestSplitN_Invalid(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	for _, keys := range [][]api.Key{
+		{},
+		{"ccc", "bbb"},
+		{"bbb", "bbb"},
+		{"bbb", api.ZeroKey},
+	} {
+		_, err := ks.SplitN(r1, keys)
+		require.Error(t, err, "keys=%v", keys)
+	}
+
+	// Nothing was changed.
+	require.Equal(t, "{1 [-inf, +inf] RsActive}", ks.LogString())
+	requireInState(t, ks, api.RsActive, r1)
+}
+
+func TestIndex_JoinTwo(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	one, two, err := ks.Split(r1, "ccc")
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	three, err := ks.JoinTwo(one, two)
+	require.NoError(t, err)
+
+	for _, k := range []api.Key{api.ZeroKey, "ccb", "ccc", "zzz"} {
+		actual, err := ks.Find(k)
+		require.NoError(t, err, "k=%q", k)
+		require.Same(t, three, actual, "k=%q", k)
+	}
+
+	requireInState(t, ks, api.RsNew, three)
+	requireInState(t, ks, api.RsSubsuming, one, two)
+	requireInState(t, ks, api.RsObsolete, r1)
+
+	completeOp(t, ks, one, two)
+	requireInState(t, ks, api.RsActive, three)
+	requireInState(t, ks, api.RsSubsuming)
+	requireInState(t, ks, api.RsObsolete, r1, one, two)
+}
+
+func TestIndex_JoinTwoFailure(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	one, two, err := ks.Split(r1, "ccc")
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	// Split the right side again, so it can't be joined.
+	three, four, err := ks.Split(two, "ddd")
+	require.NoError(t, err)
+
+	_, err = ks.JoinTwo(one, two)
+	require.EqualError(t, err, "can't join non-active ranges")
+
+	// Neither range was changed.
+	require.Equal(t, api.RsActive, one.State)
+	require.Equal(t, api.RsSubsuming, two.State)
+	requireInState(t, ks, api.RsActive, one)
+	requireInState(t, ks, api.RsNew, three, four)
+	requireInState(t, ks, api.RsSubsuming, two)
+}
+
+func TestJoinN(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	rs, err := ks.SplitN(r1, []api.Key{"bbb", "ccc"})
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	r5, err := ks.JoinN(rs)
+	require.NoError(t, err)
+	require.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, bbb] RsSubsuming} {3 (bbb, ccc] RsSubsuming} {4 (ccc, +inf] RsSubsuming} {5 [-inf, +inf] RsNew}", ks.LogString())
+	require.Equal(t, []api.RangeID{2, 3, 4}, r5.Parents)
+
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	require.Equal(t, "{Join 2,3,4 -> 5}", ops[0].TestString())
+
+	actual, err := ks.Find("bbb")
+	require.NoError(t, err)
+	require.Same(t, r5, actual)
+}
+
+func TestJoinN_Invalid(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	rs, err := ks.SplitN(r1, []api.Key{"bbb", "ccc"})
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	_, err = ks.JoinN(rs[:1])
+	require.EqualError(t, err, "can't join fewer than two ranges (got 1)")
+
+	_, err = ks.JoinN([]*ranje.Range{rs[0], rs[2]})
+	require.EqualError(t, err, "not adjacent: R{2 [-inf, bbb] RsActive}, R{4 (ccc, +inf] RsActive}")
+
+	_, err = ks.JoinN([]*ranje.Range{rs[1], rs[0]})
+	require.Error(t, err)
+
+	rs[2].Repair(&ranje.R3)
+	_, err = ks.JoinN(rs)
+	require.EqualError(t, err, "incompatible replication configs: R{2 [-inf, bbb] RsActive}, R{4 (ccc, +inf] RsActive}")
+
+	// None of the ranges were changed.
+	requireInState(t, ks, api.RsActive, rs...)
+	requireInState(t, ks, api.RsSubsuming)
+}
+
+func TestAbort_Split(t *testing.T) {
+	pers := &FakePersister{}
+	ks, err := New(pers, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+	r1.NewPlacement("aaa").StateCurrent = api.PsActive
+
+	rs, err := ks.SplitN(r1, []api.Key{"ccc"})
+	require.NoError(t, err)
+	rs[0].NewPlacement("bbb")
+
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	require.NoError(t, ops[0].MayAbort())
+	require.NoError(t, ops[0].Abort(ks))
+
+	// The parent is active again, and the children will never be.
+	require.Equal(t, "{1 [-inf, +inf] RsActive p0=aaa:PsActive} {2 [-inf, ccc] RsAborted p0=bbb:PsPending} {3 (ccc, +inf] RsAborted}", ks.LogString())
+	require.Empty(t, r1.Children)
+	require.Equal(t, []api.RangeID{1}, rs[0].Parents)
+	requireInState(t, ks, api.RsActive, r1)
+	requireInState(t, ks, api.RsAborted, rs...)
+
+	ops, err = ks.Operations()
+	require.NoError(t, err)
+	require.Empty(t, ops)
+
+	actual, err := ks.Find("ccc")
+	require.NoError(t, err)
+	require.Same(t, r1, actual)
+	require.NoError(t, ks.sanityCheck())
+
+	// Aborted ranges can't be activated, and can always be dropped.
+	p := rs[0].Placements[0]
+	p.StateCurrent = api.PsInactive
+	require.EqualError(t, (*Operation)(nil).MayActivate(p, rs[0]), "range aborted")
+	require.NoError(t, (*Operation)(nil).MayDrop(p, rs[0]))
+
+	// Everything was persisted.
+	for _, r := range pers.ranges {
+		if r.Meta.Ident == 1 {
+			require.Equal(t, api.RsActive, r.State)
+		} else {
+			require.Equal(t, api.RsAborted, r.State)
+		}
+	}
+}
+
+func TestAbort_Refused(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+	p1 := r1.NewPlacement("aaa")
+	p1.StateCurrent = api.PsInactive
+
+	rs, err := ks.SplitN(r1, []api.Key{"ccc"})
+	require.NoError(t, err)
+	p2 := rs[0].NewPlacement("bbb")
+	p2.StateCurrent = api.PsActive
+
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+
+	// One of the children is already serving, so the parent can't go back.
+	require.EqualError(t, ops[0].MayAbort(), "child range has active placements (rID=2, n=1)")
+	require.Error(t, ops[0].Abort(ks))
+
+	// The parent has nothing left to serve.
+	p2.StateCurrent = api.PsInactive
+	p1.StateCurrent = api.PsDropped
+	require.EqualError(t, ops[0].MayAbort(), "parent range has no placements left (rID=1)")
+
+	// Nothing was changed.
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsDropped} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew}", ks.LogString())
+}
+
+func TestSetReplication(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+	require.Equal(t, ranje.R1, r1.ReplicationConfig())
+
+	require.NoError(t, ks.SetReplication(1, &ranje.R3))
+	require.Equal(t, ranje.R3, r1.ReplicationConfig())
+
+	// Children inherit the config when split.
+	rs, err := ks.SplitN(r1, []api.Key{"bbb", "ccc"})
+	require.NoError(t, err)
+	for _, r := range rs {
+		require.Equal(t, ranje.R3, r.ReplicationConfig())
+	}
+
+	// Can't change it while an operation is in progress.
+	require.EqualError(t, ks.SetReplication(1, nil), "can't change replication of non-active range: R{1 [-inf, +inf] RsSubsuming}")
+	require.Error(t, ks.SetReplication(2, nil))
+	completeOp(t, ks, r1)
+
+	// Revert the middle range back to the default.
+	require.NoError(t, ks.SetReplication(3, nil))
+	require.Nil(t, rs[1].Replication)
+	require.Equal(t, ranje.R1, rs[1].ReplicationConfig())
+
+	// Now they can't be joined, because the configs differ.
+	_, err = ks.JoinN(rs)
+	require.EqualError(t, err, "incompatible replication configs: R{2 [-inf, bbb] RsActive}, R{3 (bbb, ccc] RsActive}")
+}
+
+func TestSetReplicationSpan(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+
+	rs, err := ks.SplitN(r1, []api.Key{"bbb", "ccc", "ddd"})
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	for _, ex := range []struct {
+		start, end api.Key
+		expected   []api.RangeID
+	}{
+		{api.ZeroKey, api.ZeroKey, []api.RangeID{2, 3, 4, 5}},
+		{"bbb", "ccc", []api.RangeID{3}},
+		{"bbb", "ccd", []api.RangeID{3, 4}},
+		{"bba", "ccc", []api.RangeID{2, 3}},
+		{api.ZeroKey, "bbb", []api.RangeID{2}},
+		{"zzz", api.ZeroKey, []api.RangeID{5}},
+	} {
+		rIDs, err := ks.SetReplicationSpan(ex.start, ex.end, &ranje.R3)
+		require.NoError(t, err, "start=%q, end=%q", ex.start, ex.end)
+		require.Equal(t, ex.expected, rIDs, "start=%q, end=%q", ex.start, ex.end)
+	}
+
+	_, err = ks.SetReplicationSpan("ccc", "bbb", nil)
+	require.Error(t, err)
+
+	// Join two ranges with the same overridden config; the child inherits it.
+	r6, err := ks.JoinN(rs[2:])
+	require.NoError(t, err)
+	require.Equal(t, ranje.R3, r6.ReplicationConfig())
+	require.NotNil(t, r6.Replication)
+}
+
+func TestSetPlacementPolicy(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.Find(api.ZeroKey)
+	require.NoError(t, err)
+	require.Equal(t, ranje.PlacementPolicy{}, r1.PlacementPolicy())
+
+	pp := ranje.PlacementPolicy{Require: "pool=a"}
+	require.NoError(t, ks.SetPlacementPolicy(1, &pp))
+	require.Equal(t, pp, r1.PlacementPolicy())
+
+	err = ks.SetPlacementPolicy(1, &ranje.PlacementPolicy{Avoid: "!"})
+	require.EqualError(t, err, `invalid placement policy: invalid Avoid: invalid selector requirement: "!"`)
+
+	// Children inherit the policy when split.
+	rs, err := ks.SplitN(r1, []api.Key{"bbb", "ccc"})
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+	for _, r := range rs {
+		require.Equal(t, pp, r.PlacementPolicy())
+	}
+
+	// The middle range is adjacent to the others.
+	prev, next := ks.Adjacent(rs[1])
+	require.Equal(t, rs[0], prev)
+	require.Equal(t, rs[2], next)
+	prev, next = ks.Adjacent(rs[0])
+	require.Nil(t, prev)
+	require.Equal(t, rs[1], next)
+
+	// Remove it from the middle range, which can then no longer be joined.
+	rIDs, err := ks.SetPlacementPolicySpan("bbb", "ccc", nil)
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{3}, rIDs)
+	require.Nil(t, rs[1].Policy)
+
+	_, err = ks.JoinN(rs)
+	require.EqualError(t, err, "incompatible placement policies: R{2 [-inf, bbb] RsActive}, R{3 (bbb, ccc] RsActive}")
+}
+
+func TestIndex_New(t *testing.T) {
+	orig := historyFixture(t, 5, 100)
+	ranges, unlock := orig.Ranges()
+	unlock()
+
+	ks, err := New(&FakePersister{ranges: ranges}, ranje.R1)
+	require.NoError(t, err)
+
+	for _, s := range []api.RangeState{api.RsActive, api.RsSubsuming, api.RsObsolete, api.RsNew} {
+		expected, unlock := orig.RangesInState(s)
+		unlock()
+		requireInState(t, ks, s, expected...)
+	}
+
+	// The obsolete ranges are the bulk of the history.
+	obsolete, unlock := ks.RangesInState(api.RsObsolete)
+	unlock()
+	require.Len(t, obsolete, len(ranges)-5)
+
+	for i := 0; i < 5; i++ {
+		k := api.Key(fmt.Sprintf("%08d", i))
+		expected, err := orig.Find(k)
+		require.NoError(t, err)
+
+		actual, err := ks.Find(k)
+		require.NoError(t, err)
+		require.Equal(t, expected.Meta.Ident, actual.Meta.Ident, "k=%q", k)
+	}
+}
+
+func TestSanityCheck_Overlap(t *testing.T) {
+	// Leaf ranges are ordered by start key and then ident, so check that
+	// overlapping leaves which share a start key are caught in either order.
+	examples := []struct {
+		name   string
+		ranges []*ranje.Range
+		err    string
+	}{
+		{
+			name: "wide first",
+			ranges: []*ranje.Range{
+				{Meta: api.Meta{Ident: 1, End: "ccc"}, State: api.RsActive},
+				{Meta: api.Meta{Ident: 2, Start: "ccc"}, State: api.RsActive},
+				{Meta: api.Meta{Ident: 3, Start: "ccc", End: "ddd"}, State: api.RsActive},
+			},
+			err: "non-last leaf range ended with zero key (rID=2)",
+		},
+		{
+			name: "narrow first",
+			ranges: []*ranje.Range{
+				{Meta: api.Meta{Ident: 1, End: "ccc"}, State: api.RsActive},
+				{Meta: api.Meta{Ident: 2, Start: "ccc", End: "ddd"}, State: api.RsActive},
+				{Meta: api.Meta{Ident: 3, Start: "ccc"}, State: api.RsActive},
+			},
+			err: "leaf range does not begin at prior leaf range end (i=2)",
+		},
+	}
+
+	for _, ex := range examples {
+		ks := &Keyspace{ranges: ex.ranges, idx: newRangeIndex()}
+		for _, r := range ex.ranges {
+			ks.idx.add(r)
+		}
+
+		require.EqualError(t, ks.sanityCheck(), ex.err, ex.name)
+	}
+}
+
+// requireInState asserts that exactly the given ranges are in the given state,
+// according to the keyspace index.
+func requireInState(t *testing.T, ks *Keyspace, s api.RangeState, expected ...*ranje.Range) {
+	t.Helper()
+
+	actual, unlock := ks.RangesInState(s)
+	defer unlock()
+
+	require.ElementsMatch(t, idents(expected), idents(actual), "state=%s", s)
+}
+
+// historyFixture returns a keyspace with the given number of leaf ranges, and
+// at least the given number of ranges in total, most of which are obsolete. The
+// history is built by repeatedly splitting a leaf range and joining it back.
+func historyFixture(tb testing.TB, leaves, history int) *Keyspace {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(tb, err)
+
+	// Split the genesis range into the requested number of leaves.
+	for i := 1; i < leaves; i++ {
+		r, err := ks.Find(api.Key(fmt.Sprintf("%08d", i)))
+		require.NoError(tb, err)
+
+		_, _, err = ks.Split(r, api.Key(fmt.Sprintf("%08d", i)))
+		require.NoError(tb, err)
+		completeOp(tb, ks, r)
+	}
+
+	// Churn through some splits and joins until the history is long enough.
+	for i := 0; len(ks.ranges) < history; i++ {
+		k := api.Key(fmt.Sprintf("%08d", 1+(i%(leaves-1))))
+
+		r, err := ks.Find(k)
+		require.NoError(tb, err)
+
+		one, two, err := ks.Split(r, k+"m")
+		require.NoError(tb, err)
+		completeOp(tb, ks, r)
+
+		_, err = ks.JoinTwo(one, two)
+		require.NoError(tb, err)
+		completeOp(tb, ks, one, two)
+	}
+
+	return ks
+}
+
 // -----------------------------------------------------------------------------
 
 // TODO: Unify this with the one in orchestrator_test.go
 type FakePersister struct {
-	ranges []*ranje.Range
+	ranges  []*ranje.Range
+	deleted []api.RangeID
+
+	// The IDs of the ranges passed to each call to PutRanges and DeleteRanges.
+	puts    [][]api.RangeID
+	deletes [][]api.RangeID
 }
 
 func (fp *FakePersister) GetRanges() ([]*ranje.Range, error) {
 	return fp.ranges, nil
 }
 
-func (fp *FakePersister) PutRanges([]*ranje.Range) error {
+func (fp *FakePersister) PutRanges(rs []*ranje.Range) error {
+	fp.puts = append(fp.puts, idents(rs))
 	return nil
 }
+
+func (fp *FakePersister) DeleteRanges(rs []*ranje.Range) error {
+	fp.deletes = append(fp.deletes, idents(rs))
+	for _, r := range rs {
+		fp.deleted = append(fp.deleted, r.Meta.Ident)
+	}
+	return nil
+}
+
+// completeOp moves the given parent ranges to RsObsolete and their children to
+// RsActive, like Operation.CheckComplete does once the parents' placements have
+// all been dropped.
+func completeOp(tb testing.TB, ks *Keyspace, parents ...*ranje.Range) {
+	tb.Helper()
+
+	for _, r := range parents {
+		require.NoError(tb, ks.RangeToState(r, api.RsObsolete))
+	}
+
+	for _, r := range parents {
+		for _, rID := range r.Children {
+			rc, err := ks.GetRange(rID)
+			require.NoError(tb, err)
+
+			if rc.State == api.RsNew {
+				require.NoError(tb, ks.RangeToState(rc, api.RsActive))
+			}
+		}
+	}
+}
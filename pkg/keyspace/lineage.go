@@ -0,0 +1,112 @@
+package keyspace
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// RangeHistory is a snapshot of a range and the states it has been in, which
+// is safe to use after the keyspace lock has been released.
+type RangeHistory struct {
+	Meta     api.Meta
+	State    api.RangeState
+	Parents  []api.RangeID
+	Children []api.RangeID
+	History  []ranje.StateChange
+}
+
+func newRangeHistory(r *ranje.Range) RangeHistory {
+	r.Lock()
+	defer r.Unlock()
+
+	return RangeHistory{
+		Meta:     r.Meta,
+		State:    r.State,
+		Parents:  append([]api.RangeID(nil), r.Parents...),
+		Children: append([]api.RangeID(nil), r.Children...),
+		History:  append([]ranje.StateChange(nil), r.History...),
+	}
+}
+
+// Lineage returns the given range followed by all of its ancestors which have
+// not been garbage collected, nearest first. Each range appears only once, even
+// if it's reachable by more than one path (e.g. split then joined back), so the
+// tree must be rebuilt from the Parents of each.
+func (ks *Keyspace) Lineage(rID api.RangeID) ([]RangeHistory, error) {
+	ks.rangesMu.RLock()
+	defer ks.rangesMu.RUnlock()
+
+	r, ok := ks.idx.byID[rID]
+	if !ok {
+		return nil, fmt.Errorf("no such range: %s", rID)
+	}
+
+	out := []RangeHistory{}
+	seen := map[api.RangeID]struct{}{rID: {}}
+	queue := []*ranje.Range{r}
+
+	for len(queue) > 0 {
+		r, queue = queue[0], queue[1:]
+		out = append(out, newRangeHistory(r))
+
+		for _, pID := range r.Parents {
+			if _, ok := seen[pID]; ok {
+				continue
+			}
+			seen[pID] = struct{}{}
+
+			// Parents which have been collected are removed from their children,
+			// so this shouldn't happen. But don't make it fatal.
+			if p, ok := ks.idx.byID[pID]; ok {
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// RangeAt returns the range which owned the given key at the given time, i.e.
+// was active or subsuming, and so could have had placements serving it. Ranges
+// which don't have enough history to say, either because it was collected or
+// because it predates state changes being recorded, result in an error.
+func (ks *Keyspace) RangeAt(k api.Key, t time.Time) (RangeHistory, error) {
+	ks.rangesMu.RLock()
+	defer ks.rangesMu.RUnlock()
+
+	// Start at the range which owns the key now, and walk backwards.
+	r, err := ks.Find(k)
+	if err != nil {
+		return RangeHistory{}, err
+	}
+
+	for {
+		if len(r.History) == 0 {
+			return RangeHistory{}, fmt.Errorf("no history for range: %s", r.Meta.Ident)
+		}
+
+		s, ok := r.StateAt(t)
+		if ok && (s == api.RsActive || s == api.RsSubsuming) {
+			return newRangeHistory(r), nil
+		}
+
+		// The range was new or didn't exist yet, so its parent must have owned
+		// the key. Only one of them can contain it.
+		var next *ranje.Range
+		for _, pID := range r.Parents {
+			if p, ok := ks.idx.byID[pID]; ok && p.Meta.Contains(k) {
+				next = p
+				break
+			}
+		}
+
+		if next == nil {
+			return RangeHistory{}, fmt.Errorf("no range owned key %q at %s (earliest=%s)", k, t.Format(time.RFC3339Nano), r.Meta.Ident)
+		}
+
+		r = next
+	}
+}
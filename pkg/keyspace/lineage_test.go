@@ -0,0 +1,94 @@
+package keyspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineage(t *testing.T) {
+	ks, _ := retentionFixture(t)
+
+	lineage, err := ks.Lineage(5)
+	require.NoError(t, err)
+
+	// Range 1 is reachable via both 2 and 3, but only appears once.
+	rIDs := make([]api.RangeID, len(lineage))
+	for i := range lineage {
+		rIDs[i] = lineage[i].Meta.Ident
+	}
+	require.Equal(t, []api.RangeID{5, 4, 2, 3, 1}, rIDs)
+	require.Equal(t, []api.RangeID{4}, lineage[0].Parents)
+	require.Equal(t, []api.RangeID{2, 3}, lineage[1].Parents)
+	require.Equal(t, []api.RangeID{5, 6}, lineage[1].Children)
+	require.Empty(t, lineage[4].Parents)
+
+	// Range 4 was born new, and then became active, subsuming, and obsolete.
+	states := []api.RangeState{}
+	for _, sc := range lineage[1].History {
+		states = append(states, sc.State)
+	}
+	require.Equal(t, []api.RangeState{api.RsNew, api.RsActive, api.RsSubsuming, api.RsObsolete}, states)
+
+	// Collected ranges are omitted.
+	ks.SetRetention(Retention{Generations: 1})
+	_, err = ks.GC()
+	require.NoError(t, err)
+	lineage, err = ks.Lineage(5)
+	require.NoError(t, err)
+	require.Len(t, lineage, 2)
+
+	_, err = ks.Lineage(1)
+	require.Error(t, err)
+}
+
+func TestRangeAt(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+	r := rangeGetter(t, ks)
+
+	rangeAt := func(k string, t0 time.Time) api.RangeID {
+		rh, err := ks.RangeAt(api.Key(k), t0)
+		require.NoError(t, err)
+		return rh.Meta.Ident
+	}
+
+	tBefore := time.Now().Add(-time.Hour)
+	tGenesis := time.Now()
+
+	_, _, err = ks.Split(r(1), api.Key("ccc"))
+	require.NoError(t, err)
+	tSplitting := time.Now()
+	completeOp(t, ks, r(1))
+	tSplit := time.Now()
+
+	_, err = ks.JoinTwo(r(2), r(3))
+	require.NoError(t, err)
+	tJoining := time.Now()
+	completeOp(t, ks, r(2), r(3))
+	tJoined := time.Now()
+
+	// Parents own their keys until the operation completes.
+	require.Equal(t, api.RangeID(1), rangeAt("aaa", tGenesis))
+	require.Equal(t, api.RangeID(1), rangeAt("aaa", tSplitting))
+	require.Equal(t, api.RangeID(2), rangeAt("aaa", tSplit))
+	require.Equal(t, api.RangeID(3), rangeAt("ddd", tSplit))
+	require.Equal(t, api.RangeID(3), rangeAt("ddd", tJoining))
+	require.Equal(t, api.RangeID(4), rangeAt("ddd", tJoined))
+	require.Equal(t, api.RangeID(4), rangeAt("ddd", time.Now().Add(time.Hour)))
+
+	// Nothing existed before the genesis range.
+	_, err = ks.RangeAt(api.Key("aaa"), tBefore)
+	require.Error(t, err)
+
+	// Or after it's been collected.
+	ks.SetRetention(Retention{Generations: 1})
+	_, err = ks.GC()
+	require.NoError(t, err)
+	_, err = ks.RangeAt(api.Key("aaa"), tGenesis)
+	require.Error(t, err)
+	require.Equal(t, api.RangeID(2), rangeAt("aaa", tSplit))
+}
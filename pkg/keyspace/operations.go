@@ -6,6 +6,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/adammck/ranger/pkg/api"
 	"github.com/adammck/ranger/pkg/ranje"
@@ -15,13 +16,14 @@ import (
 // progress. Invalidated after any kind of transformation.
 func (ks *Keyspace) Operations() ([]*Operation, error) {
 
-	// Build a set of active ranges to consider. This is churning through the
-	// whole range history, but only really needs the leaf ranges. Keep an index
-	// of those in the keyspace.
-	ranges := map[api.RangeID]*ranje.Range{}
-	for _, r := range ks.ranges {
-		if r.State == api.RsActive {
-			ranges[r.Meta.Ident] = r
+	// Build a set of new and active ranges to consider. This is a copy, because
+	// we remove ranges from it as we go. The children of operations in flight
+	// are usually new, but might be active if they were created by an older
+	// version which didn't have RsNew.
+	ranges := make(map[api.RangeID]*ranje.Range, len(ks.idx.byState[api.RsNew])+len(ks.idx.byState[api.RsActive]))
+	for _, s := range []api.RangeState{api.RsNew, api.RsActive} {
+		for rID, r := range ks.idx.byState[s] {
+			ranges[rID] = r
 		}
 	}
 
@@ -167,7 +169,7 @@ func (op *Operation) Ranges() []*ranje.Range {
 }
 
 // CheckComplete checks the status of the operation, and if complete, marks the
-// parent ranges as obsolete and returns true.
+// parent ranges as obsolete and the child ranges as active, and returns true.
 // TODO: Maybe just make the ks a field on Operation.
 func (op *Operation) CheckComplete(ks *Keyspace) (bool, error) {
 	for _, r := range op.parents {
@@ -183,9 +185,97 @@ func (op *Operation) CheckComplete(ks *Keyspace) (bool, error) {
 		}
 	}
 
+	for _, r := range op.children {
+		if r.State != api.RsNew {
+			continue
+		}
+
+		err := ks.RangeToState(r, api.RsActive)
+		if err != nil {
+			return false, fmt.Errorf("while completing operation: %w", err)
+		}
+	}
+
 	return true, nil
 }
 
+// MayAbort returns an error if the operation can't be aborted, i.e. if the
+// parent ranges can't safely go back to being active. That's the case once any
+// of the child ranges have an active placement, because the parents might have
+// been deactivated and their contents may be out of date. It's also the case
+// if any of the parents no longer have any placements which could serve,
+// because they've been dropped.
+func (op *Operation) MayAbort() error {
+	for _, r := range op.children {
+		if r.State != api.RsNew {
+			return fmt.Errorf("child range is not new (rID=%s, state=%s)", r.Meta.Ident, r.State)
+		}
+
+		if n := r.NumPlacementsInState(api.PsActive); n > 0 {
+			return fmt.Errorf("child range has active placements (rID=%s, n=%d)", r.Meta.Ident, n)
+		}
+	}
+
+	for _, r := range op.parents {
+		n := r.NumPlacements(func(p *ranje.Placement) bool {
+			return p.StateCurrent != api.PsDropped && p.StateCurrent != api.PsMissing
+		})
+		if n == 0 {
+			return fmt.Errorf("parent range has no placements left (rID=%s)", r.Meta.Ident)
+		}
+	}
+
+	return nil
+}
+
+// Abort gives up on the operation, if MayAbort allows it. The parent ranges go
+// back to RsActive, as if the operation never happened, and the child ranges
+// are moved to RsAborted, so their placements will be dropped. All of the
+// ranges are persisted in a single transaction.
+func (op *Operation) Abort(ks *Keyspace) error {
+	if err := op.MayAbort(); err != nil {
+		return fmt.Errorf("can't abort operation: %w", err)
+	}
+
+	// Check that all of the ranges can transition before changing any of them,
+	// so we don't leave the operation half-aborted.
+	for _, r := range op.parents {
+		if err := ranje.CanTransitionRange(r.State, api.RsActive); err != nil {
+			return err
+		}
+	}
+	for _, r := range op.children {
+		if err := ranje.CanTransitionRange(r.State, api.RsAborted); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range op.children {
+		if err := r.ToState(api.RsAborted); err != nil {
+			// This should never happen, because we checked above.
+			panic(fmt.Sprintf("ToState: %v", err))
+		}
+
+		ks.markDirty(r)
+		ks.idx.update(r)
+		ks.obsoleteAt[r.Meta.Ident] = time.Now()
+	}
+
+	// The parents become leaves again. The children keep their links to the
+	// parents, so it's still possible to see where they came from.
+	for _, r := range op.parents {
+		if err := r.ToState(api.RsActive); err != nil {
+			panic(fmt.Sprintf("ToState: %v", err))
+		}
+
+		r.Children = nil
+		ks.markDirty(r)
+		ks.idx.update(r)
+	}
+
+	return ks.mustPersistDirtyRanges()
+}
+
 // isRecalling returns true if the given parent and child ranges should result
 // in a recall, i.e. if this operation should (temporarily) flow in reverse, by
 // deactivating the children and activating the parents.
@@ -240,7 +330,7 @@ var ErrNoParents = errors.New("given range has no parents")
 var ErrObsoleteParents = errors.New("given range has obsolete parents")
 
 func opFromRange(ks *Keyspace, r *ranje.Range) (op *Operation, err error) {
-	if r.State != api.RsActive {
+	if r.State != api.RsActive && r.State != api.RsNew {
 		panic("bug: called opFromRange with non-active range")
 	}
 
@@ -337,6 +427,11 @@ func (op *Operation) MayActivate(p *ranje.Placement, r *ranje.Range) error {
 		return fmt.Errorf("gave up")
 	}
 
+	// The operation this range was born in was aborted, so it must never serve.
+	if r.State == api.RsAborted {
+		return fmt.Errorf("range aborted")
+	}
+
 	active := r.NumPlacementsInState(api.PsActive)
 
 	// Count how many active placements this range has. If there are already the
@@ -345,6 +440,24 @@ func (op *Operation) MayActivate(p *ranje.Placement, r *ranje.Range) error {
 		return fmt.Errorf("too many active placements (n=%d, MaxActive=%d)", active, r.MaxActive())
 	}
 
+	// Untainted placements beyond the target are spares. They stay inactive
+	// until one of the others is lost. Placements which are already on their
+	// way to active are counted, since several may be activated in one tick.
+	if !p.Tainted {
+		n := r.NumPlacements(func(other *ranje.Placement) bool {
+			if other == p || other.Tainted {
+				return false
+			}
+			if other.StateCurrent == api.PsActive {
+				return true
+			}
+			return other.StateDesired == api.PsActive && !other.Failed(api.Activate)
+		})
+		if n >= r.TargetActive() {
+			return fmt.Errorf("enough active placements (n=%d, TargetActive=%d)", n, r.TargetActive())
+		}
+	}
+
 	if op == nil {
 
 		// If this placement is tainted, *only* allow it to activate if there
@@ -487,6 +600,11 @@ func (op *Operation) MayDrop(p *ranje.Placement, r *ranje.Range) error {
 		return fmt.Errorf("placment not in api.PsInactive")
 	}
 
+	// Aborted ranges are never activated, so there's no reason to keep them.
+	if r.State == api.RsAborted {
+		return nil
+	}
+
 	if op == nil {
 
 		// If the placement is tainted, we *want* to drop it. It's probably been
@@ -16,12 +16,10 @@ type Repl struct {
 
 func (ks *Keyspace) ReplicationState() []Repl {
 	flat := flatRanges(ks)
+	live := ks.idx.live()
 
 	for i := range flat {
-		for _, r := range ks.ranges {
-			if r.State == api.RsObsolete {
-				continue
-			}
+		for _, r := range live {
 			if r.Meta.Contains(flat[i].Start) {
 				log.Printf("%s: r=%s, t=%d, a=%d", flat[i].Start, r.String(), len(r.Placements), r.NumPlacementsInState(api.PsActive))
 				flat[i].Total += len(r.Placements)
@@ -35,13 +33,11 @@ func (ks *Keyspace) ReplicationState() []Repl {
 
 func flatRanges(ks *Keyspace) []Repl {
 
-	keyMap := make(map[api.Key]struct{}, len(ks.ranges))
-	for _, r := range ks.ranges {
-		if r.State == api.RsObsolete {
-			// Obsolete ranges can't have placements, so skip them.
-			continue
-		}
+	// Obsolete ranges can't have placements, so skip them.
+	live := ks.idx.live()
 
+	keyMap := make(map[api.Key]struct{}, len(live))
+	for _, r := range live {
 		if r.Meta.Start != api.ZeroKey {
 			keyMap[r.Meta.Start] = struct{}{}
 		}
@@ -0,0 +1,297 @@
+package keyspace
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// Retention controls when obsolete ranges are garbage collected, i.e. removed
+// from the keyspace and from the persister. When that's safe is up to the
+// service: for some it's as soon as the range is obsolete, others need the
+// start/end keys of old ranges indefinitely to recover state from cold storage.
+//
+// Each non-zero field is a condition which must be met before an obsolete range
+// can be collected. If none are set (the default), obsolete ranges are kept
+// forever.
+type Retention struct {
+
+	// Generations is the number of generations of obsolete ranges to keep
+	// behind each non-obsolete range. For example, if this is one, the parents
+	// of active ranges are kept, but their grandparents are collected.
+	Generations int
+
+	// Age is the minimum amount of time to keep a range after it becomes
+	// obsolete. That time is persisted with the range's history, but ranges
+	// which predate that have their clock started when the controller starts.
+	Age time.Duration
+
+	// Ack, if true, keeps each obsolete range until the service acknowledges
+	// that it no longer needs it, via AckObsolete. Acks are persisted with the
+	// range.
+	Ack bool
+}
+
+// gcMaxOps is the most ranges which a single call to GC will write or delete in
+// one transaction. Consul rejects transactions with more than 64 ops, one of
+// which is the fence. Any more obsolete ranges are left for the next call.
+const gcMaxOps = 63
+
+// RetainForever is the default retention policy, which never collects any
+// obsolete ranges.
+var RetainForever = Retention{}
+
+// SetRetention replaces the retention policy, which is used by GC to decide
+// which obsolete ranges to collect.
+func (ks *Keyspace) SetRetention(ret Retention) {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+	ks.retention = ret
+}
+
+// AckObsolete records that the service no longer needs the given obsolete (or
+// aborted) ranges. This only matters if the retention policy has Ack set. If
+// any of the ranges can't be acked, none are.
+func (ks *Keyspace) AckObsolete(rIDs ...api.RangeID) error {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	rs := make([]*ranje.Range, len(rIDs))
+	for i, rID := range rIDs {
+		r, err := ks.GetRange(rID)
+		if err != nil {
+			return err
+		}
+
+		if r.State != api.RsObsolete && r.State != api.RsAborted {
+			return fmt.Errorf("can't ack non-obsolete range: %s", r)
+		}
+
+		rs[i] = r
+	}
+
+	for _, r := range rs {
+		if !r.Acked {
+			r.Acked = true
+			ks.markDirty(r)
+		}
+	}
+
+	return ks.mustPersistDirtyRanges()
+}
+
+// GC removes obsolete ranges which the retention policy no longer requires from
+// the keyspace and the persister, and returns the IDs of the ranges which were
+// removed. Links to them from the remaining ranges are removed, so the history
+// still makes sense, it's just shorter.
+//
+// Ranges are only ever removed along with all of their parents, i.e. from the
+// top of the history down. Otherwise the parents of a removed range would have
+// no children, and look like leaf ranges.
+//
+// Ranges are removed oldest first, and only as many per call as can be deleted,
+// and have their children written, within gcMaxOps. A long history may take
+// several calls to collect.
+//
+// Aborted ranges are collected too, once their placements have been dropped.
+// They aren't part of the history (their parents went back to being active), so
+// they don't have to wait for their parents, and they have no generation.
+func (ks *Keyspace) GC() ([]api.RangeID, error) {
+	ks.rangesMu.Lock()
+	defer ks.rangesMu.Unlock()
+
+	if ks.retention == RetainForever {
+		return nil, nil
+	}
+
+	now := time.Now()
+	gens := map[api.RangeID]int{}
+
+	// Ordered by ident, so parents are always considered before children.
+	obsolete := ks.idx.inState(api.RsObsolete, api.RsAborted)
+	doomed := map[api.RangeID]*ranje.Range{}
+
+	// The ranges which will be written before the doomed ones are deleted,
+	// i.e. everything which is already dirty, plus the surviving children of
+	// the doomed ranges.
+	writes := make(map[api.RangeID]struct{}, len(ks.dirty))
+	for rID := range ks.dirty {
+		writes[rID] = struct{}{}
+	}
+
+	for _, r := range obsolete {
+		if !ks.mayCollect(r, gens, now) {
+			continue
+		}
+
+		ok := true
+		if r.State == api.RsAborted {
+			ok = len(r.Placements) == 0
+		} else {
+			for _, pID := range r.Parents {
+				if _, d := doomed[pID]; !d {
+					ok = false
+					break
+				}
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		n := 0
+		for _, cID := range r.Children {
+			if _, w := writes[cID]; !w {
+				n++
+			}
+		}
+
+		// Stopping early is safe, because parents always have lower idents
+		// than their children, so no range is doomed without its parents.
+		if len(doomed)+1 > gcMaxOps || len(writes)+n > gcMaxOps {
+			break
+		}
+
+		doomed[r.Meta.Ident] = r
+		for _, cID := range r.Children {
+			writes[cID] = struct{}{}
+		}
+
+		// No longer a surviving child, so isn't written unless it's dirty.
+		if _, d := ks.dirty[r.Meta.Ident]; !d {
+			delete(writes, r.Meta.Ident)
+		}
+	}
+
+	if len(doomed) == 0 {
+		return nil, nil
+	}
+
+	// Remove the doomed ranges from the parents of their children. This is
+	// persisted before the doomed ranges are deleted, so if we crash in between
+	// they're still around but unreferenced, and will be collected next time.
+	for _, r := range doomed {
+		for _, cID := range r.Children {
+			if _, d := doomed[cID]; d {
+				continue
+			}
+
+			c, err := ks.GetRange(cID)
+			if err != nil {
+				// This should never happen, since children are never collected
+				// before their parents.
+				panic(fmt.Sprintf("GC: %v", err))
+			}
+
+			c.Parents = withoutRangeID(c.Parents, r.Meta.Ident)
+			ks.markDirty(c)
+		}
+	}
+
+	ks.mustPersistDirtyRanges()
+
+	rs := make([]*ranje.Range, 0, len(doomed))
+	for _, r := range doomed {
+		rs = append(rs, r)
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Meta.Ident < rs[j].Meta.Ident
+	})
+
+	err := ks.pers.DeleteRanges(rs)
+	if err != nil {
+		return nil, fmt.Errorf("deleting ranges: %w", err)
+	}
+
+	ranges := make([]*ranje.Range, 0, len(ks.ranges)-len(rs))
+	for _, r := range ks.ranges {
+		if _, d := doomed[r.Meta.Ident]; !d {
+			ranges = append(ranges, r)
+		}
+	}
+	ks.ranges = ranges
+
+	out := make([]api.RangeID, len(rs))
+	for i, r := range rs {
+		rID := r.Meta.Ident
+		ks.idx.remove(r)
+		delete(ks.dirty, rID)
+		delete(ks.obsoleteAt, rID)
+		out[i] = rID
+	}
+
+	log.Printf("collected %d obsolete ranges", len(out))
+
+	return out, nil
+}
+
+// mayCollect returns true if the retention policy allows the given obsolete
+// range to be collected. The gens map is used to memoize generation numbers
+// between calls.
+func (ks *Keyspace) mayCollect(r *ranje.Range, gens map[api.RangeID]int, now time.Time) bool {
+	ret := ks.retention
+
+	if ret.Generations > 0 && r.State == api.RsObsolete && ks.generation(r, gens) <= ret.Generations {
+		return false
+	}
+
+	if ret.Age > 0 && now.Sub(ks.obsoleteAt[r.Meta.Ident]) < ret.Age {
+		return false
+	}
+
+	if ret.Ack && !r.Acked {
+		return false
+	}
+
+	return true
+}
+
+// generation returns the number of generations between the given range and its
+// nearest non-obsolete descendant. Non-obsolete ranges are generation zero, the
+// parents of those are generation one, and so on.
+func (ks *Keyspace) generation(r *ranje.Range, gens map[api.RangeID]int) int {
+	if r.State != api.RsObsolete {
+		return 0
+	}
+
+	if g, ok := gens[r.Meta.Ident]; ok {
+		return g
+	}
+
+	g := -1
+	for _, cID := range r.Children {
+		c, err := ks.GetRange(cID)
+		if err != nil {
+			panic(fmt.Sprintf("generation: %v", err))
+		}
+
+		cg := ks.generation(c, gens)
+		if g == -1 || cg < g {
+			g = cg
+		}
+	}
+
+	// Obsolete ranges always have children, but just in case.
+	if g == -1 {
+		g = 0
+	}
+
+	gens[r.Meta.Ident] = g + 1
+	return g + 1
+}
+
+func withoutRangeID(rIDs []api.RangeID, rID api.RangeID) []api.RangeID {
+	out := make([]api.RangeID, 0, len(rIDs))
+	for _, id := range rIDs {
+		if id != rID {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,271 @@
+package keyspace
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/stretchr/testify/require"
+)
+
+// retentionFixture returns a keyspace with a short history:
+//
+//	      ┌─────┐
+//	   ┌──│ 1 o │──┐
+//	   │  └─────┘  │
+//	   ▼           ▼
+//	┌─────┐     ┌─────┐
+//	│ 2 o │     │ 3 o │
+//	└─────┘     └─────┘
+//	   │           │
+//	   │  ┌─────┐  │
+//	   └─▶│ 4 o │◀─┘
+//	      └─────┘
+//	         │
+//	   ┌─────┴─────┐
+//	   ▼           ▼
+//	┌─────┐     ┌─────┐
+//	│ 5 a │     │ 6 a │
+//	└─────┘     └─────┘
+func retentionFixture(t *testing.T) (*Keyspace, *FakePersister) {
+	pers := &FakePersister{}
+	ks, err := New(pers, ranje.R1)
+	require.NoError(t, err)
+
+	r1, err := ks.GetRange(1)
+	require.NoError(t, err)
+
+	r2, r3, err := ks.Split(r1, api.Key("ccc"))
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	r4, err := ks.JoinTwo(r2, r3)
+	require.NoError(t, err)
+	completeOp(t, ks, r2, r3)
+
+	_, _, err = ks.Split(r4, api.Key("ccc"))
+	require.NoError(t, err)
+	completeOp(t, ks, r4)
+
+	return ks, pers
+}
+
+func TestGC_RetainForever(t *testing.T) {
+	ks, pers := retentionFixture(t)
+
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Empty(t, rIDs)
+	require.Empty(t, pers.deleted)
+	require.Len(t, ks.ranges, 6)
+}
+
+func TestGC_Generations(t *testing.T) {
+	ks, pers := retentionFixture(t)
+	r := rangeGetter(t, ks)
+
+	// Ranges 1-3 are more than one generation behind the active ranges, so
+	// they're collected. Range 4 is kept, but no longer has any parents.
+	ks.SetRetention(Retention{Generations: 1})
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{1, 2, 3}, rIDs)
+	require.Equal(t, []api.RangeID{1, 2, 3}, pers.deleted)
+	require.Empty(t, r(4).Parents)
+	require.Len(t, ks.ranges, 3)
+	requireInState(t, ks, api.RsObsolete, r(4))
+
+	_, err = ks.GetRange(1)
+	require.Error(t, err)
+
+	// There are no ops in progress, and the history still looks sane.
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Empty(t, ops)
+	require.NoError(t, ks.sanityCheck())
+
+	// Nothing else to collect.
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Empty(t, rIDs)
+}
+
+func TestGC_Batches(t *testing.T) {
+	pers := &FakePersister{}
+	ks, err := New(pers, ranje.R1)
+	require.NoError(t, err)
+
+	// Split and rejoin the only range over and over, leaving 90 obsolete
+	// ranges behind the active one.
+	r, err := ks.GetRange(1)
+	require.NoError(t, err)
+	for i := 0; i < 30; i++ {
+		a, b, err := ks.Split(r, api.Key("ccc"))
+		require.NoError(t, err)
+		completeOp(t, ks, r)
+
+		r, err = ks.JoinTwo(a, b)
+		require.NoError(t, err)
+		completeOp(t, ks, a, b)
+	}
+
+	ks.SetRetention(Retention{Generations: 1})
+
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Len(t, rIDs, gcMaxOps)
+	require.NoError(t, ks.sanityCheck())
+
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Len(t, rIDs, 88-gcMaxOps)
+	require.NoError(t, ks.sanityCheck())
+
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Empty(t, rIDs)
+
+	// Every obsolete range but the active range's parents was deleted, and
+	// never too many at once.
+	require.Len(t, pers.deleted, 88)
+	require.Len(t, ks.ranges, 3)
+	for _, d := range pers.deletes {
+		require.LessOrEqual(t, len(d), gcMaxOps)
+	}
+}
+
+func TestGC_WideSplit(t *testing.T) {
+	pers := &FakePersister{}
+	ks, err := New(pers, ranje.R1)
+	require.NoError(t, err)
+
+	// Split range 1 in two, then split each of those 40 ways, and join the
+	// children back together, leaving two active ranges and 83 obsolete.
+	r1, err := ks.GetRange(1)
+	require.NoError(t, err)
+	r2, r3, err := ks.Split(r1, api.Key("m"))
+	require.NoError(t, err)
+	completeOp(t, ks, r1)
+
+	for i, r := range []*ranje.Range{r2, r3} {
+		prefix := []string{"a", "n"}[i]
+		keys := make([]api.Key, 39)
+		for ii := range keys {
+			keys[ii] = api.Key(fmt.Sprintf("%s%02d", prefix, ii))
+		}
+
+		children, err := ks.SplitN(r, keys)
+		require.NoError(t, err)
+		completeOp(t, ks, r)
+
+		_, err = ks.JoinN(children)
+		require.NoError(t, err)
+		completeOp(t, ks, children...)
+	}
+
+	// Ranges 1-3 may be collected, but their 80 grandchildren are kept, and
+	// must be written when their parents are removed. That's too many for one
+	// transaction, so range 3 is left for the next call.
+	ks.SetRetention(Retention{Generations: 1})
+	pers.puts = nil
+
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{1, 2}, rIDs)
+	require.NoError(t, ks.sanityCheck())
+
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{3}, rIDs)
+	require.NoError(t, ks.sanityCheck())
+
+	require.Len(t, pers.puts, 2)
+	for _, p := range pers.puts {
+		require.LessOrEqual(t, len(p), gcMaxOps)
+	}
+}
+
+func TestGC_Age(t *testing.T) {
+	ks, _ := retentionFixture(t)
+
+	ks.SetRetention(Retention{Age: time.Hour})
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Empty(t, rIDs)
+
+	// Pretend that the ranges became obsolete a long time ago.
+	for rID := range ks.obsoleteAt {
+		ks.obsoleteAt[rID] = time.Now().Add(-2 * time.Hour)
+	}
+
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{1, 2, 3, 4}, rIDs)
+}
+
+func TestGC_Ack(t *testing.T) {
+	ks, pers := retentionFixture(t)
+	r := rangeGetter(t, ks)
+
+	ks.SetRetention(Retention{Ack: true})
+
+	// Can't ack ranges which aren't obsolete.
+	require.Error(t, ks.AckObsolete(5))
+
+	// Nothing is acked if any range can't be.
+	require.Error(t, ks.AckObsolete(2, 6))
+	require.False(t, r(2).Acked)
+
+	// Acks are persisted with the range.
+	require.NoError(t, ks.AckObsolete(2))
+	require.True(t, r(2).Acked)
+	require.Contains(t, pers.puts[len(pers.puts)-1], api.RangeID(2))
+
+	// Range 2 can't be collected before its parent.
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Empty(t, rIDs)
+
+	require.NoError(t, ks.AckObsolete(1))
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{1, 2}, rIDs)
+	require.Empty(t, r(3).Parents)
+	require.Equal(t, []api.RangeID{3}, r(4).Parents)
+}
+
+func TestGC_Aborted(t *testing.T) {
+	ks, pers := retentionFixture(t)
+	r := rangeGetter(t, ks)
+
+	// Split range 5 again, and then give up. The parent needs a placement to
+	// go back to, or the split can't be aborted.
+	r(5).NewPlacement("aaa").StateCurrent = api.PsActive
+	r7, _, err := ks.Split(r(5), api.Key("bbb"))
+	require.NoError(t, err)
+	r7.NewPlacement("bbb")
+
+	ops, err := ks.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	require.NoError(t, ops[0].Abort(ks))
+
+	// Aborted ranges aren't generations behind anything, but they can be
+	// collected once they have no placements.
+	ks.SetRetention(Retention{Generations: 10})
+	rIDs, err := ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{8}, rIDs)
+
+	r7.Placements = nil
+	rIDs, err = ks.GC()
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{7}, rIDs)
+	require.Equal(t, []api.RangeID{8, 7}, pers.deleted)
+
+	requireInState(t, ks, api.RsActive, r(5), r(6))
+	require.Empty(t, r(5).Children)
+	require.NoError(t, ks.sanityCheck())
+}
@@ -0,0 +1,153 @@
+package keyspace
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/adammck/ranger/pkg/util/wake"
+)
+
+// How many of the most recent events are kept, so that watchers can resume
+// after disconnecting. This is also the size of each watcher's buffer.
+const watchHistory = 1024
+
+// ErrCompacted is returned by Watch when the requested revision is too old to
+// resume from, either because too many events have happened since then, or
+// because it's from before the controller restarted. The caller should fetch
+// the current state of the ranges, and then watch from Revision.
+var ErrCompacted = errors.New("revision has been compacted")
+
+// Event is a change to the keyspace, as seen by Watch.
+type Event struct {
+	ranje.Event
+
+	// Increases by one with every event. Revisions start at the wall clock
+	// time (in nanoseconds) when the keyspace was loaded, so they never repeat
+	// after the controller restarts.
+	Rev uint64
+}
+
+// watchers fans out the events from every range in the keyspace to the
+// channels returned by Watch, and the signals given to NotifyOnChange. It has
+// its own lock, since events are emitted by ranges which may or may not be
+// holding the keyspace lock.
+type watchers struct {
+	sync.Mutex
+	rev     uint64
+	recent  []Event
+	chans   map[chan Event]struct{}
+	signals []*wake.Signal
+}
+
+func newWatchers() *watchers {
+	return &watchers{
+		rev:   uint64(time.Now().UnixNano()),
+		chans: map[chan Event]struct{}{},
+	}
+}
+
+// publish assigns the next revision to the given event, and sends it to every
+// watcher. Watchers which have fallen so far behind that their buffer is full
+// are closed rather than blocking, and can resume from their last revision.
+func (w *watchers) publish(re ranje.Event) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.rev += 1
+	e := Event{Event: re, Rev: w.rev}
+
+	if len(w.recent) == watchHistory {
+		w.recent = append(w.recent[:0], w.recent[1:]...)
+	}
+	w.recent = append(w.recent, e)
+
+	for ch := range w.chans {
+		select {
+		case ch <- e:
+		default:
+			delete(w.chans, ch)
+			close(ch)
+		}
+	}
+
+	for _, s := range w.signals {
+		s.Notify()
+	}
+}
+
+// Revision returns the revision of the most recent event. Watching from this
+// revision returns only events which happen after this call.
+func (ks *Keyspace) Revision() uint64 {
+	ks.watchers.Lock()
+	defer ks.watchers.Unlock()
+	return ks.watchers.rev
+}
+
+// Watch returns a channel which receives every event after the given revision,
+// i.e. first any recent events which the caller missed, and then every new
+// event as it happens. The channel is closed if the caller falls too far
+// behind, in which case it should call Watch again with the last revision it
+// saw. Call the returned func to stop watching.
+func (ks *Keyspace) Watch(rev uint64) (<-chan Event, func(), error) {
+	w := ks.watchers
+	w.Lock()
+	defer w.Unlock()
+
+	if rev > w.rev {
+		return nil, nil, fmt.Errorf("revision is in the future (rev=%d, current=%d)", rev, w.rev)
+	}
+
+	// The earliest revision we can resume from is the one just before the
+	// oldest event we still have, or the current one if there are none.
+	oldest := w.rev
+	if len(w.recent) > 0 {
+		oldest = w.recent[0].Rev - 1
+	}
+	if rev < oldest {
+		return nil, nil, fmt.Errorf("%w (rev=%d, oldest=%d)", ErrCompacted, rev, oldest)
+	}
+
+	ch := make(chan Event, watchHistory)
+	for _, e := range w.recent {
+		if e.Rev > rev {
+			ch <- e
+		}
+	}
+
+	w.chans[ch] = struct{}{}
+
+	cancel := func() {
+		w.Lock()
+		defer w.Unlock()
+
+		if _, ok := w.chans[ch]; ok {
+			delete(w.chans, ch)
+			close(ch)
+		}
+	}
+
+	return ch, cancel, nil
+}
+
+// NotifyOnChange notifies the given signal whenever the keyspace changes. That
+// is, whenever there's an event (see Watch), and also whenever changes which
+// aren't events are persisted, like an operator clearing the failures of a
+// placement. Unlike Watch, it doesn't say what changed, so is only useful to
+// wake a loop which will look for itself.
+func (ks *Keyspace) NotifyOnChange(s *wake.Signal) {
+	ks.watchers.Lock()
+	defer ks.watchers.Unlock()
+	ks.watchers.signals = append(ks.watchers.signals, s)
+}
+
+func (ks *Keyspace) notifyChanged() {
+	ks.watchers.Lock()
+	defer ks.watchers.Unlock()
+
+	for _, s := range ks.watchers.signals {
+		s.Notify()
+	}
+}
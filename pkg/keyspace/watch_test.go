@@ -0,0 +1,148 @@
+package keyspace
+
+import (
+	"testing"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/adammck/ranger/pkg/util/wake"
+	"github.com/stretchr/testify/require"
+)
+
+// drain returns the events which are waiting in the given channel, without
+// blocking.
+func drain(ch <-chan Event) []string {
+	out := []string{}
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return out
+			}
+			out = append(out, e.String())
+		default:
+			return out
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+	r := rangeGetter(t, ks)
+
+	rev := ks.Revision()
+	ch, cancel, err := ks.Watch(rev)
+	require.NoError(t, err)
+
+	p := r(1).NewPlacement("aaa")
+	require.NoError(t, p.ToState(api.PsInactive))
+	_, _, err = ks.Split(r(1), api.Key("ccc"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"EvPlacementCreated(R1, aaa, PsPending)",
+		"EvPlacementState(R1, aaa, PsInactive)",
+		"EvRangeState(R1, RsSubsuming)",
+		"EvRangeState(R2, RsNew)",
+		"EvRangeState(R3, RsNew)",
+	}, drain(ch))
+
+	// Resume from part way through.
+	ch2, cancel2, err := ks.Watch(rev + 3)
+	require.NoError(t, err)
+	defer cancel2()
+
+	r(1).DestroyPlacement(p)
+	require.Equal(t, []string{
+		"EvRangeState(R2, RsNew)",
+		"EvRangeState(R3, RsNew)",
+		"EvPlacementDestroyed(R1, aaa, PsInactive)",
+	}, drain(ch2))
+
+	// Stopping closes the channel, and nothing more is sent.
+	cancel()
+	r(2).NewPlacement("bbb")
+	require.Equal(t, []string{
+		"EvPlacementDestroyed(R1, aaa, PsInactive)",
+	}, drain(ch))
+	_, ok := <-ch
+	require.False(t, ok)
+	require.Equal(t, []string{
+		"EvPlacementCreated(R2, bbb, PsPending)",
+	}, drain(ch2))
+}
+
+func TestWatch_Revisions(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+	r := rangeGetter(t, ks)
+	rev := ks.Revision()
+
+	_, _, err = ks.Watch(rev + 1)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCompacted)
+
+	// Can't resume from before the keyspace was loaded. (The first event, at
+	// rev, is the creation of range 1.)
+	_, _, err = ks.Watch(rev - 2)
+	require.ErrorIs(t, err, ErrCompacted)
+
+	// Fill up the history, so the creation of range 1 is forgotten.
+	for i := 0; i < watchHistory/2; i++ {
+		r(1).DestroyPlacement(r(1).NewPlacement("aaa"))
+	}
+
+	_, _, err = ks.Watch(rev - 1)
+	require.ErrorIs(t, err, ErrCompacted)
+
+	ch, cancel, err := ks.Watch(rev)
+	require.NoError(t, err)
+	defer cancel()
+	require.Len(t, drain(ch), watchHistory)
+}
+
+func TestWatch_SlowWatcher(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+	r := rangeGetter(t, ks)
+
+	ch, cancel, err := ks.Watch(ks.Revision())
+	require.NoError(t, err)
+	defer cancel()
+
+	// The watcher isn't reading, so is closed once its buffer is full, rather
+	// than blocking the keyspace.
+	for i := 0; i <= watchHistory/2; i++ {
+		r(1).DestroyPlacement(r(1).NewPlacement("aaa"))
+	}
+
+	events := drain(ch)
+	require.Len(t, events, watchHistory)
+	_, ok := <-ch
+	require.False(t, ok)
+}
+
+func TestNotifyOnChange(t *testing.T) {
+	ks, err := New(&FakePersister{}, ranje.R1)
+	require.NoError(t, err)
+	r := rangeGetter(t, ks)
+
+	s := wake.New()
+	ks.NotifyOnChange(s)
+	require.Len(t, s.C(), 0)
+
+	p := r(1).NewPlacement("aaa")
+	require.Len(t, s.C(), 1)
+	<-s.C()
+
+	require.NoError(t, ks.PlacementToState(p, api.PsInactive))
+	require.Len(t, s.C(), 1)
+	<-s.C()
+
+	// Not an event.
+	p.SetFailed(api.Activate, true)
+	_, err = ks.ClearFailures(1, "aaa", nil)
+	require.NoError(t, err)
+	require.Len(t, s.C(), 1)
+}
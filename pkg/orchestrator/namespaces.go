@@ -0,0 +1,354 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultNamespace is the namespace which requests with no namespace are sent
+// to. A controller with a single keyspace serves only this one.
+const DefaultNamespace = ""
+
+// Namespaces serves the Orchestrator and Debug RPCs for several independent
+// keyspaces, each with its own Orchestrator, from a single gRPC server. Each
+// request is routed to an orchestrator by its namespace field.
+type Namespaces struct {
+	orchs   map[string]*Orchestrator
+	orchsMu sync.RWMutex
+
+	// Whether this controller is a standby, i.e. not the leader, in which case
+	// only the read-only Debug RPCs are served. And the address of the leader
+	// (if known), to tell callers where to go instead.
+	standby  bool
+	leader   string
+	leaderMu sync.RWMutex
+}
+
+// NewNamespaces returns an empty set of namespaces, and registers the RPC
+// servers on the given gRPC server. Orchestrators passed to Add should have
+// been created with a nil server, so they don't register their own.
+func NewNamespaces(srv *grpc.Server) *Namespaces {
+	ns := &Namespaces{
+		orchs: map[string]*Orchestrator{},
+	}
+
+	pb.RegisterOrchestratorServer(srv, &orchestratorRouter{ns: ns})
+	pb.RegisterDebugServer(srv, &debugRouter{ns: ns})
+
+	return ns
+}
+
+// Add routes requests for the given namespace to the given orchestrator.
+func (ns *Namespaces) Add(name string, orch *Orchestrator) error {
+	ns.orchsMu.Lock()
+	defer ns.orchsMu.Unlock()
+
+	if _, ok := ns.orchs[name]; ok {
+		return fmt.Errorf("duplicate namespace: %q", name)
+	}
+
+	ns.orchs[name] = orch
+	return nil
+}
+
+// Replace routes requests for the given namespace to the given orchestrator,
+// instead of whichever they were routed to before. This is used when a standby
+// reloads the keyspace.
+func (ns *Namespaces) Replace(name string, orch *Orchestrator) {
+	ns.orchsMu.Lock()
+	defer ns.orchsMu.Unlock()
+	ns.orchs[name] = orch
+}
+
+// SetStandby marks the controller as a standby, so the Orchestrator RPCs (and
+// other RPCs which need the current state) return Unavailable. The given leader
+// address, which can be empty if there's no leader, is included in the error.
+func (ns *Namespaces) SetStandby(leader string) {
+	ns.leaderMu.Lock()
+	defer ns.leaderMu.Unlock()
+	ns.standby = true
+	ns.leader = leader
+}
+
+// SetLeader marks the controller as the leader, so all RPCs are served.
+func (ns *Namespaces) SetLeader() {
+	ns.leaderMu.Lock()
+	defer ns.leaderMu.Unlock()
+	ns.standby = false
+	ns.leader = ""
+}
+
+// getLeader is like get, but returns Unavailable if this controller is a
+// standby.
+func (ns *Namespaces) getLeader(name string) (*Orchestrator, error) {
+	ns.leaderMu.RLock()
+	standby, leader := ns.standby, ns.leader
+	ns.leaderMu.RUnlock()
+
+	if standby {
+		if leader == "" {
+			return nil, status.Error(codes.Unavailable, "standby; no leader is elected")
+		}
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("standby; leader is at: %s", leader))
+	}
+
+	return ns.get(name)
+}
+
+func (ns *Namespaces) get(name string) (*Orchestrator, error) {
+	ns.orchsMu.RLock()
+	defer ns.orchsMu.RUnlock()
+
+	orch, ok := ns.orchs[name]
+	if !ok {
+		if name == DefaultNamespace {
+			return nil, status.Error(codes.InvalidArgument, "missing: namespace")
+		}
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("no such namespace: %q", name))
+	}
+
+	return orch, nil
+}
+
+type orchestratorRouter struct {
+	pb.UnsafeOrchestratorServer
+	ns *Namespaces
+}
+
+func (r *orchestratorRouter) Move(ctx context.Context, req *pb.MoveRequest) (*pb.MoveResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Move(ctx, req)
+}
+
+func (r *orchestratorRouter) Split(ctx context.Context, req *pb.SplitRequest) (*pb.SplitResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Split(ctx, req)
+}
+
+func (r *orchestratorRouter) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Join(ctx, req)
+}
+
+func (r *orchestratorRouter) Abort(ctx context.Context, req *pb.AbortRequest) (*pb.AbortResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Abort(ctx, req)
+}
+
+func (r *orchestratorRouter) SetReplication(ctx context.Context, req *pb.SetReplicationRequest) (*pb.SetReplicationResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.SetReplication(ctx, req)
+}
+
+func (r *orchestratorRouter) SetPlacementPolicy(ctx context.Context, req *pb.SetPlacementPolicyRequest) (*pb.SetPlacementPolicyResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.SetPlacementPolicy(ctx, req)
+}
+
+func (r *orchestratorRouter) AckObsolete(ctx context.Context, req *pb.AckObsoleteRequest) (*pb.AckObsoleteResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.AckObsolete(ctx, req)
+}
+
+func (r *orchestratorRouter) GetOperation(ctx context.Context, req *pb.GetOperationRequest) (*pb.GetOperationResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.GetOperation(ctx, req)
+}
+
+func (r *orchestratorRouter) ListOperations(ctx context.Context, req *pb.ListOperationsRequest) (*pb.ListOperationsResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.ListOperations(ctx, req)
+}
+
+func (r *orchestratorRouter) WaitOperation(ctx context.Context, req *pb.WaitOperationRequest) (*pb.WaitOperationResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.WaitOperation(ctx, req)
+}
+
+func (r *orchestratorRouter) CancelOperation(ctx context.Context, req *pb.CancelOperationRequest) (*pb.CancelOperationResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.CancelOperation(ctx, req)
+}
+
+func (r *orchestratorRouter) Untaint(ctx context.Context, req *pb.UntaintRequest) (*pb.UntaintResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Untaint(ctx, req)
+}
+
+func (r *orchestratorRouter) ClearFailures(ctx context.Context, req *pb.ClearFailuresRequest) (*pb.ClearFailuresResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.ClearFailures(ctx, req)
+}
+
+func (r *orchestratorRouter) DropPlacement(ctx context.Context, req *pb.DropPlacementRequest) (*pb.DropPlacementResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.DropPlacement(ctx, req)
+}
+
+func (r *orchestratorRouter) Cordon(ctx context.Context, req *pb.CordonRequest) (*pb.CordonResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Cordon(ctx, req)
+}
+
+func (r *orchestratorRouter) Drain(ctx context.Context, req *pb.DrainRequest) (*pb.DrainResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Drain(ctx, req)
+}
+
+func (r *orchestratorRouter) Uncordon(ctx context.Context, req *pb.UncordonRequest) (*pb.UncordonResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.Uncordon(ctx, req)
+}
+
+func (r *orchestratorRouter) DrainStatus(ctx context.Context, req *pb.DrainStatusRequest) (*pb.DrainStatusResponse, error) {
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.bs.DrainStatus(ctx, req)
+}
+
+type debugRouter struct {
+	pb.UnsafeDebugServer
+	ns *Namespaces
+}
+
+func (r *debugRouter) RangesList(ctx context.Context, req *pb.RangesListRequest) (*pb.RangesListResponse, error) {
+	orch, err := r.ns.get(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.dbg.RangesList(ctx, req)
+}
+
+func (r *debugRouter) Range(ctx context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
+	orch, err := r.ns.get(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.dbg.Range(ctx, req)
+}
+
+func (r *debugRouter) NodesList(ctx context.Context, req *pb.NodesListRequest) (*pb.NodesListResponse, error) {
+	orch, err := r.ns.get(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.dbg.NodesList(ctx, req)
+}
+
+func (r *debugRouter) Node(ctx context.Context, req *pb.NodeRequest) (*pb.NodeResponse, error) {
+	orch, err := r.ns.get(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.dbg.Node(ctx, req)
+}
+
+func (r *debugRouter) Lineage(ctx context.Context, req *pb.LineageRequest) (*pb.LineageResponse, error) {
+	orch, err := r.ns.get(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.dbg.Lineage(ctx, req)
+}
+
+func (r *debugRouter) RangeAt(ctx context.Context, req *pb.RangeAtRequest) (*pb.RangeAtResponse, error) {
+	orch, err := r.ns.get(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return orch.dbg.RangeAt(ctx, req)
+}
+
+func (r *debugRouter) Watch(req *pb.WatchRequest, stream pb.Debug_WatchServer) error {
+	// The keyspace of a standby is replaced whenever it's reloaded, so streams
+	// from it would go quiet. Watch the leader instead.
+	orch, err := r.ns.getLeader(req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return orch.dbg.Watch(req, stream)
+}
@@ -0,0 +1,570 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/persister"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// How many finished ops are remembered (and persisted), so that callers can
+// find out what happened to them. Older ones are forgotten.
+const opHistory = 100
+
+var ErrNoSuchOp = errors.New("no such op")
+
+// ErrRangeBusy is returned by Submit when asked to split a range which already
+// has an op queued or running.
+var ErrRangeBusy = errors.New("range already has an op queued or running")
+
+// ErrCantCancel is returned by CancelOp when the op has already finished, or is
+// a move which has already started.
+var ErrCantCancel = errors.New("op can't be cancelled")
+
+// opRecords tracks the state of every op which was submitted via Submit, from
+// being queued until it finishes, and writes each change to the persister (if
+// there is one). It has its own lock, since ops start during orchestrator ticks
+// but finish via callbacks and in background routines.
+type opRecords struct {
+	sync.Mutex
+	pers   persister.OpPersister
+	nextID ranje.OpID
+	ops    map[ranje.OpID]*ranje.Op
+
+	// Closed when the op with the same ID finishes.
+	done map[ranje.OpID]chan struct{}
+}
+
+func newOpRecords() *opRecords {
+	return &opRecords{
+		nextID: 1,
+		ops:    map[ranje.OpID]*ranje.Op{},
+		done:   map[ranje.OpID]chan struct{}{},
+	}
+}
+
+// put writes the given op to the persister, if there is one. Caller must hold
+// the lock.
+func (rs *opRecords) put(op *ranje.Op) error {
+	if rs.pers == nil {
+		return nil
+	}
+
+	return rs.pers.PutOps([]*ranje.Op{op})
+}
+
+// add assigns the next ID to the given op, and starts tracking it as queued.
+// Returns a copy of it.
+func (rs *opRecords) add(op *ranje.Op) (ranje.Op, error) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	now := time.Now()
+	op.ID = rs.nextID
+	op.State = ranje.OsQueued
+	op.Created = now
+	op.Updated = now
+
+	// Don't accept the op unless it can be persisted. The caller would have no
+	// way to find out what happened to it after the controller restarts.
+	err := rs.put(op)
+	if err != nil {
+		return ranje.Op{}, err
+	}
+
+	rs.nextID += 1
+	rs.ops[op.ID] = op
+	rs.done[op.ID] = make(chan struct{})
+
+	return *op, nil
+}
+
+// busy returns whether any op which is queued or running involves the given
+// range.
+func (rs *opRecords) busy(rID api.RangeID) bool {
+	rs.Lock()
+	defer rs.Unlock()
+
+	for _, op := range rs.ops {
+		if op.State.Done() {
+			continue
+		}
+
+		for _, r := range op.Ranges {
+			if r == rID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// update calls the given func with the op (if it's being tracked) to change
+// it, and then persists it. Errors from the persister are only logged, since
+// it's too late to reject the op, and it'll be retried at the next change.
+// Caller must hold the lock.
+func (rs *opRecords) update(id ranje.OpID, f func(*ranje.Op)) {
+	op, ok := rs.ops[id]
+	if !ok {
+		return
+	}
+
+	f(op)
+	op.Updated = time.Now()
+
+	err := rs.put(op)
+	if err != nil {
+		log.Printf("error persisting op %s: %v", op, err)
+	}
+}
+
+// started marks the op with the given ID (which is zero if the op wasn't
+// submitted via Submit, in which case this does nothing) as running. For moves,
+// src is the node that the range is being moved from.
+func (rs *opRecords) started(id ranje.OpID, src api.NodeID) {
+	if id == 0 {
+		return
+	}
+
+	rs.Lock()
+	defer rs.Unlock()
+
+	rs.update(id, func(op *ranje.Op) {
+		op.State = ranje.OsRunning
+		if op.Src == "" {
+			op.Src = src
+		}
+	})
+}
+
+// await blocks until the given error channel (which was attached to the op
+// with the given ID when it was queued) is closed, and then marks the op as
+// finished.
+func (rs *opRecords) await(id ranje.OpID, ch chan error) {
+	errs := []string{}
+	for err := range ch {
+		errs = append(errs, err.Error())
+	}
+
+	rs.Lock()
+	defer rs.Unlock()
+
+	rs.update(id, func(op *ranje.Op) {
+		switch {
+		case op.Cancel:
+			op.State = ranje.OsCancelled
+		case len(errs) > 0:
+			op.State = ranje.OsFailed
+			op.Error = strings.Join(errs, "; ")
+		default:
+			op.State = ranje.OsSucceeded
+		}
+	})
+
+	if done, ok := rs.done[id]; ok {
+		close(done)
+	}
+
+	rs.prune()
+}
+
+// prune forgets the oldest finished ops, so that at most opHistory of them are
+// kept. Caller must hold the lock.
+func (rs *opRecords) prune() {
+	finished := []*ranje.Op{}
+	for _, op := range rs.ops {
+		if op.State.Done() {
+			finished = append(finished, op)
+		}
+	}
+
+	if len(finished) <= opHistory {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].ID < finished[j].ID
+	})
+
+	old := finished[:len(finished)-opHistory]
+
+	if rs.pers != nil {
+		err := rs.pers.DeleteOps(old)
+		if err != nil {
+			log.Printf("error deleting old ops: %v", err)
+			return
+		}
+	}
+
+	for _, op := range old {
+		delete(rs.ops, op.ID)
+		delete(rs.done, op.ID)
+	}
+}
+
+// get returns a copy of the op with the given ID, and a channel which is closed
+// when it finishes.
+func (rs *opRecords) get(id ranje.OpID) (ranje.Op, <-chan struct{}, error) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	op, ok := rs.ops[id]
+	if !ok {
+		return ranje.Op{}, nil, fmt.Errorf("%w: %d", ErrNoSuchOp, id)
+	}
+
+	return *op, rs.done[id], nil
+}
+
+// Submit records the given move, split, or join, and queues it to be initiated
+// during the next tick. Returns the op with its ID and state filled in. Unlike
+// the Queue methods, the caller doesn't need to wait for the op to finish; use
+// Op or WaitOp to find out how it went.
+func (b *Orchestrator) Submit(op ranje.Op) (ranje.Op, error) {
+	err := validateOp(op)
+	if err != nil {
+		return ranje.Op{}, err
+	}
+
+	// Only one split of a range can be queued at once (see QueueSplit), and
+	// it can't be split while anything else is happening to it anyway.
+	if op.Kind == ranje.OpKindSplit {
+		b.opSplitsMu.RLock()
+		_, queued := b.opSplits[op.Ranges[0]]
+		b.opSplitsMu.RUnlock()
+
+		if queued || b.records.busy(op.Ranges[0]) {
+			return ranje.Op{}, fmt.Errorf("%w: R%s", ErrRangeBusy, op.Ranges[0])
+		}
+	}
+
+	out, err := b.records.add(&op)
+	if err != nil {
+		return ranje.Op{}, err
+	}
+
+	b.queueOp(out)
+
+	return out, nil
+}
+
+func validateOp(op ranje.Op) error {
+	switch op.Kind {
+	case ranje.OpKindMove:
+		if len(op.Ranges) != 1 || len(op.Keys) > 0 || len(op.Nodes) > 1 {
+			return errors.New("move must have one range, no keys, and at most one node")
+		}
+
+	case ranje.OpKindSplit:
+		if len(op.Ranges) != 1 || len(op.Keys) == 0 || len(op.Nodes) > len(op.Keys)+1 {
+			return errors.New("split must have one range, at least one key, and at most one node per child")
+		}
+
+	case ranje.OpKindJoin:
+		if len(op.Ranges) < 2 || len(op.Keys) > 0 || len(op.Nodes) > 1 {
+			return errors.New("join must have at least two ranges, no keys, and at most one node")
+		}
+
+	default:
+		return fmt.Errorf("unknown op kind: %s", op.Kind)
+	}
+
+	return nil
+}
+
+// queueOp queues the move, split, or join described by the given op, which has
+// already been recorded, and tracks it until it finishes.
+func (b *Orchestrator) queueOp(op ranje.Op) {
+	ch := make(chan error, 1)
+
+	var dest api.NodeID
+	if len(op.Nodes) > 0 {
+		dest = op.Nodes[0]
+	}
+
+	switch op.Kind {
+	case ranje.OpKindMove:
+		b.QueueMove(OpMove{
+			ID:    op.ID,
+			Range: op.Ranges[0],
+			Src:   op.Src,
+			Dest:  dest,
+			Err:   ch,
+		})
+
+	case ranje.OpKindSplit:
+		b.QueueSplit(OpSplit{
+			ID:    op.ID,
+			Range: op.Ranges[0],
+			Keys:  op.Keys,
+			Dests: op.Nodes,
+			Err:   ch,
+		})
+
+	case ranje.OpKindJoin:
+		b.QueueJoin(OpJoin{
+			ID:     op.ID,
+			Ranges: op.Ranges,
+			Dest:   dest,
+			Err:    ch,
+		})
+	}
+
+	go b.records.await(op.ID, ch)
+}
+
+// Op returns the op with the given ID.
+func (b *Orchestrator) Op(id ranje.OpID) (ranje.Op, error) {
+	op, _, err := b.records.get(id)
+	return op, err
+}
+
+// Ops returns every op which is queued or running, and the most recent ones
+// which have finished, ordered by ID.
+func (b *Orchestrator) Ops() []ranje.Op {
+	b.records.Lock()
+	defer b.records.Unlock()
+
+	out := make([]ranje.Op, 0, len(b.records.ops))
+	for _, op := range b.records.ops {
+		out = append(out, *op)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+
+	return out
+}
+
+// WaitOp blocks until the op with the given ID finishes, or the context is
+// cancelled, and then returns it.
+func (b *Orchestrator) WaitOp(ctx context.Context, id ranje.OpID) (ranje.Op, error) {
+	_, done, err := b.records.get(id)
+	if err != nil {
+		return ranje.Op{}, err
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ranje.Op{}, ctx.Err()
+	}
+
+	return b.Op(id)
+}
+
+// CancelOp cancels the op with the given ID. Queued ops are removed from the
+// queue. Running splits and joins are aborted, which is only possible until
+// any of the child ranges have been activated. Running moves can't be
+// cancelled. This blocks until the next tick, if the op has to be aborted.
+// Returns the op, which may not be OsCancelled yet, if it was aborted.
+func (b *Orchestrator) CancelOp(id ranje.OpID) (ranje.Op, error) {
+	op, _, err := b.records.get(id)
+	if err != nil {
+		return ranje.Op{}, err
+	}
+
+	if op.State.Done() {
+		return op, fmt.Errorf("%w: already %s", ErrCantCancel, op.State)
+	}
+
+	b.setCancel(id, true)
+
+	// If the op is still queued, just take it out of the queue, and close its
+	// error channel, which marks it as cancelled.
+	if ch := b.unqueue(op); ch != nil {
+		close(ch)
+		_, done, _ := b.records.get(id)
+		<-done
+		return b.Op(id)
+	}
+
+	// Otherwise it was initiated (or failed) since the state was checked above,
+	// so check again.
+	op, _, err = b.records.get(id)
+	if err != nil {
+		return ranje.Op{}, err
+	}
+
+	if op.State.Done() || op.Kind == ranje.OpKindMove {
+		b.setCancel(id, false)
+		if op.State.Done() {
+			return op, fmt.Errorf("%w: already %s", ErrCantCancel, op.State)
+		}
+		return op, fmt.Errorf("%w: moves can't be cancelled once started", ErrCantCancel)
+	}
+
+	ch := make(chan error, 1)
+	b.QueueAbort(OpAbort{
+		Range: op.Ranges[0],
+		Err:   ch,
+	})
+
+	errs := []string{}
+	for err := range ch {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		b.setCancel(id, false)
+		return op, fmt.Errorf("%w: %s", ErrCantCancel, strings.Join(errs, "; "))
+	}
+
+	return b.Op(id)
+}
+
+func (b *Orchestrator) setCancel(id ranje.OpID, cancel bool) {
+	b.records.Lock()
+	defer b.records.Unlock()
+
+	b.records.update(id, func(op *ranje.Op) {
+		op.Cancel = cancel
+	})
+}
+
+// unqueue removes the given op from the queue, if it's still there, and returns
+// its error channel. Otherwise returns nil.
+func (b *Orchestrator) unqueue(op ranje.Op) chan error {
+	switch op.Kind {
+	case ranje.OpKindMove:
+		b.opMovesMu.Lock()
+		defer b.opMovesMu.Unlock()
+
+		for i, m := range b.opMoves {
+			if m.ID == op.ID {
+				b.opMoves = append(b.opMoves[:i], b.opMoves[i+1:]...)
+				return m.Err
+			}
+		}
+
+	case ranje.OpKindSplit:
+		b.opSplitsMu.Lock()
+		defer b.opSplitsMu.Unlock()
+
+		if s, ok := b.opSplits[op.Ranges[0]]; ok && s.ID == op.ID {
+			delete(b.opSplits, op.Ranges[0])
+			return s.Err
+		}
+
+	case ranje.OpKindJoin:
+		b.opJoinsMu.Lock()
+		defer b.opJoinsMu.Unlock()
+
+		for i, j := range b.opJoins {
+			if j.ID == op.ID {
+				b.opJoins = append(b.opJoins[:i], b.opJoins[i+1:]...)
+				return j.Err
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOps loads the ops from the given persister, and persists every change to
+// them from now on. Ops which were queued when the controller stopped are
+// queued again, and ones which were running are tracked until they finish.
+// This must be called (if at all) after the keyspace is loaded, but before Run.
+func (b *Orchestrator) LoadOps(pers persister.OpPersister) error {
+	ops, err := pers.GetOps()
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].ID < ops[j].ID
+	})
+
+	rs := b.records
+	rs.Lock()
+	rs.pers = pers
+	for _, op := range ops {
+		rs.ops[op.ID] = op
+		rs.done[op.ID] = make(chan struct{})
+		if op.State.Done() {
+			close(rs.done[op.ID])
+		}
+		if op.ID >= rs.nextID {
+			rs.nextID = op.ID + 1
+		}
+	}
+	rs.Unlock()
+
+	for _, op := range ops {
+		switch op.State {
+		case ranje.OsQueued:
+			b.queueOp(*op)
+
+		case ranje.OsRunning:
+			b.resumeOp(*op)
+		}
+	}
+
+	return nil
+}
+
+// resumeOp tracks the given op, which was running when the controller stopped,
+// until it finishes. This is like queueOp, but attaches the callbacks that the
+// orchestrator would have attached when the op was initiated.
+func (b *Orchestrator) resumeOp(op ranje.Op) {
+	ch := make(chan error, 1)
+	go b.records.await(op.ID, ch)
+
+	// Hold the keyspace lock until the callbacks are attached, so the range
+	// can't change state in between.
+	_, unlock := b.ks.Ranges()
+	defer unlock()
+
+	// Obsolete ranges are only ever garbage collected, so if the range is gone
+	// then the op must have succeeded.
+	r, err := b.ks.GetRange(op.Ranges[0])
+	if err != nil {
+		close(ch)
+		return
+	}
+
+	switch op.Kind {
+	case ranje.OpKindMove:
+		for _, p := range r.Placements {
+			if p.NodeID == op.Src {
+				p.OnDestroy(func() {
+					close(ch)
+				})
+				return
+			}
+		}
+
+		// The source placement is already gone.
+		close(ch)
+
+	case ranje.OpKindSplit, ranje.OpKindJoin:
+		switch r.State {
+		case api.RsObsolete:
+			close(ch)
+
+		case api.RsSubsuming:
+			r.OnObsolete(func() {
+				close(ch)
+			})
+			r.OnAbort(func() {
+				ch <- fmt.Errorf("%s aborted", op.Kind)
+				close(ch)
+			})
+
+		default:
+			// The parent went back to active, so the op was aborted.
+			ch <- fmt.Errorf("%s aborted", op.Kind)
+			close(ch)
+		}
+	}
+}
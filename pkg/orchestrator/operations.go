@@ -1,9 +1,15 @@
 package orchestrator
 
-import "github.com/adammck/ranger/pkg/api"
+import (
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
 
 // TODO: Split this into Add, Remove
 type OpMove struct {
+	// The op which this move was submitted as, if any. See Orchestrator.Submit.
+	ID ranje.OpID
+
 	Range api.RangeID
 	Src   api.NodeID
 	Dest  api.NodeID
@@ -11,21 +17,38 @@ type OpMove struct {
 }
 
 type OpSplit struct {
+	ID    ranje.OpID
 	Range api.RangeID
-	Key   api.Key
 
+	// The keys to split the range at, in ascending order. The range is split
+	// into len(Keys)+1 child ranges.
+	Keys []api.Key
+
+	// The nodes to place each of the child ranges on, in the same order as the
+	// children. Optional; any missing or empty ones go wherever.
+	//
 	// TODO: Update this interface (and the proto) to accomodate replication.
 	//       Currently only the first placement can be placed deliberately.
 	//       Others just go wherever.
-	Left  api.NodeID
-	Right api.NodeID
+	Dests []api.NodeID
 
 	Err chan error
 }
 
 type OpJoin struct {
-	Left  api.RangeID
-	Right api.RangeID
-	Dest  api.NodeID
-	Err   chan error
+	ID ranje.OpID
+
+	// The ranges to join, in order of their start keys. There must be at least
+	// two of them.
+	Ranges []api.RangeID
+
+	Dest api.NodeID
+	Err  chan error
+}
+
+type OpAbort struct {
+	// Any range involved in the operation to abort, either parent or child.
+	Range api.RangeID
+
+	Err chan error
 }
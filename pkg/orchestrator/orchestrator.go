@@ -8,9 +8,9 @@ import (
 
 	"github.com/adammck/ranger/pkg/api"
 	"github.com/adammck/ranger/pkg/keyspace"
-	pb "github.com/adammck/ranger/pkg/proto/gen"
 	"github.com/adammck/ranger/pkg/ranje"
 	"github.com/adammck/ranger/pkg/roster"
+	"github.com/adammck/ranger/pkg/util/wake"
 	"google.golang.org/grpc"
 )
 
@@ -35,36 +35,150 @@ type Orchestrator struct {
 	// Same for joins.
 	opJoins   []OpJoin
 	opJoinsMu sync.RWMutex
+
+	// Same for aborts.
+	opAborts   []OpAbort
+	opAbortsMu sync.RWMutex
+
+	// How long a split or join can be in progress before it's aborted. Zero
+	// means never. The start times are only tracked in memory, so the clock
+	// starts again (for every operation in progress) when the controller
+	// restarts. Keyed by the ident of the first parent range.
+	opTimeout time.Duration
+	opStarted map[api.RangeID]time.Time
+
+	// The moves, splits, and joins requested via the Orchestrator API, which
+	// are tracked (and optionally persisted) so that they can be inspected
+	// after the RPC which requested them has returned.
+	records *opRecords
+
+	// Non-nil only while planning, i.e. in a throwaway orchestrator created by
+	// Plan, to record the nodes chosen for each new placement.
+	plan *Plan
+
+	// Notified to tick as soon as possible, rather than waiting for the ticker
+	// given to Run. See Wake.
+	sig *wake.Signal
+
+	// Called after every tick. See OnTick.
+	onTick func()
 }
 
 func New(ks *keyspace.Keyspace, rost *roster.Roster, srv *grpc.Server) *Orchestrator {
 	b := &Orchestrator{
-		ks:       ks,
-		rost:     rost,
-		srv:      srv,
-		opMoves:  []OpMove{},
-		opSplits: map[api.RangeID]OpSplit{},
-		opJoins:  []OpJoin{},
+		ks:        ks,
+		rost:      rost,
+		srv:       srv,
+		opMoves:   []OpMove{},
+		opSplits:  map[api.RangeID]OpSplit{},
+		opJoins:   []OpJoin{},
+		opAborts:  []OpAbort{},
+		opStarted: map[api.RangeID]time.Time{},
+		records:   newOpRecords(),
+		sig:       wake.New(),
 	}
 
-	// Register the gRPC server to receive instructions from operators. This
-	// will hopefully not be necessary once balancing actually works!
+	// Tick again whenever anything in the keyspace changes, since it's usually
+	// because a placement changed state, and the next step can be taken.
+	ks.NotifyOnChange(b.sig)
+
+	// The gRPC server to receive instructions from operators. This will
+	// hopefully not be necessary once balancing actually works!
 	b.bs = &orchestratorServer{orch: b}
-	pb.RegisterOrchestratorServer(srv, b.bs)
 
-	// Register the debug server, to fetch info about the state of the world.
-	// One could arguably pluck this straight from Consul -- since it's totally
-	// consistent *right?* -- but it's a much richer interface to do it here.
+	// The debug server, to fetch info about the state of the world. One could
+	// arguably pluck this straight from Consul -- since it's totally consistent
+	// *right?* -- but it's a much richer interface to do it here.
 	b.dbg = &debugServer{orch: b}
-	pb.RegisterDebugServer(srv, b.dbg)
+
+	// Register both as the default namespace. When serving several keyspaces,
+	// srv is nil, and the caller adds this orchestrator to a Namespaces.
+	if srv != nil {
+		ns := NewNamespaces(srv)
+		ns.Add(DefaultNamespace, b) // can't fail; it's empty
+	}
 
 	return b
 }
 
+// SetOpTimeout sets how long a split or join can be in progress before it's
+// automatically aborted. Zero (the default) means never. Operations can only be
+// aborted before any of their child ranges have been activated, so ones which
+// are slow after that point are left alone. This must be called before Run.
+func (b *Orchestrator) SetOpTimeout(d time.Duration) {
+	b.opTimeout = d
+}
+
+// QueueMove queues the given move, to be initiated during the next tick. If the
+// op has an error channel, it receives any error, and is closed when the move
+// is complete. Callers which give one must drain it.
+func (b *Orchestrator) QueueMove(op OpMove) {
+	b.opMovesMu.Lock()
+	defer b.opMovesMu.Unlock()
+	b.opMoves = append(b.opMoves, op)
+	b.Wake()
+}
+
+// QueueSplit is like QueueMove, for splits. Only one split of each range can be
+// queued at once; if the range already has one, the new one fails right away
+// with ErrRangeBusy, and the one which was already queued is left alone.
+func (b *Orchestrator) QueueSplit(op OpSplit) {
+	b.opSplitsMu.Lock()
+	defer b.opSplitsMu.Unlock()
+
+	if _, ok := b.opSplits[op.Range]; ok {
+		if op.Err != nil {
+			op.Err <- fmt.Errorf("%w: R%s", ErrRangeBusy, op.Range)
+			close(op.Err)
+		}
+		return
+	}
+
+	b.opSplits[op.Range] = op
+	b.Wake()
+}
+
+// QueueJoin is like QueueMove, for joins.
+func (b *Orchestrator) QueueJoin(op OpJoin) {
+	b.opJoinsMu.Lock()
+	defer b.opJoinsMu.Unlock()
+	b.opJoins = append(b.opJoins, op)
+	b.Wake()
+}
+
+// QueueAbort is like QueueMove, for aborts. The error channel is closed as soon
+// as the abort has been initiated.
+func (b *Orchestrator) QueueAbort(op OpAbort) {
+	b.opAbortsMu.Lock()
+	defer b.opAbortsMu.Unlock()
+	b.opAborts = append(b.opAborts, op)
+	b.Wake()
+}
+
+// Wake makes the next tick happen as soon as possible, rather than waiting for
+// the ticker given to Run. Call it when something has happened which the
+// orchestrator might want to react to, e.g. a command completing, or a node
+// being probed. Changes to the keyspace and newly queued ops do this already.
+func (b *Orchestrator) Wake() {
+	b.sig.Notify()
+}
+
+// OnTick sets a callback to be called after every tick, e.g. to wake the
+// actuator, since ticks change the desired state of placements. It must not
+// block, and must be set before Run is called.
+func (b *Orchestrator) OnTick(f func()) {
+	b.onTick = f
+}
+
 func (b *Orchestrator) Tick() {
+	if b.onTick != nil {
+		defer b.onTick()
+	}
 
-	// Hold the keyspace lock for the entire tick.
-	rs, unlock := b.ks.Ranges()
+	// Hold the keyspace lock for the entire tick. Obsolete ranges never change,
+	// so don't bother ticking them. There are usually far more of those than
+	// anything else. Aborted ranges might still have placements to drop.
+	rs, unlock := b.ks.RangesInState(api.RsActive, api.RsSubsuming, api.RsNew, api.RsAborted)
 	defer unlock()
 
 	// Any joins?
@@ -79,6 +193,21 @@ func (b *Orchestrator) Tick() {
 		b.opJoins = []OpJoin{}
 	}()
 
+	// Any aborts?
+	func() {
+		b.opAbortsMu.Lock()
+		defer b.opAbortsMu.Unlock()
+
+		for _, opAbort := range b.opAborts {
+			b.initAbort(opAbort)
+		}
+
+		b.opAborts = []OpAbort{}
+	}()
+
+	// Abort any operations which have been in progress for too long.
+	b.abortSlowOps()
+
 	// Keep track of which ranges we've already ticked, since we do those
 	// involved in ops first.
 	visited := map[api.RangeID]struct{}{}
@@ -114,12 +243,6 @@ func (b *Orchestrator) Tick() {
 	// big mess.
 	for _, r := range rs {
 
-		// Don't bother ticking Obsolete ranges. They never change.
-		// TODO: Don't include them in the first place!
-		if r.State == api.RsObsolete {
-			continue
-		}
-
 		// Skip the range if it has already been ticked by the operations loop,
 		// above. I think we need to refactor this.
 		if _, ok := visited[r.Meta.Ident]; ok {
@@ -144,20 +267,11 @@ func (b *Orchestrator) tickRange(r *ranje.Range, op *keyspace.Operation) {
 	case api.RsActive:
 
 		// Not enough placements? Create enough to reach the minimum.
-		if n := min(r.MinPlacements(), r.TargetActive()) - len(r.Placements); n > 0 {
-			con := ranje.Constraint{}
-
-			for i := 0; i < n; i++ {
-				nID, err := b.rost.Candidate(r, con)
-				if err != nil {
-					//log.Printf("no candidate for: rID=%s, con=%v, err=%v", r, con, err)
-					continue
-				}
+		b.replenishPlacements(r)
 
-				con = con.WithNot(nID)
-				r.NewPlacement(nID)
-			}
-		}
+		// Too many active placements? Probably the replication config has
+		// changed. Get rid of the extras.
+		b.shedPlacements(r)
 
 		// Initiate any pending moves for this range.
 		for {
@@ -203,10 +317,18 @@ func (b *Orchestrator) tickRange(r *ranje.Range, op *keyspace.Operation) {
 			b.initSplit(r, *opSplit)
 		}
 
+	case api.RsNew:
+		// Child ranges of operations in flight are placed like active ranges,
+		// but don't accept moves or splits until the operation is complete.
+		b.replenishPlacements(r)
+
 	case api.RsSubsuming:
 		// Skip parent ranges of operations in flight. The only thing to do is
 		// check whether they're complete, which we do before calling tick.
 
+	case api.RsAborted:
+		// Nothing to do except drop any placements, which tickPlacement does.
+
 	case api.RsObsolete:
 		// TODO: Skip obsolete ranges in Tick. There's never anything to do with
 		//       them, except possibly discard them, which we don't support yet.
@@ -230,9 +352,112 @@ func (b *Orchestrator) tickRange(r *ranje.Range, op *keyspace.Operation) {
 	}
 }
 
+// constraintFor returns the constraint for a new placement of the given range,
+// or of a child of it, according to its replication config and placement
+// policy. If the policy says so, nodes with placements of the given adjacent
+// ranges (which may be nil) are preferred.
+func constraintFor(r *ranje.Range, adjacent ...*ranje.Range) ranje.Constraint {
+	pp := r.PlacementPolicy()
+	c := ranje.Constraint{SpreadBy: r.SpreadBy()}.WithPolicy(pp)
+
+	if pp.ColocateAdjacent {
+		for _, a := range adjacent {
+			if a == nil {
+				continue
+			}
+
+			for _, p := range a.Placements {
+				if !p.Tainted {
+					c = c.WithAffinity(p.NodeID)
+				}
+			}
+		}
+	}
+
+	return c
+}
+
+// replenishPlacements creates enough new placements of the given range to
+// reach the minimum, if there are fewer than that. Any beyond the target number
+// of active placements will remain inactive, as spares.
+func (b *Orchestrator) replenishPlacements(r *ranje.Range) {
+	if n := r.MinPlacements() - len(r.Placements); n > 0 {
+		prev, next := b.ks.Adjacent(r)
+		con := constraintFor(r, prev, next)
+
+		// Never put two placements on the same node. The roster only knows
+		// about placements which the node has reported, which pending ones
+		// may not have been yet. Spread them apart from the placements which
+		// aren't about to be dropped.
+		for _, p := range r.Placements {
+			con = con.WithNot(p.NodeID)
+			if !p.Tainted {
+				con = con.WithPeer(p.NodeID)
+			}
+		}
+
+		for i := 0; i < n; i++ {
+			nID, err := b.candidate(roster.Proposal{
+				Range:      r,
+				Load:       b.rost.RangeLoad(r),
+				Constraint: con,
+			})
+			if err != nil {
+				//log.Printf("no candidate for: rID=%s, con=%v, err=%v", r, con, err)
+				continue
+			}
+
+			con = con.WithNot(nID).WithPeer(nID)
+			r.NewPlacement(nID)
+		}
+	}
+}
+
+// shedPlacements taints enough of the active placements of the given range to
+// bring it down to its target, and enough of its spare placements to bring it
+// down to its minimum, if it has more than that. Tainted placements are
+// deactivated and dropped when it's safe, like the source of a move. Tainted
+// placements are already on their way out, so aren't counted.
+func (b *Orchestrator) shedPlacements(r *ranje.Range) {
+	active := []*ranje.Placement{}
+	spare := []*ranje.Placement{}
+	for _, p := range r.Placements {
+		if p.Tainted || p.Failed(api.Activate) {
+			continue
+		}
+
+		switch {
+		case p.StateCurrent == api.PsActive:
+			active = append(active, p)
+		case p.StateCurrent == api.PsMissing || p.StateCurrent == api.PsDropped:
+			// Already gone.
+		case p.StateDesired == api.PsActive:
+			// About to be activated, probably to replace one which was lost,
+			// so it's not a spare any more.
+			active = append(active, p)
+		default:
+			spare = append(spare, p)
+		}
+	}
+
+	// Keep the oldest ones.
+	for i := len(active) - 1; i >= r.TargetActive(); i-- {
+		active[i].Tainted = true
+	}
+
+	// Same for spares, but count the active placements too. During a move,
+	// the replacement looks like a spare until it activates.
+	if len(active) > r.TargetActive() {
+		active = active[:r.TargetActive()]
+	}
+	for i := len(spare) - 1; i >= 0 && len(active)+i >= r.MinPlacements(); i-- {
+		spare[i].Tainted = true
+	}
+}
+
 func (b *Orchestrator) moveOp(rID api.RangeID) (OpMove, bool) {
-	b.opMovesMu.RLock()
-	defer b.opMovesMu.RUnlock()
+	b.opMovesMu.Lock()
+	defer b.opMovesMu.Unlock()
 
 	// TODO: Incredibly dumb to iterate this list for every range. Do it once at
 	//       the start of the Tick and stitch them back together or something!
@@ -247,6 +472,25 @@ func (b *Orchestrator) moveOp(rID api.RangeID) (OpMove, bool) {
 	return OpMove{}, false
 }
 
+// queueDrainMove queues a move of the given range off the given node, unless a
+// move of the range is already queued. This is called on every tick until the
+// node is drained, which may be a long time, so mustn't grow the queue.
+func (b *Orchestrator) queueDrainMove(rID api.RangeID, nID api.NodeID) {
+	b.opMovesMu.Lock()
+	defer b.opMovesMu.Unlock()
+
+	for i := range b.opMoves {
+		if b.opMoves[i].Range == rID {
+			return
+		}
+	}
+
+	b.opMoves = append(b.opMoves, OpMove{
+		Range: rID,
+		Src:   nID,
+	})
+}
+
 func (b *Orchestrator) doMove(r *ranje.Range, opMove OpMove) error {
 	var src *ranje.Placement
 	if opMove.Src != "" {
@@ -279,15 +523,28 @@ func (b *Orchestrator) doMove(r *ranje.Range, opMove OpMove) error {
 	}
 
 	// If the src is already tainted, it might be being replaced by some other
-	// placement already. (The operator must manually remove the taint if that
-	// really isn't the case.)
-	//
-	// TODO: Add an endpoint to remove the taint.
+	// placement already. (The operator must manually remove the taint, via the
+	// Untaint RPC, if that really isn't the case.)
 	if src.Tainted {
 		return fmt.Errorf("src placement is already tainted (rID=%s, src=%s)", r.Meta.Ident, src.NodeID)
 	}
 
-	destNodeID, err := b.rost.Candidate(r, ranje.Constraint{NodeID: opMove.Dest})
+	// Spread the new placement apart from the others which will remain, i.e.
+	// not the source, which it's replacing.
+	prev, next := b.ks.Adjacent(r)
+	con := constraintFor(r, prev, next).WithNodeID(opMove.Dest)
+	for _, p := range r.Placements {
+		if p != src && !p.Tainted {
+			con = con.WithPeer(p.NodeID)
+		}
+	}
+
+	destNodeID, err := b.candidate(roster.Proposal{
+		Range:      r,
+		Kind:       ranje.OpKindMove,
+		Load:       b.rost.RangeLoad(r),
+		Constraint: con,
+	})
 	if err != nil {
 		return err
 	}
@@ -302,6 +559,7 @@ func (b *Orchestrator) doMove(r *ranje.Range, opMove OpMove) error {
 	}
 
 	r.NewPlacement(destNodeID)
+	b.records.started(opMove.ID, src.NodeID)
 
 	// Taint the source range, to provide a hint to the orchestrator that it
 	// should deactivate and drop itself asap (i.e. when the replacement,
@@ -324,26 +582,16 @@ func (b *Orchestrator) tickPlacement(p *ranje.Placement, r *ranje.Range, op *key
 		}
 	}
 
-	// If the node this placement is on wants to be drained, mark this placement
-	// as wanting to be moved. The next Tick will create a new placement, and
-	// exclude the current node from the candidates.
+	// If the node this placement is on wants to be drained (or an operator
+	// wants it to be), mark this placement as wanting to be moved. The next
+	// Tick will create a new placement, and exclude the current node from the
+	// candidates. Only active ranges can be moved, and tainted placements are
+	// already being replaced.
 	//
 	// TODO: Also this is almost certainly only valid in some placement states;
 	//       think about that.
-	if n != nil && n.WantDrain() {
-		func() {
-			b.opMovesMu.Lock()
-			defer b.opMovesMu.Unlock()
-
-			// TODO: Probably add a method to do this.
-			b.opMoves = append(b.opMoves, OpMove{
-				Range: p.Range().Meta.Ident,
-				Src:   n.Ident(),
-			})
-		}()
-
-		// TODO: Fix
-		//p.SetWantMoveTo(ranje.AnyNode())
+	if n != nil && b.rost.WantDrain(n) && r.State == api.RsActive && !p.Tainted {
+		b.queueDrainMove(r.Meta.Ident, n.Ident())
 	}
 
 	switch p.StateCurrent {
@@ -377,6 +625,13 @@ func (b *Orchestrator) tickPlacement(p *ranje.Placement, r *ranje.Range, op *key
 			doPlace = true
 		}
 
+		// Don't bother placing ranges which have been aborted. If the node
+		// doesn't have the placement, there's nothing to drop.
+		if doPlace && r.State == api.RsAborted {
+			destroy = true
+			return
+		}
+
 		if doPlace {
 			p.Want(api.PsInactive)
 			if p.Failed(api.Prepare) {
@@ -493,9 +748,102 @@ func (b *Orchestrator) tickPlacement(p *ranje.Placement, r *ranje.Range, op *key
 	return
 }
 
+// Run calls Tick immediately, and then again whenever the orchestrator is woken
+// (see Wake), or the given ticker fires, forever. The ticker is only a safety
+// net, so can be quite slow.
 func (b *Orchestrator) Run(t *time.Ticker) {
-	for ; true; <-t.C {
-		b.Tick()
+	wake.Loop(b.sig, t, b.Tick)
+}
+
+// initAbort aborts the split or join which the given range is involved in, and
+// sends the resulting error (if any) down the error channel and closes it.
+//
+// Caller must hold the keyspace lock and opAbortsMu.
+func (b *Orchestrator) initAbort(opAbort OpAbort) {
+	err := initAbortInner(b, opAbort)
+
+	// If no error channel is given, this drops the error on the floor.
+	if opAbort.Err == nil {
+		return
+	}
+
+	if err != nil {
+		opAbort.Err <- err
+	}
+
+	close(opAbort.Err)
+}
+
+func initAbortInner(b *Orchestrator, opAbort OpAbort) error {
+	ops, err := b.ks.Operations()
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		for _, r := range op.Ranges() {
+			if r.Meta.Ident == opAbort.Range {
+				return op.Abort(b.ks)
+			}
+		}
+	}
+
+	return fmt.Errorf("range is not involved in any operation (rID=%s)", opAbort.Range)
+}
+
+// abortSlowOps aborts any operation which has been in progress for longer than
+// the op timeout, if there is one.
+//
+// Caller must hold the keyspace lock.
+func (b *Orchestrator) abortSlowOps() {
+	if b.opTimeout <= 0 {
+		return
+	}
+
+	ops, err := b.ks.Operations()
+	if err != nil {
+		log.Printf("error getting operations: %v", err)
+		return
+	}
+
+	now := time.Now()
+	seen := map[api.RangeID]struct{}{}
+
+	for _, op := range ops {
+
+		// The parents are always first.
+		rID := op.Ranges()[0].Meta.Ident
+		seen[rID] = struct{}{}
+
+		t, ok := b.opStarted[rID]
+		if !ok {
+			b.opStarted[rID] = now
+			continue
+		}
+
+		if now.Sub(t) < b.opTimeout {
+			continue
+		}
+
+		// Can't abort this one, but don't keep logging about it every tick.
+		if t.IsZero() {
+			continue
+		}
+
+		if err := op.Abort(b.ks); err != nil {
+			log.Printf("not aborting slow operation: %v (op=%s)", err, op.TestString())
+			b.opStarted[rID] = time.Time{}
+			continue
+		}
+
+		log.Printf("aborted slow operation (op=%s)", op.TestString())
+	}
+
+	// Forget about operations which are no longer in progress.
+	for rID := range b.opStarted {
+		if _, ok := seen[rID]; !ok {
+			delete(b.opStarted, rID)
+		}
 	}
 }
 
@@ -505,6 +853,9 @@ func (b *Orchestrator) Run(t *time.Ticker) {
 // Caller must hold the keyspace lock and opJoinsMu.
 func (b *Orchestrator) initJoin(opJoin OpJoin) {
 	r, err := initJoinInner(b, opJoin)
+	if err == nil {
+		b.records.started(opJoin.ID, "")
+	}
 
 	// If no error channel is given, this drops the error on the floor.
 	if opJoin.Err == nil {
@@ -517,11 +868,17 @@ func (b *Orchestrator) initJoin(opJoin OpJoin) {
 		return
 	}
 
-	// Unlock operator RPC when the join finishes. This assumes that both
+	// Unlock operator RPC when the join finishes. This assumes that all of the
 	// parents will become obsolete at once, via Operation.CheckComplete.
 	r.OnObsolete(func() {
 		close(opJoin.Err)
 	})
+
+	// Or when it's aborted, in which case they all become active again.
+	r.OnAbort(func() {
+		opJoin.Err <- fmt.Errorf("join aborted")
+		close(opJoin.Err)
+	})
 }
 
 // initJoinInner is a helper func so we can return errors directly. This should
@@ -532,35 +889,51 @@ func (b *Orchestrator) initJoin(opJoin OpJoin) {
 // already looks it up from the rID, so I'm being lazy and passing it along.
 func initJoinInner(b *Orchestrator, opJoin OpJoin) (*ranje.Range, error) {
 
-	r1, err := b.ks.GetRange(opJoin.Left)
-	if err != nil {
-		return nil, fmt.Errorf("join with invalid left side: %v (rID=%s)", err, opJoin.Left)
+	if len(opJoin.Ranges) < 2 {
+		return nil, fmt.Errorf("join with fewer than two ranges (got %d)", len(opJoin.Ranges))
 	}
 
-	r2, err := b.ks.GetRange(opJoin.Right)
-	if err != nil {
-		return nil, fmt.Errorf("join with invalid right side: %v (rID=%s)", err, opJoin.Right)
+	parents := make([]*ranje.Range, len(opJoin.Ranges))
+	for i, rID := range opJoin.Ranges {
+		r, err := b.ks.GetRange(rID)
+		if err != nil {
+			return nil, fmt.Errorf("join with invalid range: %v (rID=%s)", err, rID)
+		}
+		parents[i] = r
 	}
 
-	constraint := ranje.AnyNode
+	// Place the child by the config and policy of the first parent, too.
+	// They're the same, or JoinN would fail. It will be adjacent to whatever
+	// the first and last parents are.
+	prev, _ := b.ks.Adjacent(parents[0])
+	_, next := b.ks.Adjacent(parents[len(parents)-1])
+	constraint := constraintFor(parents[0], prev, next)
 
-	// Exclude any node which has a placement of either parent reange.
+	// Exclude any node which has a placement of any parent range.
 	// TODO: Make this tweakable once Dest can specify all target nodes.
-	for _, r := range []*ranje.Range{r1, r2} {
+	for _, r := range parents {
 		for _, p := range r.Placements {
 			constraint.Not = append(constraint.Not, p.NodeID)
 		}
 	}
 
-	// Use replication configs of the left parent for now.
-	// TODO: Verify that the two ranges have compatible replication configs, so
-	//       that the join can be performed without deadlocking.
-	n := min(r1.MinPlacements(), r1.TargetActive())
+	// The child is expected to have the combined load of the parents.
+	load := api.LoadInfo{}
+	for _, r := range parents {
+		load = load.Add(b.rost.RangeLoad(r))
+	}
+
+	// Use replication configs of the first parent. JoinN verifies that all of
+	// the parents have the same config before joining them. Only the placements
+	// which will be activated are needed to complete the join; any spares are
+	// added later, by replenishPlacements.
+	n := parents[0].TargetActive()
 	nIDs := make([]api.NodeID, n)
+	var err error
 
 	// Find the candidates for the placements of the new (joined) range before
-	// performing the join. Once that happens, we can't (currently) abort, so
-	// the parent ranges will be stuck in RsSubsuming.
+	// performing the join. Once that happens, the parent ranges will be stuck
+	// in RsSubsuming until the join completes or is aborted.
 
 	for i := 0; i < n; i++ {
 
@@ -569,35 +942,44 @@ func initJoinInner(b *Orchestrator, opJoin OpJoin) (*ranje.Range, error) {
 			c.NodeID = opJoin.Dest
 		}
 
-		nIDs[i], err = b.rost.Candidate(nil, c)
+		nIDs[i], err = b.candidate(roster.Proposal{
+			Kind:       ranje.OpKindJoin,
+			Load:       load,
+			Constraint: c,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("error selecting join candidate: %v", err)
 		}
 
-		// Exclude this node from further placements.
-		constraint = constraint.WithNot(nIDs[i])
+		// Exclude this node from further placements, and spread them apart
+		// from it. The parents' placements will all be dropped, so aren't
+		// peers.
+		constraint = constraint.WithNot(nIDs[i]).WithPeer(nIDs[i])
 	}
 
-	r3, err := b.ks.JoinTwo(r1, r2)
+	child, err := b.ks.JoinN(parents)
 	if err != nil {
-		return nil, fmt.Errorf("join failed: %v (left=%v, right=%v)", err, opJoin.Left, opJoin.Right)
+		return nil, fmt.Errorf("join failed: %v (rIDs=%v)", err, opJoin.Ranges)
 	}
 
 	// If we made it this far, the join has happened and already been persisted.
 	// No turning back now.
 
 	for i := range nIDs {
-		r3.NewPlacement(nIDs[i])
+		child.NewPlacement(nIDs[i])
 	}
 
-	// It's not an error that this func returns r1 not r3. The caller needs it.
-	// See the docstring.
-	return r1, nil
+	// It's not an error that this func returns a parent rather than the child.
+	// The caller needs it. See the docstring.
+	return parents[0], nil
 }
 
 // TODO: Dedup this with initJoin, once OnReady is OnObsolete.
 func (b *Orchestrator) initSplit(r *ranje.Range, opSplit OpSplit) {
 	err := initSplitInner(b, r, opSplit)
+	if err == nil {
+		b.records.started(opSplit.ID, "")
+	}
 
 	// If no error channel is given, this drops the error on the floor.
 	if opSplit.Err == nil {
@@ -616,22 +998,25 @@ func (b *Orchestrator) initSplit(r *ranje.Range, opSplit OpSplit) {
 	r.OnObsolete(func() {
 		close(opSplit.Err)
 	})
+
+	// If the split is aborted, the parent range goes back to being active
+	// instead, and the RPC handler receives an error.
+	r.OnAbort(func() {
+		opSplit.Err <- fmt.Errorf("split aborted")
+		close(opSplit.Err)
+	})
 }
 
 func initSplitInner(b *Orchestrator, r *ranje.Range, opSplit OpSplit) error {
 
-	// Find candidates for each of the placements in the new the left and right
-	// sides *before* performing the split. Once the split happens, we can't
-	// (currently) abort, so the parent range will be stuck in RsSubsuming until
-	// placement is possible.
-	//
-	// TODO: Allow split abort by allowing ranges to transition back from
-	//       RsSubsuming into RsActive, and from RsActive into some new terminal
-	//       state (RsAborted?) like RsObsolete. Would also need a new entry
-	//       state (RsNewSplit?) to indicate that it's okay to give up, unlike
-	//       RsActive. Ranges would need to keep track of how many placements
-	//       had been created and failed.
+	// Find candidates for each of the placements in each of the new child
+	// ranges *before* performing the split. Once the split happens, the parent
+	// range will be stuck in RsSubsuming until placement is possible, or until
+	// the split is aborted (see initAbort).
 
+	// Like the parent, only the first and last children will be adjacent to
+	// any other range.
+	prev, next := b.ks.Adjacent(r)
 	constraint := ranje.AnyNode
 
 	// Exclude any node which has a placement of the parent range.
@@ -642,24 +1027,54 @@ func initSplitInner(b *Orchestrator, r *ranje.Range, opSplit OpSplit) error {
 		constraint.Not = append(constraint.Not, p.NodeID)
 	}
 
-	n := min(r.MinPlacements(), r.TargetActive()) * 2
-	nIDs := make([]api.NodeID, n)
+	// Number of children, and number of placements of each. Spares are added
+	// later, by replenishPlacements, like the join.
+	k := len(opSplit.Keys) + 1
+	n := r.TargetActive()
+
+	if len(opSplit.Dests) > k {
+		return fmt.Errorf("more dests than child ranges: %d > %d", len(opSplit.Dests), k)
+	}
+
+	// Without knowing the distribution of the keys, assume that the load of
+	// the parent will be shared evenly between the children.
+	load := b.rost.RangeLoad(r).Scale(1 / float64(k))
+
+	// nIDs[i][ii] is the node to put placement i of child ii on.
+	nIDs := make([][]api.NodeID, n)
 	var err error
 
-	for i := 0; i < n; i += 2 {
-		for ii := 0; ii < 2; ii++ {
+	for i := 0; i < n; i++ {
+		nIDs[i] = make([]api.NodeID, k)
+		for ii := 0; ii < k; ii++ {
+
+			// Children have the same replication config and placement
+			// policy as the parent.
+			var adj []*ranje.Range
+			if ii == 0 {
+				adj = append(adj, prev)
+			}
+			if ii == k-1 {
+				adj = append(adj, next)
+			}
 
-			// Copy just for this iteration, so we can mutate.
-			c := constraint
-			if i == 0 {
-				if ii == 0 && opSplit.Left != "" {
-					c.NodeID = opSplit.Left
-				} else if ii == 1 && opSplit.Right != "" {
-					c.NodeID = opSplit.Right
-				}
+			c := constraintFor(r, adj...)
+			c.Not = constraint.Not
+			if i == 0 && ii < len(opSplit.Dests) && opSplit.Dests[ii] != "" {
+				c.NodeID = opSplit.Dests[ii]
 			}
 
-			nIDs[i+ii], err = b.rost.Candidate(nil, c)
+			// Spread the placements of each child apart from one another.
+			// The parent's placements will all be dropped, so aren't peers.
+			for _, p := range nIDs[:i] {
+				c = c.WithPeer(p[ii])
+			}
+
+			nIDs[i][ii], err = b.candidate(roster.Proposal{
+				Kind:       ranje.OpKindSplit,
+				Load:       load,
+				Constraint: c,
+			})
 
 			// TODO: Make it possible to force a split even when not enough
 			//       candiates can be found.
@@ -668,14 +1083,14 @@ func initSplitInner(b *Orchestrator, r *ranje.Range, opSplit OpSplit) error {
 			}
 
 			// Exclude this node from further placements.
-			constraint = constraint.WithNot(nIDs[i+ii])
+			constraint = constraint.WithNot(nIDs[i][ii])
 		}
 	}
 
 	// Perform the actual range split. The source range (r) is moved to
 	// RsSubsuming, where it will remain until its placements have all been
-	// moved elsewhere. Two new ranges
-	rL, rR, err := b.ks.Split(r, opSplit.Key)
+	// moved elsewhere. New ranges are created for each of the children.
+	children, err := b.ks.SplitN(r, opSplit.Keys)
 	if err != nil {
 		return err
 	}
@@ -696,9 +1111,10 @@ func initSplitInner(b *Orchestrator, r *ranje.Range, opSplit OpSplit) error {
 	//       "ranges which have splits scheduled" loop before the main
 	//       all-ranges loop. Join is already up there.
 
-	for i := 0; i < n; i += 2 {
-		rL.NewPlacement(nIDs[i])
-		rR.NewPlacement(nIDs[i+1])
+	for i := range nIDs {
+		for ii, c := range children {
+			c.NewPlacement(nIDs[i][ii])
+		}
 	}
 
 	return nil
@@ -2,10 +2,16 @@ package orchestrator
 
 import (
 	"fmt"
+	"io"
+	"log"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"context"
 
@@ -14,11 +20,15 @@ import (
 	"github.com/adammck/ranger/pkg/api"
 	mock_disc "github.com/adammck/ranger/pkg/discovery/mock"
 	"github.com/adammck/ranger/pkg/keyspace"
+	"github.com/adammck/ranger/pkg/proto/conv"
+	pb "github.com/adammck/ranger/pkg/proto/gen"
 	"github.com/adammck/ranger/pkg/ranje"
 	"github.com/adammck/ranger/pkg/roster"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const strictTransactions = true
@@ -125,6 +135,47 @@ func Test_R3_Place(t *testing.T) {
 	requireStable(t, orch, act)
 }
 
+func TestPlace_SpreadBy(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive}"
+	rosStr := "{aaa []} {bbb []} {ccc []} {ddd []} {eee []} {fff []} {ggg []} {hhh []} {iii []}"
+	repl := r3
+	repl.SpreadBy = "zone"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, repl)
+
+	// Three zones, with three nodes each. Without spreading, every placement
+	// would end up in the first.
+	zones := map[api.NodeID]string{
+		"aaa": "a", "bbb": "a", "ccc": "a",
+		"ddd": "b", "eee": "b", "fff": "b",
+		"ggg": "c", "hhh": "c", "iii": "c",
+	}
+	for nID, n := range orch.rost.Nodes {
+		n.Remote.Labels = map[string]string{"zone": zones[nID]}
+	}
+
+	requireSpread := func(rIDs ...api.RangeID) {
+		t.Helper()
+		for _, rID := range rIDs {
+			r := mustGetRange(t, orch.ks, int(rID))
+			seen := map[string]bool{}
+			for _, p := range r.Placements {
+				seen[zones[p.NodeID]] = true
+			}
+			assert.Len(t, seen, 3, "placements of range %d: %v", rID, r.Placements)
+		}
+	}
+
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=aaa:PsActive p1=ddd:PsActive p2=ggg:PsActive}", orch.ks.LogString())
+
+	// The children of a split are also spread, even though the nodes of the
+	// parent are excluded.
+	opErr := splitOpKeys(orch, 1, "ccc")
+	tickUntilStable(t, orch, act)
+	require.NoError(t, <-opErr)
+	requireSpread(2, 3)
+}
+
 func TestPlace_Short(t *testing.T) {
 	ksStr := "{1 [-inf, +inf] RsActive}"
 	rosStr := "{test-aaa []}"
@@ -135,6 +186,23 @@ func TestPlace_Short(t *testing.T) {
 	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}", orch.ks.LogString())
 }
 
+func TestPlace_Bootstrap_Short(t *testing.T) {
+	ks, err := keyspace.NewWithBootstrap(&FakePersister{}, r1, keyspace.Bootstrap{
+		Boundaries: []api.Key{"ggg", "ppp"},
+		Nodes:      [][]api.NodeID{{"test-ccc"}, {"test-bbb"}},
+	})
+	require.NoError(t, err)
+
+	ros := rosterFactory(t, context.TODO(), ks, parseRoster(t, "{test-aaa []} {test-bbb []} {test-ccc []}"))
+	act := actuator.New(ks, ros, 0, mock_actuator.New(noStrictTransactions))
+	orch := New(ks, ros, grpc.NewServer())
+
+	// The first two ranges are placed where they were bootstrapped, and the
+	// last one wherever there's room.
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, ggg] RsActive p0=test-ccc:PsActive} {2 (ggg, ppp] RsActive p0=test-bbb:PsActive} {3 (ppp, +inf] RsActive p0=test-aaa:PsActive}", orch.ks.LogString())
+}
+
 func TestPlace_Slow(t *testing.T) {
 	ksStr := "{1 [-inf, +inf] RsActive}"
 	rosStr := "{test-aaa []}"
@@ -472,6 +540,32 @@ func TestMove_Short(t *testing.T) {
 	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=test-bbb:PsActive}", orch.ks.LogString())
 }
 
+func TestSetReplication_Short(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb []} {test-ccc []} {test-ddd []} {test-eee []} {test-fff []} {test-ggg []} {test-hhh []} {test-iii []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	requireStable(t, orch, act)
+
+	// Scale up.
+	require.NoError(t, orch.ks.SetReplication(1, &r3))
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive p1=test-bbb:PsActive p2=test-ccc:PsActive}", orch.ks.LogString())
+
+	// Children inherit the config.
+	opErr := splitOp(orch, 1)
+	tickUntilStable(t, orch, act)
+	assertClosed(t, opErr)
+	assert.Equal(t, r3, mustGetRange(t, orch.ks, 2).ReplicationConfig())
+	assert.Equal(t, r3, mustGetRange(t, orch.ks, 3).ReplicationConfig())
+
+	// Scale back down.
+	rIDs, err := orch.ks.SetReplicationSpan(api.ZeroKey, api.ZeroKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, []api.RangeID{2, 3}, rIDs)
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=test-ddd:PsActive} {3 (ccc, +inf] RsActive p0=test-eee:PsActive}", orch.ks.LogString())
+}
+
 func TestMove_Slow(t *testing.T) {
 	ksStr := "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}"
 	rosStr := "{test-aaa [1:NsActive]} {test-bbb []}"
@@ -799,56 +893,56 @@ func TestSplit_R1(t *testing.T) {
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc []}", orch.rost.TestString())
 	assert.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks))
 
 	tickWait(t, orch, act)
 	assert.Equal(t, "Prepare(R2, bbb), Prepare(R3, ccc)", commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// 2. Deactivate
 
 	tickWait(t, orch, act)
 	assert.Equal(t, "Deactivate(R1, aaa)", commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// 3. Activate
 
 	tickWait(t, orch, act)
 	assert.Equal(t, "Activate(R2, bbb), Activate(R3, ccc)", commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
 
 	// 4. Drop
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, aaa)", commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsDropped} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsDropped} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
 
 	// 5. Cleanup
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
 	assert.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks)) // Operation is still active.
 
@@ -879,8 +973,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd []} {eee []} {fff []} {ggg []} {hhh []} {iii []}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsPending p1=fff:PsPending p2=hhh:PsPending} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsPending p1=ggg:PsPending p2=iii:PsPending}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsPending p1=fff:PsPending p2=hhh:PsPending} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsPending p1=ggg:PsPending p2=iii:PsPending}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -889,8 +983,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd [2:NsInactive]} {eee [3:NsInactive]} {fff [2:NsInactive]} {ggg [3:NsInactive]} {hhh [2:NsInactive]} {iii [3:NsInactive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsPending p1=fff:PsPending p2=hhh:PsPending} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsPending p1=ggg:PsPending p2=iii:PsPending}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsPending p1=fff:PsPending p2=hhh:PsPending} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsPending p1=ggg:PsPending p2=iii:PsPending}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -899,8 +993,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd [2:NsInactive]} {eee [3:NsInactive]} {fff [2:NsInactive]} {ggg [3:NsInactive]} {hhh [2:NsInactive]} {iii [3:NsInactive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsInactive p1=fff:PsInactive p2=hhh:PsInactive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsInactive p1=ggg:PsInactive p2=iii:PsInactive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsInactive p1=fff:PsInactive p2=hhh:PsInactive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsInactive p1=ggg:PsInactive p2=iii:PsInactive}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -911,8 +1005,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa [1:NsInactive]} {bbb [1:NsInactive]} {ccc [1:NsInactive]} {ddd [2:NsInactive]} {eee [3:NsInactive]} {fff [2:NsInactive]} {ggg [3:NsInactive]} {hhh [2:NsInactive]} {iii [3:NsInactive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsInactive p1=fff:PsInactive p2=hhh:PsInactive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsInactive p1=ggg:PsInactive p2=iii:PsInactive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsInactive p1=fff:PsInactive p2=hhh:PsInactive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsInactive p1=ggg:PsInactive p2=iii:PsInactive}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -928,8 +1022,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa [1:NsInactive]} {bbb [1:NsInactive]} {ccc [1:NsInactive]} {ddd [2:NsActive]} {eee [3:NsActive]} {fff [2:NsActive]} {ggg [3:NsActive]} {hhh [2:NsActive]} {iii [3:NsActive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsInactive p1=fff:PsInactive p2=hhh:PsInactive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsInactive p1=ggg:PsInactive p2=iii:PsInactive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsInactive p1=fff:PsInactive p2=hhh:PsInactive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsInactive p1=ggg:PsInactive p2=iii:PsInactive}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -938,8 +1032,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa [1:NsInactive]} {bbb [1:NsInactive]} {ccc [1:NsInactive]} {ddd [2:NsActive]} {eee [3:NsActive]} {fff [2:NsActive]} {ggg [3:NsActive]} {hhh [2:NsActive]} {iii [3:NsActive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -950,8 +1044,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa []} {bbb []} {ccc []} {ddd [2:NsActive]} {eee [3:NsActive]} {fff [2:NsActive]} {ggg [3:NsActive]} {hhh [2:NsActive]} {iii [3:NsActive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -960,8 +1054,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa []} {bbb []} {ccc []} {ddd [2:NsActive]} {eee [3:NsActive]} {fff [2:NsActive]} {ggg [3:NsActive]} {hhh [2:NsActive]} {iii [3:NsActive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming p0=aaa:PsDropped p1=bbb:PsDropped p2=ccc:PsDropped} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
 		"{Split 1 -> 2,3}",
 		opErr)
 
@@ -972,8 +1066,8 @@ func TestSplit_R3(t *testing.T) {
 		"{aaa []} {bbb []} {ccc []} {ddd [2:NsActive]} {eee [3:NsActive]} {fff [2:NsActive]} {ggg [3:NsActive]} {hhh [2:NsActive]} {iii [3:NsActive]}",
 		""+
 			"{1 [-inf, +inf] RsSubsuming} "+
-			"{2 [-inf, ccc] RsActive p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
-			"{3 (ccc, +inf] RsActive p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
+			"{2 [-inf, ccc] RsNew p0=ddd:PsActive p1=fff:PsActive p2=hhh:PsActive} "+
+			"{3 (ccc, +inf] RsNew p0=eee:PsActive p1=ggg:PsActive p2=iii:PsActive}",
 		"{Split 1 -> 2,3}", // Operation is still active.
 		opErr)
 
@@ -1004,6 +1098,20 @@ func TestSplit_Short(t *testing.T) {
 	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
 }
 
+func TestSplit_ThreeWay_Short(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []} {ddd []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	opErr := splitOpKeys(orch, 1, "ccc", "ppp")
+	tickUntilStable(t, orch, act)
+
+	// Range 1 was split into ranges 2, 3 and 4 at ccc and ppp.
+	assert.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]} {ddd [4:NsActive]}", orch.rost.TestString())
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, ppp] RsActive p0=ccc:PsActive} {4 (ppp, +inf] RsActive p0=ddd:PsActive}", orch.ks.LogString())
+	assertClosed(t, opErr)
+}
+
 func TestSplit_Slow(t *testing.T) {
 	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
 	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
@@ -1017,7 +1125,7 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc []}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks))
 
@@ -1031,7 +1139,7 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R2, bbb), Prepare(R3, ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsPreparing]} {ccc [3:NsPreparing]}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks))
 
@@ -1040,14 +1148,14 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R2, bbb), Prepare(R3, ccc)", commands(t, act)) // retry
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsPreparing]}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks))
 
 	// Updates placement from roster.
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R3, ccc)", commands(t, act)) // retry
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsPreparing]}", orch.rost.TestString())
 
 	requireStable(t, orch, act)
@@ -1055,13 +1163,13 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R3, ccc)", commands(t, act)) // retry
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// Updates placement from roster.
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	//
@@ -1073,7 +1181,7 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, aaa)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsDeactivating]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	requireStable(t, orch, act)
@@ -1081,7 +1189,7 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, aaa)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	//
@@ -1094,7 +1202,7 @@ func TestSplit_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R2, bbb), Activate(R3, ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActivating]} {ccc [3:NsActivating]}", orch.rost.TestString())
 
 	requireStable(t, orch, act)
@@ -1103,12 +1211,12 @@ func TestSplit_Slow(t *testing.T) {
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R2, bbb), Activate(R3, ccc)", commands(t, act))
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActivating]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R2, bbb)", commands(t, act))
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActivating]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	requireStable(t, orch, act)
 	i2s.Response(api.NsActive) // R2 activated.
@@ -1116,12 +1224,12 @@ func TestSplit_Slow(t *testing.T) {
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R2, bbb)", commands(t, act))
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	//
 	// ---- Drop
@@ -1133,12 +1241,12 @@ func TestSplit_Slow(t *testing.T) {
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, aaa)", commands(t, act))
 	require.Equal(t, "{aaa [1:NsDropping]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, aaa)", commands(t, act))
 	require.Equal(t, "{aaa [1:NsDropping]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	requireStable(t, orch, act)
 	i1d.Response(api.NsNotFound) // R1 finished dropping.
@@ -1146,12 +1254,12 @@ func TestSplit_Slow(t *testing.T) {
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, aaa)", commands(t, act))
 	require.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
 	require.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsDropped} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsDropped} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	//
 	// ---- Cleanup
@@ -1160,7 +1268,7 @@ func TestSplit_Slow(t *testing.T) {
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
 	require.Equal(t, "{aaa []} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
@@ -1182,20 +1290,20 @@ func TestSplitFailure_Prepare(t *testing.T) {
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc []} {ddd []}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks))
 	require.Equal(t, "Prepare(R2, bbb), Prepare(R3, ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 
 	for attempt := 2; attempt <= 3; attempt++ {
 		tickWait(t, orch, act)
 		// Only the failing placement (rID=2) will be retried.
 		require.Equal(t, "Prepare(R2, bbb)", commands(t, act))
-		assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+		assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 		assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 	}
 
@@ -1205,7 +1313,7 @@ func TestSplitFailure_Prepare(t *testing.T) {
 	tickWait(t, orch, act)
 	// Failed placement is destroyed.
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 
 	// 2. Prepare (retry on ddd)
@@ -1216,7 +1324,7 @@ func TestSplitFailure_Prepare(t *testing.T) {
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=ddd:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=ddd:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd [2:NsInactive]}", orch.rost.TestString())
 
 	// Recovered! Finish the split.
@@ -1236,7 +1344,7 @@ func TestSplitFailure_Deactivate_Short(t *testing.T) {
 	// End up in a bad but stable situation where the original range never
 	// relinquish (that's the point), but that the successors don't activate.
 	tickUntilStable(t, orch, act)
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// R1 is stuck until some operator comes and unsticks it.
@@ -1249,6 +1357,68 @@ func TestSplitFailure_Deactivate(t *testing.T) {
 	t.Skip("not implemented")
 }
 
+func TestSplitAbort_Short(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	inject(t, act, "aaa", 1, api.Deactivate).Failure()
+	opErr := splitOp(orch, 1)
+
+	// Same as TestSplitFailure_Deactivate_Short.
+	tickUntilStable(t, orch, act)
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
+
+	// Give up on the split. The parent goes back to being active, and the
+	// children are dropped.
+	abortErr := abortOp(orch, 3)
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=aaa:PsActive} {2 [-inf, ccc] RsAborted} {3 (ccc, +inf] RsAborted}", orch.ks.LogString())
+	assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc []}", orch.rost.TestString())
+	assert.Empty(t, OpsString(orch.ks))
+	assertClosed(t, abortErr)
+	assertClosedError(t, opErr, "split aborted")
+}
+
+func TestSplitAbort_Timeout_Short(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	inject(t, act, "aaa", 1, api.Deactivate).Failure()
+	opErr := splitOp(orch, 1)
+
+	tickUntilStable(t, orch, act)
+	require.Equal(t, "{Split 1 <- 2,3}", OpsString(orch.ks))
+
+	orch.SetOpTimeout(time.Nanosecond)
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=aaa:PsActive} {2 [-inf, ccc] RsAborted} {3 (ccc, +inf] RsAborted}", orch.ks.LogString())
+	assert.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc []}", orch.rost.TestString())
+	assertClosedError(t, opErr, "split aborted")
+}
+
+func TestSplitAbort_TooLate(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []} {ddd []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	inject(t, act, "bbb", 2, api.Activate).Failure()
+	splitOp(orch, 1)
+
+	// Tick until the right side has activated.
+	for i := 0; i < 6; i++ {
+		tickWait(t, orch, act)
+	}
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
+
+	// Now it's too late to abort, because R3 might have accepted writes.
+	abortErr := abortOp(orch, 1)
+	tickWait(t, orch, act)
+	assertClosedError(t, abortErr, "can't abort operation: child range has active placements (rID=3, n=1)")
+
+	// The split continues as if nothing happened.
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=ddd:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+}
+
 func TestSplitFailure_Activate_Short(t *testing.T) {
 	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
 	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []} {ddd []}"
@@ -1270,26 +1440,26 @@ func TestSplitFailure_Activate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc []} {ddd []}", orch.rost.TestString())
 
 	// 1. Prepare
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R2, bbb), Prepare(R3, ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 
 	// 2. Deactivate
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, aaa)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 
 	// 3. Activate
@@ -1301,11 +1471,11 @@ func TestSplitFailure_Activate(t *testing.T) {
 		tickWait(t, orch, act)
 		if attempt == 1 {
 			require.Equal(t, "Activate(R2, bbb), Activate(R3, ccc)", commands(t, act))
-			require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+			require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 			require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsActive]} {ddd []}", orch.rost.TestString())
 		} else {
 			require.Equal(t, "Activate(R2, bbb)", commands(t, act))
-			require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+			require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 			require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsActive]} {ddd []}", orch.rost.TestString())
 		}
 	}
@@ -1321,14 +1491,14 @@ func TestSplitFailure_Activate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R3, ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 	require.True(t, mustGetPlacement(t, orch.ks, 2, "bbb").Failed(api.Activate))
 	require.Equal(t, "{Split 1 <- 2,3}", OpsString(orch.ks))
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 <- 2,3}", OpsString(orch.ks))
 
@@ -1339,7 +1509,7 @@ func TestSplitFailure_Activate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R1, aaa)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb [2:NsInactive]} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 <- 2,3}", OpsString(orch.ks))
 
@@ -1348,7 +1518,7 @@ func TestSplitFailure_Activate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R2, bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=bbb:PsInactive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 <- 2,3}", OpsString(orch.ks))
 
@@ -1356,7 +1526,7 @@ func TestSplitFailure_Activate(t *testing.T) {
 	// to the normal/forwards direction so we can continue placing the split.
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd []}", orch.rost.TestString())
 	require.Equal(t, "{Split 1 -> 2,3}", OpsString(orch.ks))
 
@@ -1364,7 +1534,7 @@ func TestSplitFailure_Activate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R2, ddd)", commands(t, act))
-	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsActive p0=ddd:PsPending} {3 (ccc, +inf] RsActive p0=ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=ddd:PsPending} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{aaa [1:NsActive]} {bbb []} {ccc [3:NsInactive]} {ddd [2:NsInactive]}", orch.rost.TestString())
 
 	// Recovered! Let the re-placement of R3 on Nccc finish.
@@ -1388,7 +1558,7 @@ func TestSplitFailure_Drop_Short(t *testing.T) {
 	// End up in a bad but stable situation where the original range never
 	// relinquish (that's the point), but that the successors don't activate.
 	tickUntilStable(t, orch, act)
-	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsInactive} {2 [-inf, ccc] RsNew p0=bbb:PsActive} {3 (ccc, +inf] RsNew p0=ccc:PsActive}", orch.ks.LogString())
 	assert.Equal(t, "{aaa [1:NsInactive]} {bbb [2:NsActive]} {ccc [3:NsActive]}", orch.rost.TestString())
 
 	// R1 is stuck until some operator comes and unsticks it.
@@ -1407,13 +1577,13 @@ func TestJoin_R1(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
@@ -1421,38 +1591,38 @@ func TestJoin_R1(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, test-aaa), Deactivate(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// Activate
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	// Drop
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, test-aaa), Drop(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	// Cleanup
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsDropped} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsDropped} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsDropped} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsDropped} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
@@ -1479,14 +1649,14 @@ func TestJoin_R3(t *testing.T) {
 	tickCmpOpErr(t, orch, act,
 		"Prepare(R3, ggg), Prepare(R3, hhh), Prepare(R3, iii)",
 		"{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd [2:NsActive]} {eee [2:NsActive]} {fff [2:NsActive]} {ggg [3:NsInactive]} {hhh [3:NsInactive]} {iii [3:NsInactive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsActive p1=eee:PsActive p2=fff:PsActive} {3 [-inf, +inf] RsActive p0=ggg:PsPending p1=hhh:PsPending p2=iii:PsPending}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsActive p1=eee:PsActive p2=fff:PsActive} {3 [-inf, +inf] RsNew p0=ggg:PsPending p1=hhh:PsPending p2=iii:PsPending}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
 	tickCmpOpErr(t, orch, act,
 		"",
 		"{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd [2:NsActive]} {eee [2:NsActive]} {fff [2:NsActive]} {ggg [3:NsInactive]} {hhh [3:NsInactive]} {iii [3:NsInactive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsActive p1=eee:PsActive p2=fff:PsActive} {3 [-inf, +inf] RsActive p0=ggg:PsInactive p1=hhh:PsInactive p2=iii:PsInactive}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsActive p1=eee:PsActive p2=fff:PsActive} {3 [-inf, +inf] RsNew p0=ggg:PsInactive p1=hhh:PsInactive p2=iii:PsInactive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
@@ -1495,7 +1665,7 @@ func TestJoin_R3(t *testing.T) {
 	tickCmpOpErr(t, orch, act,
 		"Deactivate(R1, aaa), Deactivate(R1, bbb), Deactivate(R1, ccc), Deactivate(R2, ddd), Deactivate(R2, eee), Deactivate(R2, fff)",
 		"{aaa [1:NsInactive]} {bbb [1:NsInactive]} {ccc [1:NsInactive]} {ddd [2:NsInactive]} {eee [2:NsInactive]} {fff [2:NsInactive]} {ggg [3:NsInactive]} {hhh [3:NsInactive]} {iii [3:NsInactive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsActive p1=eee:PsActive p2=fff:PsActive} {3 [-inf, +inf] RsActive p0=ggg:PsInactive p1=hhh:PsInactive p2=iii:PsInactive}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsActive p1=eee:PsActive p2=fff:PsActive} {3 [-inf, +inf] RsNew p0=ggg:PsInactive p1=hhh:PsInactive p2=iii:PsInactive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
@@ -1504,14 +1674,14 @@ func TestJoin_R3(t *testing.T) {
 	tickCmpOpErr(t, orch, act,
 		"Activate(R3, ggg), Activate(R3, hhh), Activate(R3, iii)",
 		"{aaa [1:NsInactive]} {bbb [1:NsInactive]} {ccc [1:NsInactive]} {ddd [2:NsInactive]} {eee [2:NsInactive]} {fff [2:NsInactive]} {ggg [3:NsActive]} {hhh [3:NsActive]} {iii [3:NsActive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsInactive p1=eee:PsInactive p2=fff:PsInactive} {3 [-inf, +inf] RsActive p0=ggg:PsInactive p1=hhh:PsInactive p2=iii:PsInactive}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsInactive p1=eee:PsInactive p2=fff:PsInactive} {3 [-inf, +inf] RsNew p0=ggg:PsInactive p1=hhh:PsInactive p2=iii:PsInactive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
 	tickCmpOpErr(t, orch, act,
 		"",
 		"{aaa [1:NsInactive]} {bbb [1:NsInactive]} {ccc [1:NsInactive]} {ddd [2:NsInactive]} {eee [2:NsInactive]} {fff [2:NsInactive]} {ggg [3:NsActive]} {hhh [3:NsActive]} {iii [3:NsActive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsInactive p1=eee:PsInactive p2=fff:PsInactive} {3 [-inf, +inf] RsActive p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsInactive p1=eee:PsInactive p2=fff:PsInactive} {3 [-inf, +inf] RsNew p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
@@ -1520,14 +1690,14 @@ func TestJoin_R3(t *testing.T) {
 	tickCmpOpErr(t, orch, act,
 		"Drop(R1, aaa), Drop(R1, bbb), Drop(R1, ccc), Drop(R2, ddd), Drop(R2, eee), Drop(R2, fff)",
 		"{aaa []} {bbb []} {ccc []} {ddd []} {eee []} {fff []} {ggg [3:NsActive]} {hhh [3:NsActive]} {iii [3:NsActive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsInactive p1=eee:PsInactive p2=fff:PsInactive} {3 [-inf, +inf] RsActive p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsInactive p1=bbb:PsInactive p2=ccc:PsInactive} {2 (ggg, +inf] RsSubsuming p0=ddd:PsInactive p1=eee:PsInactive p2=fff:PsInactive} {3 [-inf, +inf] RsNew p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
 	tickCmpOpErr(t, orch, act,
 		"",
 		"{aaa []} {bbb []} {ccc []} {ddd []} {eee []} {fff []} {ggg [3:NsActive]} {hhh [3:NsActive]} {iii [3:NsActive]}",
-		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsDropped p1=bbb:PsDropped p2=ccc:PsDropped} {2 (ggg, +inf] RsSubsuming p0=ddd:PsDropped p1=eee:PsDropped p2=fff:PsDropped} {3 [-inf, +inf] RsActive p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
+		"{1 [-inf, ggg] RsSubsuming p0=aaa:PsDropped p1=bbb:PsDropped p2=ccc:PsDropped} {2 (ggg, +inf] RsSubsuming p0=ddd:PsDropped p1=eee:PsDropped p2=fff:PsDropped} {3 [-inf, +inf] RsNew p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
@@ -1536,7 +1706,7 @@ func TestJoin_R3(t *testing.T) {
 	tickCmpOpErr(t, orch, act,
 		"",
 		"{aaa []} {bbb []} {ccc []} {ddd []} {eee []} {fff []} {ggg [3:NsActive]} {hhh [3:NsActive]} {iii [3:NsActive]}",
-		"{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsActive p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
+		"{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsNew p0=ggg:PsActive p1=hhh:PsActive p2=iii:PsActive}",
 		"{Join 1,2 -> 3}",
 		opErr)
 
@@ -1563,6 +1733,31 @@ func TestJoin_Short(t *testing.T) {
 	assert.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 }
 
+func TestJoin_ThreeWay_Short(t *testing.T) {
+	ksStr := "{1 [-inf, ggg] RsActive p0=test-aaa:PsActive} {2 (ggg, ppp] RsActive p0=test-bbb:PsActive} {3 (ppp, +inf] RsActive p0=test-ccc:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsActive]} {test-ddd []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	opErr := joinOpN(orch, []int{1, 2, 3}, "test-ddd")
+
+	tickUntilStable(t, orch, act)
+	// Ranges 1, 2 and 3 were joined into range 4, which holds the entire keyspace.
+	assert.Equal(t, "{1 [-inf, ggg] RsObsolete} {2 (ggg, ppp] RsObsolete} {3 (ppp, +inf] RsObsolete} {4 [-inf, +inf] RsActive p0=test-ddd:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc []} {test-ddd [4:NsActive]}", orch.rost.TestString())
+	assertClosed(t, opErr)
+}
+
+func TestJoinFailure_NotAdjacent(t *testing.T) {
+	ksStr := "{1 [-inf, ggg] RsActive p0=test-aaa:PsActive} {2 (ggg, ppp] RsActive p0=test-bbb:PsActive} {3 (ppp, +inf] RsActive p0=test-ccc:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsActive]} {test-ddd []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	opErr := joinOpN(orch, []int{1, 3}, "test-ddd")
+
+	tickWait(t, orch, act)
+	assert.Error(t, <-opErr)
+	assert.Equal(t, ksStr, orch.ks.LogString())
+	assertClosed(t, opErr)
+}
+
 func TestJoin_Slow(t *testing.T) {
 	ksStr := "{1 [-inf, ggg] RsActive p0=test-aaa:PsActive} {2 (ggg, +inf] RsActive p0=test-bbb:PsActive}"
 	rosStr := "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc []}"
@@ -1583,7 +1778,7 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsPreparing]}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
@@ -1592,13 +1787,13 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
@@ -1612,7 +1807,7 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, test-aaa), Deactivate(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsDeactivating]} {test-bbb [2:NsDeactivating]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// Parent ranges finish deactivating.
@@ -1622,14 +1817,14 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, test-aaa), Deactivate(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// Missing?
 	// tickWait(t, orch, act)
 	// require.Empty(t, commands(t, act))
 	// require.Equal(t, "Deactivate(R1, test-aaa), Deactivate(R2, test-bbb)", commands(t, act))
-	// require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	// require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	// require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 
 	//
@@ -1641,7 +1836,7 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsActivating]}", orch.rost.TestString())
 
 	// New range activates.
@@ -1650,12 +1845,12 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	//
@@ -1668,7 +1863,7 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, test-aaa), Drop(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsDropping]} {test-bbb [2:NsDropping]} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	// Drops finish.
@@ -1679,7 +1874,7 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R1, test-aaa), Drop(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	//
@@ -1688,12 +1883,12 @@ func TestJoin_Slow(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsDropped} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsDropped} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsDropped} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsDropped} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
@@ -1736,7 +1931,7 @@ func TestJoinFailure_Prepare(t *testing.T) {
 	for attempt := 1; attempt <= 3; attempt++ {
 		tickWait(t, orch, act)
 		assert.Equal(t, "Prepare(R3, test-ccc)", commands(t, act))
-		require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsPending}", orch.ks.LogString())
+		require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsPending}", orch.ks.LogString())
 		require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc []} {test-ddd []}", orch.rost.TestString())
 	}
 
@@ -1769,13 +1964,13 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Prepare(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsPending}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsPending}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
 	tickWait(t, orch, act)
 	assert.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 
 	//
@@ -1788,11 +1983,11 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 		tickWait(t, orch, act)
 		if attempt == 1 {
 			require.Equal(t, "Deactivate(R1, test-aaa), Deactivate(R2, test-bbb)", commands(t, act))
-			require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+			require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 			require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 		} else {
 			require.Equal(t, "Deactivate(R1, test-aaa)", commands(t, act))
-			require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+			require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 			require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 		}
 	}
@@ -1804,7 +1999,7 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 	require.True(t, mustGetPlacement(t, orch.ks, 1, "test-aaa").Failed(api.Deactivate))
 	require.Equal(t, "{Join 1,2 <- 3}", OpsString(orch.ks))
@@ -1812,7 +2007,7 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 	// R2 updates state
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 
 	// R3 is wedged as inactive indefinitely...
@@ -1838,7 +2033,7 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Deactivate(R1, test-aaa), Deactivate(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]} {test-ddd []}", orch.rost.TestString())
 	require.Equal(t, "{Join 1,2 -> 3}", OpsString(orch.ks))
 
@@ -1848,12 +2043,12 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Activate(R3, test-ccc)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsMissing} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsMissing} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb [2:NsInactive]} {test-ccc [3:NsActive]} {test-ddd []}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsDropped} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsDropped} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb [2:NsInactive]} {test-ccc [3:NsActive]} {test-ddd []}", orch.rost.TestString())
 
 	//
@@ -1862,12 +2057,12 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Equal(t, "Drop(R2, test-bbb)", commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]} {test-ddd []}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsDropped} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsDropped} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]} {test-ddd []}", orch.rost.TestString())
 
 	//
@@ -1876,7 +2071,7 @@ func TestJoinFailure_Deactivate(t *testing.T) {
 
 	tickWait(t, orch, act)
 	require.Empty(t, commands(t, act))
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa []} {test-bbb []} {test-ccc [3:NsActive]} {test-ddd []}", orch.rost.TestString())
 
 	tickWait(t, orch, act)
@@ -1903,13 +2098,13 @@ func TestJoinFailure_Activate_Short(t *testing.T) {
 
 	// This is a bad state to be in! But it's valid, because the parent ranges
 	// must be deactivated before the child range can be activated.
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsInactive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb [2:NsInactive]} {test-ccc [3:NsInactive]}", orch.rost.TestString())
 
 	// Couple of ticks later, the parent ranges are reactivated.
 	tickWait(t, orch, act)
 	tickWait(t, orch, act)
-	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsActive p0=test-ccc:PsInactive}", orch.ks.LogString())
+	require.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsActive} {2 (ggg, +inf] RsSubsuming p0=test-bbb:PsActive} {3 [-inf, +inf] RsNew p0=test-ccc:PsInactive}", orch.ks.LogString())
 	require.Equal(t, "{test-aaa [1:NsActive]} {test-bbb [2:NsActive]} {test-ccc []}", orch.rost.TestString())
 
 	// The above is arguably the end of the test, but fast forward to the stable
@@ -1933,7 +2128,7 @@ func TestJoinFailure_Drop_Short(t *testing.T) {
 	// the right (non-stuck) side of the parent dropped, and the left (stuck)
 	// side inactive but still hanging around on aaa.
 	tickUntilStable(t, orch, act)
-	assert.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsActive p0=test-ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{1 [-inf, ggg] RsSubsuming p0=test-aaa:PsInactive} {2 (ggg, +inf] RsSubsuming} {3 [-inf, +inf] RsNew p0=test-ccc:PsActive}", orch.ks.LogString())
 	assert.Equal(t, "{test-aaa [1:NsInactive]} {test-bbb []} {test-ccc [3:NsActive]}", orch.rost.TestString())
 
 	p := mustGetPlacement(t, orch.ks, 1, "test-aaa")
@@ -1977,6 +2172,941 @@ func TestMissingPlacement(t *testing.T) {
 	assert.Equal(t, "{test-aaa [1:NsInactive]}", orch.rost.TestString())
 }
 
+// TestReplication runs the basic operations against a few different replication
+// configs, and checks that every range ends up with the expected number of
+// active and spare placements, without ever exceeding the maximums on the way.
+// The other tests cover the exact sequence of RPCs, mostly for R1.
+func TestReplication(t *testing.T) {
+	configs := map[string]ranje.ReplicationConfig{
+		"R1": r1,
+		"R3": r3,
+
+		// One active placement, and one spare ready to replace it.
+		"R1Spare": {
+			TargetActive:  1,
+			MinActive:     0,
+			MaxActive:     1,
+			MinPlacements: 2,
+			MaxPlacements: 3,
+		},
+
+		// Two active placements and one spare, which can briefly be three
+		// active or one active while moving.
+		"R2Spare": {
+			TargetActive:  2,
+			MinActive:     1,
+			MaxActive:     3,
+			MinPlacements: 3,
+			MaxPlacements: 5,
+		},
+	}
+
+	for name, rc := range configs {
+		rc := rc
+		t.Run(name, func(t *testing.T) {
+			t.Run("Place", func(t *testing.T) {
+				replicationFixture(t, rc)
+			})
+
+			t.Run("Move", func(t *testing.T) {
+				orch, act := replicationFixture(t, rc)
+				src := firstActive(t, mustGetRange(t, orch.ks, 1))
+
+				moveOpWithSource(orch, 1, string(src), "")
+				tickUntilStableWithinLimits(t, orch, act)
+				requireReplicated(t, orch)
+				requireNotOn(t, mustGetRange(t, orch.ks, 1), src)
+			})
+
+			t.Run("Split", func(t *testing.T) {
+				orch, act := replicationFixture(t, rc)
+
+				opErr := splitOp(orch, 1)
+				tickUntilStableWithinLimits(t, orch, act)
+				assertClosed(t, opErr)
+				requireReplicated(t, orch)
+				requireInState(t, orch.ks, api.RsObsolete, 1)
+				requireInState(t, orch.ks, api.RsActive, 2, 3)
+			})
+
+			t.Run("Join", func(t *testing.T) {
+				orch, act := replicationFixture(t, rc)
+				splitOp(orch, 1)
+				tickUntilStableWithinLimits(t, orch, act)
+
+				opErr := joinOp(orch, 2, 3, "")
+				tickUntilStableWithinLimits(t, orch, act)
+				assertClosed(t, opErr)
+				requireReplicated(t, orch)
+				requireInState(t, orch.ks, api.RsObsolete, 1, 2, 3)
+				requireInState(t, orch.ks, api.RsActive, 4)
+			})
+
+			t.Run("NodeLoss", func(t *testing.T) {
+				orch, act := replicationFixture(t, rc)
+				lost := firstActive(t, mustGetRange(t, orch.ks, 1))
+
+				func() {
+					orch.rost.Lock()
+					defer orch.rost.Unlock()
+					delete(orch.rost.Nodes, lost)
+				}()
+
+				tickUntilStableWithinLimits(t, orch, act)
+				requireReplicated(t, orch)
+				requireNotOn(t, mustGetRange(t, orch.ks, 1), lost)
+			})
+		})
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	orchA, _ := orchFactory(t, "{1 [-inf, +inf] RsActive}", "{test-aaa []}", noStrictTransactions, r1)
+	orchB, _ := orchFactory(t, "{1 [-inf, ggg] RsActive} {2 (ggg, +inf] RsActive}", "{test-bbb []}", noStrictTransactions, r1)
+
+	ns := NewNamespaces(grpc.NewServer())
+	require.NoError(t, ns.Add("a", orchA))
+	require.NoError(t, ns.Add("b", orchB))
+	require.Error(t, ns.Add("a", orchB))
+
+	bs := &orchestratorRouter{ns: ns}
+	dbg := &debugRouter{ns: ns}
+	ctx := context.TODO()
+
+	// Each request sees only the keyspace and nodes of its own namespace.
+
+	res, err := dbg.RangesList(ctx, &pb.RangesListRequest{Namespace: "a"})
+	require.NoError(t, err)
+	require.Len(t, res.Ranges, 1)
+
+	res, err = dbg.RangesList(ctx, &pb.RangesListRequest{Namespace: "b"})
+	require.NoError(t, err)
+	require.Len(t, res.Ranges, 2)
+
+	nodes, err := dbg.NodesList(ctx, &pb.NodesListRequest{Namespace: "b"})
+	require.NoError(t, err)
+	require.Len(t, nodes.Nodes, 1)
+	require.Equal(t, "test-bbb", nodes.Nodes[0].Node.Ident)
+
+	rep, err := bs.SetReplication(ctx, &pb.SetReplicationRequest{Namespace: "a", Config: conv.ReplicationConfigToProto(r3)})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, rep.Ranges)
+	require.Equal(t, r3, mustGetRange(t, orchA.ks, 1).ReplicationConfig())
+	require.Equal(t, r1, mustGetRange(t, orchB.ks, 1).ReplicationConfig())
+
+	_, err = dbg.RangesList(ctx, &pb.RangesListRequest{Namespace: "c"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// There's no default namespace, so every request must specify one.
+	_, err = bs.Abort(ctx, &pb.AbortRequest{Range: 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestNamespaces_Standby(t *testing.T) {
+	orchA, _ := orchFactory(t, "{1 [-inf, +inf] RsActive}", "{test-aaa []}", noStrictTransactions, r1)
+	orchB, _ := orchFactory(t, "{1 [-inf, ggg] RsActive} {2 (ggg, +inf] RsActive}", "{test-aaa []}", noStrictTransactions, r1)
+
+	ns := NewNamespaces(grpc.NewServer())
+	require.NoError(t, ns.Add("a", orchA))
+
+	bs := &orchestratorRouter{ns: ns}
+	dbg := &debugRouter{ns: ns}
+	ctx := context.TODO()
+
+	ns.SetStandby("")
+	_, err := bs.SetReplication(ctx, &pb.SetReplicationRequest{Namespace: "a", Config: conv.ReplicationConfigToProto(r3)})
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = standby; no leader is elected")
+
+	ns.SetStandby("leader:8000")
+	_, err = bs.Cordon(ctx, &pb.CordonRequest{Namespace: "a", Node: "test-aaa"})
+	require.EqualError(t, err, "rpc error: code = Unavailable desc = standby; leader is at: leader:8000")
+	require.Equal(t, r1, mustGetRange(t, orchA.ks, 1).ReplicationConfig())
+
+	// Standbys still serve the read-only debug RPCs, from whichever keyspace
+	// they loaded most recently.
+	res, err := dbg.RangesList(ctx, &pb.RangesListRequest{Namespace: "a"})
+	require.NoError(t, err)
+	require.Len(t, res.Ranges, 1)
+
+	ns.Replace("a", orchB)
+	res, err = dbg.RangesList(ctx, &pb.RangesListRequest{Namespace: "a"})
+	require.NoError(t, err)
+	require.Len(t, res.Ranges, 2)
+
+	ns.SetLeader()
+	_, err = bs.SetReplication(ctx, &pb.SetReplicationRequest{Namespace: "a", Config: conv.ReplicationConfigToProto(r3)})
+	require.NoError(t, err)
+	require.Equal(t, r3, mustGetRange(t, orchB.ks, 1).ReplicationConfig())
+}
+
+func TestOps_Submit(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	pers := &FakeOpPersister{}
+	require.NoError(t, orch.LoadOps(pers))
+
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindSplit, Ranges: []api.RangeID{1}, Keys: []api.Key{"ccc"}})
+	require.NoError(t, err)
+	assert.Equal(t, ranje.OpID(1), op.ID)
+	assert.Equal(t, ranje.OsQueued, op.State)
+	assert.Equal(t, ranje.OsQueued, pers.ops[1].State)
+
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+
+	op = waitOp(t, orch, op.ID)
+	assert.Equal(t, ranje.OsSucceeded, op.State)
+	assert.Equal(t, ranje.OsSucceeded, pers.ops[1].State)
+
+	// Finished ops can't be cancelled.
+	_, err = orch.CancelOp(op.ID)
+	assert.ErrorIs(t, err, ErrCantCancel)
+
+	_, err = orch.Op(2)
+	assert.ErrorIs(t, err, ErrNoSuchOp)
+}
+
+func TestOps_Failed(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	// There's nowhere to move the range to.
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}})
+	require.NoError(t, err)
+
+	tickUntilStable(t, orch, act)
+	op = waitOp(t, orch, op.ID)
+	assert.Equal(t, ranje.OsFailed, op.State)
+	assert.Equal(t, "no candidates available (rID=1, c=Constraint{any})", op.Error)
+}
+
+func TestOps_SplitBusy(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindSplit, Ranges: []api.RangeID{1}, Keys: []api.Key{"ccc"}})
+	require.NoError(t, err)
+
+	// A second split of the same range is rejected, rather than replacing the
+	// first one.
+	_, err = orch.Submit(ranje.Op{Kind: ranje.OpKindSplit, Ranges: []api.RangeID{1}, Keys: []api.Key{"ggg"}})
+	assert.ErrorIs(t, err, ErrRangeBusy)
+
+	res, err := orch.bs.Split(context.TODO(), &pb.SplitRequest{Range: 1, Boundary: []byte("ggg")})
+	assert.Nil(t, res)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Splits queued directly (e.g. by the balancer) fail right away instead.
+	ch := make(chan error, 1)
+	orch.QueueSplit(OpSplit{Range: 1, Keys: []api.Key{"ggg"}, Err: ch})
+	assert.ErrorIs(t, <-ch, ErrRangeBusy)
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+
+	op = waitOp(t, orch, op.ID)
+	assert.Equal(t, ranje.OsSucceeded, op.State)
+}
+
+func TestOps_CancelQueued(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}, Nodes: []api.NodeID{"bbb"}})
+	require.NoError(t, err)
+
+	op, err = orch.CancelOp(op.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ranje.OsCancelled, op.State)
+
+	// The move never happens.
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, ksStr, orch.ks.LogString())
+}
+
+func TestOps_CancelRunning(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	inject(t, act, "aaa", 1, api.Deactivate).Failure()
+
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindSplit, Ranges: []api.RangeID{1}, Keys: []api.Key{"ccc"}})
+	require.NoError(t, err)
+
+	// Same as TestSplitAbort_Short.
+	tickUntilStable(t, orch, act)
+	op, err = orch.Op(op.ID)
+	require.NoError(t, err)
+	require.Equal(t, ranje.OsRunning, op.State)
+
+	// Cancelling a running split aborts it, which blocks until the next tick.
+	errCh := make(chan error)
+	go func() {
+		_, err := orch.CancelOp(op.ID)
+		errCh <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		orch.opAbortsMu.Lock()
+		defer orch.opAbortsMu.Unlock()
+		return len(orch.opAborts) == 1
+	}, time.Second, time.Millisecond)
+
+	tickUntilStable(t, orch, act)
+	require.NoError(t, <-errCh)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=aaa:PsActive} {2 [-inf, ccc] RsAborted} {3 (ccc, +inf] RsAborted}", orch.ks.LogString())
+
+	op = waitOp(t, orch, op.ID)
+	assert.Equal(t, ranje.OsCancelled, op.State)
+}
+
+func TestOps_Load(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	// One op which was queued before the restart, and one which finished.
+	pers := &FakeOpPersister{ops: map[ranje.OpID]ranje.Op{
+		3: {ID: 3, Kind: ranje.OpKindJoin, State: ranje.OsSucceeded, Ranges: []api.RangeID{8, 9}},
+		4: {ID: 4, Kind: ranje.OpKindMove, State: ranje.OsQueued, Ranges: []api.RangeID{1}, Nodes: []api.NodeID{"bbb"}},
+	}}
+	require.NoError(t, orch.LoadOps(pers))
+
+	ops := orch.Ops()
+	require.Len(t, ops, 2)
+	assert.Equal(t, ranje.OpID(3), ops[0].ID)
+	assert.Equal(t, ranje.OpID(4), ops[1].ID)
+
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=bbb:PsActive}", orch.ks.LogString())
+
+	op := waitOp(t, orch, 4)
+	assert.Equal(t, ranje.OsSucceeded, op.State)
+	assert.Equal(t, api.NodeID("aaa"), op.Src)
+
+	// IDs continue from the highest loaded.
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}})
+	require.NoError(t, err)
+	assert.Equal(t, ranje.OpID(5), op.ID)
+}
+
+func TestClearFailures(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	i1d := inject(t, act, "aaa", 1, api.Deactivate).Failure()
+	splitOp(orch, 1)
+
+	// Same as TestSplitFailure_Deactivate_Short.
+	tickUntilStable(t, orch, act)
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ccc] RsNew p0=bbb:PsInactive} {3 (ccc, +inf] RsNew p0=ccc:PsInactive}", orch.ks.LogString())
+
+	// The operator fixes whatever was wrong with the node, then clears the
+	// failure so that the controller tries again.
+	i1d.Success().Response(api.NsInactive)
+	res, err := orch.bs.ClearFailures(context.TODO(), &pb.ClearFailuresRequest{Range: 1, Node: "aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Deactivate"}, res.Actions)
+	assert.Empty(t, mustGetPlacement(t, orch.ks, 1, "aaa").Failures())
+
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+
+	_, err = orch.bs.ClearFailures(context.TODO(), &pb.ClearFailuresRequest{Range: 2, Node: "bbb", Actions: []string{"Explode"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = orch.bs.ClearFailures(context.TODO(), &pb.ClearFailuresRequest{Range: 2, Node: "ccc"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestUntaint(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	inject(t, act, "test-bbb", 1, api.Activate).Failure()
+	moveOp(orch, 1, "test-bbb")
+
+	// Same as TestMoveFailure_Activate. The source placement is left tainted,
+	// so can't be moved again.
+	tickUntilStable(t, orch, act)
+	require.Equal(t, "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive:tainted}", orch.ks.LogString())
+	op, err := orch.Submit(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}})
+	require.NoError(t, err)
+	tickWait(t, orch, act)
+	op = waitOp(t, orch, op.ID)
+	assert.Equal(t, "src placement is already tainted (rID=1, src=test-aaa)", op.Error)
+
+	require.NoError(t, orch.ks.Untaint(1, "test-aaa"))
+	require.Equal(t, "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}", orch.ks.LogString())
+	require.EqualError(t, orch.ks.Untaint(1, "test-aaa"), "placement is not tainted: {1 [-inf, +inf] test-aaa:PsActive}")
+	require.EqualError(t, orch.ks.Untaint(1, "test-bbb"), "no placement of range 1 on node test-bbb")
+}
+
+func TestDropPlacement(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	inject(t, act, "test-aaa", 1, api.Drop).Failure()
+	moveOp(orch, 1, "test-bbb")
+
+	// Same as TestMoveFailure_Drop. The source placement can't be dropped.
+	tickUntil(t, orch, act, func(ks, ro string) bool {
+		return ks == "{1 [-inf, +inf] RsActive p0=test-aaa:PsInactive:tainted p1=test-bbb:PsActive}"
+	})
+
+	// The operator gives up on the node, and the controller forgets about the
+	// placement without sending any more RPCs.
+	require.NoError(t, orch.ks.DropPlacement(1, "test-aaa"))
+	require.Equal(t, "{1 [-inf, +inf] RsActive p0=test-aaa:PsMissing:tainted p1=test-bbb:PsActive}", orch.ks.LogString())
+
+	tickWait(t, orch, act)
+	tickWait(t, orch, act)
+	assert.Equal(t, "{1 [-inf, +inf] RsActive p0=test-bbb:PsActive}", orch.ks.LogString())
+	assert.Empty(t, commands(t, act))
+}
+
+func TestDrain(t *testing.T) {
+	ksStr := "{1 [-inf, ggg] RsActive p0=aaa:PsActive} {2 (ggg, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive 2:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	ctx := context.TODO()
+
+	// Cordoned nodes keep their placements, but don't get new ones.
+	_, err := orch.bs.Cordon(ctx, &pb.CordonRequest{Node: "bbb"})
+	require.NoError(t, err)
+	tickUntilStable(t, orch, act)
+	require.Equal(t, ksStr, orch.ks.LogString())
+
+	_, err = orch.bs.Drain(ctx, &pb.DrainRequest{Node: "aaa"})
+	require.NoError(t, err)
+
+	res, err := orch.bs.DrainStatus(ctx, &pb.DrainStatusRequest{Node: "aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, pb.AdminState_AS_DRAINING, res.State)
+	assert.Equal(t, uint64(2), res.Placements)
+
+	// Everything moves off the draining node, but not to the cordoned one.
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, "{1 [-inf, ggg] RsActive p0=ccc:PsActive} {2 (ggg, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	assert.Equal(t, "{aaa []} {bbb []} {ccc [1:NsActive 2:NsActive]}", orch.rost.TestString())
+
+	res, err = orch.bs.DrainStatus(ctx, &pb.DrainStatusRequest{Node: "aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), res.Placements)
+
+	_, err = orch.bs.Uncordon(ctx, &pb.UncordonRequest{Node: "aaa"})
+	require.NoError(t, err)
+	assert.Equal(t, ranje.AsNormal, orch.rost.AdminState("aaa"))
+	assert.Equal(t, ranje.AsCordoned, orch.rost.AdminState("bbb"))
+}
+
+func TestDrain_NoCandidates(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	_, err := orch.bs.Drain(context.TODO(), &pb.DrainRequest{Node: "aaa"})
+	require.NoError(t, err)
+
+	// There's nowhere to move the range to, so the node stays draining. Each
+	// tick tries again, but only one move is ever queued.
+	for i := 0; i < 5; i++ {
+		tickWait(t, orch, act)
+		require.Len(t, orch.opMoves, 1)
+	}
+	require.Equal(t, ksStr, orch.ks.LogString())
+}
+
+func TestDrain_Subsuming(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	// Split the range, but never finish preparing the children, so the parent
+	// is stuck in RsSubsuming.
+	for _, nID := range []api.NodeID{"bbb", "ccc"} {
+		inject(t, act, nID, 2, api.Prepare).Response(api.NsPreparing)
+		inject(t, act, nID, 3, api.Prepare).Response(api.NsPreparing)
+	}
+	orch.QueueSplit(OpSplit{Range: 1, Keys: []api.Key{"ggg"}})
+	tickWait(t, orch, act)
+	tickWait(t, orch, act)
+	require.Equal(t, "{1 [-inf, +inf] RsSubsuming p0=aaa:PsActive} {2 [-inf, ggg] RsNew p0=bbb:PsPending} {3 (ggg, +inf] RsNew p0=ccc:PsPending}", orch.ks.LogString())
+
+	// The parent can't be moved, so no moves are queued for it.
+	_, err := orch.bs.Drain(context.TODO(), &pb.DrainRequest{Node: "aaa"})
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		tickWait(t, orch, act)
+		require.Empty(t, orch.opMoves)
+	}
+}
+
+func TestPlan_Move(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb []} {test-ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	require.NoError(t, orch.rost.SetAdminState("test-bbb", ranje.AsCordoned))
+
+	plan, err := orch.Plan(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Error)
+	assert.Equal(t, []PlannedPlacement{{
+		Range:  1,
+		NodeID: "test-ccc",
+		Excluded: []roster.Exclusion{
+			{NodeID: "test-aaa", Reason: "already has range"},
+			{NodeID: "test-bbb", Reason: "cordoned"},
+		},
+	}}, plan.Placements)
+
+	// With R1, there's no room for another active placement, so the source is
+	// deactivated first. That's expected, so it's not below the minimum.
+	assert.Equal(t, "R1:test-ccc:PsPending>PsInactive, R1:test-aaa:PsActive>PsInactive, R1:test-ccc:PsInactive>PsActive, R1:test-aaa:PsInactive>PsDropped", stepsString(plan))
+	assert.Equal(t, 0, plan.MinActive)
+	assert.False(t, plan.BelowMinActive())
+
+	// Nothing actually changed.
+	tickUntilStable(t, orch, act)
+	assert.Equal(t, ksStr, orch.ks.LogString())
+	assert.Empty(t, OpsString(orch.ks))
+}
+
+func TestPlan_Move_R3(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd []}"
+	orch, _ := orchFactory(t, ksStr, rosStr, noStrictTransactions, r3)
+
+	plan, err := orch.Plan(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}, Src: "bbb", Nodes: []api.NodeID{"ddd"}})
+	require.NoError(t, err)
+
+	// With R3, the destination can be activated before the source is
+	// deactivated, so the range never has fewer than three active placements.
+	assert.Equal(t, "R1:ddd:PsPending>PsInactive, R1:ddd:PsInactive>PsActive, R1:bbb:PsActive>PsInactive, R1:bbb:PsInactive>PsDropped", stepsString(plan))
+	assert.Equal(t, 3, plan.MinActive)
+	assert.False(t, plan.BelowMinActive())
+	assert.Equal(t, ksStr, orch.ks.LogString())
+}
+
+func TestPlan_Split_R3(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive p1=bbb:PsActive p2=ccc:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb [1:NsActive]} {ccc [1:NsActive]} {ddd []} {eee []} {fff []} {ggg []} {hhh []} {iii []}"
+	orch, _ := orchFactory(t, ksStr, rosStr, noStrictTransactions, r3)
+
+	plan, err := orch.Plan(ranje.Op{Kind: ranje.OpKindSplit, Ranges: []api.RangeID{1}, Keys: []api.Key{"ccc"}})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Error)
+	require.Len(t, plan.Placements, 6)
+	assert.Equal(t, api.RangeID(2), plan.Placements[0].Range)
+	assert.Equal(t, api.RangeID(3), plan.Placements[1].Range)
+
+	// Splits always deactivate the parent before activating the children.
+	assert.Equal(t, 0, plan.MinActive)
+	assert.Equal(t, 3, plan.ReplMinActive)
+	assert.True(t, plan.BelowMinActive())
+	assert.Equal(t, ksStr, orch.ks.LogString())
+}
+
+func TestPlan_NoCandidate(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=test-aaa:PsActive}"
+	rosStr := "{test-aaa [1:NsActive]} {test-bbb []}"
+	orch, _ := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	require.NoError(t, orch.rost.SetAdminState("test-bbb", ranje.AsCordoned))
+
+	res, err := orch.bs.Move(context.TODO(), &pb.MoveRequest{Range: 1, DryRun: true})
+	require.NoError(t, err)
+	assert.Zero(t, res.Operation)
+	assert.Equal(t, "no candidates available (rID=1, c=Constraint{any})", res.Plan.Error)
+	require.Len(t, res.Plan.Placements, 1)
+	assert.Equal(t, "", res.Plan.Placements[0].Node)
+	assert.Equal(t, []*pb.Exclusion{
+		{Node: "test-aaa", Reason: "already has range"},
+		{Node: "test-bbb", Reason: "cordoned"},
+	}, res.Plan.Placements[0].Excluded)
+	assert.Empty(t, res.Plan.Steps)
+
+	// Invalid ops are rejected outright.
+	_, err = orch.bs.Split(context.TODO(), &pb.SplitRequest{Range: 1, DryRun: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPlacementPolicy(t *testing.T) {
+	ksStr := "{1 [-inf, ggg] RsActive p0=aaa:PsActive} {2 (ggg, +inf] RsActive p0=ccc:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc [2:NsActive]}"
+	orch, _ := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	planMove := func() *Plan {
+		plan, err := orch.Plan(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{2}})
+		require.NoError(t, err)
+		return plan
+	}
+
+	// The empty node is usually preferred.
+	assert.Equal(t, api.NodeID("bbb"), planMove().Placements[0].NodeID)
+
+	// Unless the range should be co-located with its neighbour.
+	res, err := orch.bs.SetPlacementPolicy(context.TODO(), &pb.SetPlacementPolicyRequest{
+		Range:  2,
+		Policy: &pb.PlacementPolicy{ColocateAdjacent: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, res.Ranges)
+	assert.Equal(t, api.NodeID("aaa"), planMove().Placements[0].NodeID)
+
+	// Required labels which no node has make the range unplaceable.
+	_, err = orch.bs.SetPlacementPolicy(context.TODO(), &pb.SetPlacementPolicyRequest{
+		Start:  []byte("ggg"),
+		Policy: &pb.PlacementPolicy{Require: "pool=a"},
+	})
+	require.NoError(t, err)
+	plan := planMove()
+	assert.Equal(t, "no candidates available (rID=2, c=Constraint{require(pool=a)})", plan.Error)
+	assert.Len(t, plan.Placements[0].Excluded, 3)
+
+	// Invalid policies are rejected.
+	_, err = orch.bs.SetPlacementPolicy(context.TODO(), &pb.SetPlacementPolicyRequest{
+		Range:  2,
+		Policy: &pb.PlacementPolicy{Prefer: "=a"},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAckObsolete(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+	orch.ks.SetRetention(keyspace.Retention{Ack: true})
+
+	splitOp(orch, 1)
+	tickUntilStable(t, orch, act)
+
+	rIDs, err := orch.ks.GC()
+	require.NoError(t, err)
+	assert.Empty(t, rIDs)
+
+	// Active ranges can't be acked.
+	_, err = orch.bs.AckObsolete(context.TODO(), &pb.AckObsoleteRequest{Ranges: []uint64{1, 2}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = orch.bs.AckObsolete(context.TODO(), &pb.AckObsoleteRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = orch.bs.AckObsolete(context.TODO(), &pb.AckObsoleteRequest{Ranges: []uint64{1}})
+	require.NoError(t, err)
+
+	rIDs, err = orch.ks.GC()
+	require.NoError(t, err)
+	assert.Equal(t, []api.RangeID{1}, rIDs)
+}
+
+// recordPolicy is a placement policy which records the proposals it scores.
+type recordPolicy struct {
+	roster.LeastRanges
+	proposals []roster.Proposal
+}
+
+func (rp *recordPolicy) Score(p roster.Proposal, nodes []*roster.Node) []float64 {
+	rp.proposals = append(rp.proposals, p)
+	return rp.LeastRanges.Score(p, nodes)
+}
+
+func TestPlacementPolicy_Proposal(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, _ := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	rp := &recordPolicy{}
+	orch.rost.Policy = rp
+	orch.rost.Nodes["aaa"].UpdateRangeInfo(&api.RangeInfo{
+		Meta:  mustGetRange(t, orch.ks, 1).Meta,
+		State: api.NsActive,
+		Info:  api.LoadInfo{Keys: 100},
+	})
+
+	_, err := orch.Plan(ranje.Op{Kind: ranje.OpKindMove, Ranges: []api.RangeID{1}})
+	require.NoError(t, err)
+	_, err = orch.Plan(ranje.Op{Kind: ranje.OpKindSplit, Ranges: []api.RangeID{1}, Keys: []api.Key{"ccc"}})
+	require.NoError(t, err)
+
+	// The children of the split are expected to share the load.
+	require.Len(t, rp.proposals, 3)
+	assert.Equal(t, ranje.OpKindMove, rp.proposals[0].Kind)
+	assert.Equal(t, 100, rp.proposals[0].Load.Keys)
+	assert.Equal(t, api.RangeID(1), rp.proposals[0].Range.Meta.Ident)
+	for _, p := range rp.proposals[1:] {
+		assert.Equal(t, ranje.OpKindSplit, p.Kind)
+		assert.Equal(t, 50, p.Load.Keys)
+		assert.Nil(t, p.Range)
+	}
+}
+
+func TestRun_Reactive(t *testing.T) {
+	ksStr := "{1 [-inf, +inf] RsActive p0=aaa:PsActive}"
+	rosStr := "{aaa [1:NsActive]} {bbb []} {ccc []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, r1)
+
+	// Wire them together like rangerd does, counting the ticks.
+	var ticks int32
+	orch.OnTick(func() {
+		atomic.AddInt32(&ticks, 1)
+		act.Wake()
+	})
+	act.OnCommand(func(api.Command, error) {
+		orch.Wake()
+	})
+
+	// The ticker never fires during the test, so everything after the first
+	// tick is driven by events.
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	go orch.Run(ticker)
+	go act.Run(ticker)
+
+	ch := make(chan error, 1)
+	orch.QueueSplit(OpSplit{Range: 1, Keys: []api.Key{"ccc"}, Err: ch})
+
+	select {
+	case err := <-ch:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for split")
+	}
+
+	_, unlock := orch.ks.Ranges()
+	assert.Equal(t, "{1 [-inf, +inf] RsObsolete} {2 [-inf, ccc] RsActive p0=bbb:PsActive} {3 (ccc, +inf] RsActive p0=ccc:PsActive}", orch.ks.LogString())
+	unlock()
+
+	// Once there's nothing left to do, nothing wakes the orchestrator.
+	n := atomic.LoadInt32(&ticks)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, n, atomic.LoadInt32(&ticks))
+}
+
+// replicationFixture returns an orchestrator with a single range, placed with
+// the given replication config on a roster with plenty of spare nodes.
+func replicationFixture(t *testing.T, rc ranje.ReplicationConfig) (*Orchestrator, *actuator.Actuator) {
+	ksStr := "{1 [-inf, +inf] RsActive}"
+	rosStr := "{aaa []} {bbb []} {ccc []} {ddd []} {eee []} {fff []} {ggg []} {hhh []} {iii []} {jjj []} {kkk []} {lll []}"
+	orch, act := orchFactory(t, ksStr, rosStr, noStrictTransactions, rc)
+
+	tickUntilStableWithinLimits(t, orch, act)
+	requireReplicated(t, orch)
+
+	return orch, act
+}
+
+// tickUntilStableWithinLimits is like tickUntilStable, but also checks after
+// every tick that no range has more placements or active placements than its
+// replication config allows.
+func tickUntilStableWithinLimits(t *testing.T, orch *Orchestrator, act *actuator.Actuator) {
+	t.Helper()
+
+	var ksPrev string // previous value of ks.LogString
+	var stable int    // ticks since keyspace changed
+
+	tickUntil(t, orch, act, func(ks, _ string) bool {
+		rs, unlock := orch.ks.Ranges()
+		defer unlock()
+
+		for _, r := range rs {
+			require.LessOrEqual(t, len(r.Placements), r.MaxPlacements(), "too many placements: %s", r.LogString())
+			require.LessOrEqual(t, r.NumPlacementsInState(api.PsActive), r.MaxActive(), "too many active placements: %s", r.LogString())
+		}
+
+		if ks != ksPrev {
+			ksPrev = ks
+			stable = 0
+			return false
+		}
+
+		stable += 1
+		return stable >= 3
+	})
+}
+
+// requireReplicated fails the test unless every active range has exactly the
+// target number of active placements, and enough inactive spares to make up
+// the minimum number of placements.
+func requireReplicated(t *testing.T, orch *Orchestrator) {
+	t.Helper()
+
+	rs, unlock := orch.ks.RangesInState(api.RsActive)
+	defer unlock()
+
+	for _, r := range rs {
+		require.Equal(t, r.TargetActive(), r.NumPlacementsInState(api.PsActive), "active placements: %s", r.LogString())
+		require.Equal(t, r.MinPlacements()-r.TargetActive(), r.NumPlacementsInState(api.PsInactive), "spare placements: %s", r.LogString())
+		require.Len(t, r.Placements, r.MinPlacements(), "placements: %s", r.LogString())
+
+		for _, p := range r.Placements {
+			require.False(t, p.Tainted, "tainted placement: %s", r.LogString())
+		}
+	}
+}
+
+func requireInState(t *testing.T, ks *keyspace.Keyspace, state api.RangeState, rIDs ...int) {
+	t.Helper()
+	for _, rID := range rIDs {
+		require.Equal(t, state, mustGetRange(t, ks, rID).State, "rID=%d", rID)
+	}
+}
+
+func requireNotOn(t *testing.T, r *ranje.Range, nID api.NodeID) {
+	t.Helper()
+	for _, p := range r.Placements {
+		require.NotEqual(t, nID, p.NodeID, "placement on %s: %s", nID, r.LogString())
+	}
+}
+
+// firstActive returns the node of the first active placement of the given
+// range, or fails the test if there isn't one.
+func firstActive(t *testing.T, r *ranje.Range) api.NodeID {
+	t.Helper()
+	for _, p := range r.Placements {
+		if p.StateCurrent == api.PsActive {
+			return p.NodeID
+		}
+	}
+
+	t.Fatalf("no active placement: %s", r.LogString())
+	return "" // unreachable
+}
+
+// BenchmarkTick measures a steady-state orchestrator and actuator tick, for
+// keyspaces with increasingly long histories. The cost should stay flat as the
+// history grows, since obsolete ranges are never ticked.
+func BenchmarkTick(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("history=%d", n), func(b *testing.B) {
+			// Range state transitions log a lot while building the history.
+			log.SetOutput(io.Discard)
+			defer log.SetOutput(os.Stderr)
+
+			ks := historyKeyspace(b, 10, n, "test-aaa")
+			ros := historyRoster(b, ks, "test-aaa")
+			ma := mock_actuator.New(strictTransactions)
+			act := actuator.New(ks, ros, 0, ma)
+			orch := New(ks, ros, grpc.NewServer())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				orch.Tick()
+				act.Tick()
+				act.Wait()
+			}
+			b.StopTimer()
+
+			// Every leaf range is already placed, so nothing should happen.
+			if u := ma.Unexpected(); len(u) > 0 {
+				b.Fatalf("unexpected commands: %v", u)
+			}
+
+			rs, unlock := ks.RangesInState(api.RsActive)
+			defer unlock()
+			for _, r := range rs {
+				if len(r.Placements) != 1 || r.Placements[0].StateCurrent != api.PsActive {
+					b.Fatalf("placement changed: %s", r.LogString())
+				}
+			}
+		})
+	}
+}
+
+// historyKeyspace returns a keyspace with the given number of leaf ranges, each
+// active on the given node, and at least the given number of ranges in total,
+// most of which are obsolete. The history is built by repeatedly splitting a
+// leaf range and joining it back.
+func historyKeyspace(tb testing.TB, leaves, history int, nID api.NodeID) *keyspace.Keyspace {
+	scratch, err := keyspace.New(&FakePersister{}, r1)
+	require.NoError(tb, err)
+
+	obsolete := func(rs ...*ranje.Range) {
+		for _, r := range rs {
+			require.NoError(tb, scratch.RangeToState(r, api.RsObsolete))
+		}
+	}
+
+	split := func(r *ranje.Range, k api.Key) (*ranje.Range, *ranje.Range) {
+		one, two, err := scratch.Split(r, k)
+		require.NoError(tb, err)
+		obsolete(r)
+		return one, two
+	}
+
+	for i := 1; i < leaves; i++ {
+		k := api.Key(fmt.Sprintf("%08d", i))
+		r, err := scratch.Find(k)
+		require.NoError(tb, err)
+		split(r, k)
+	}
+
+	ranges, unlock := scratch.Ranges()
+	unlock()
+
+	for i := 0; len(ranges) < history; i++ {
+		k := api.Key(fmt.Sprintf("%08d", 1+(i%(leaves-1))))
+		r, err := scratch.Find(k)
+		require.NoError(tb, err)
+
+		one, two := split(r, k+"m")
+		_, err = scratch.JoinTwo(one, two)
+		require.NoError(tb, err)
+		obsolete(one, two)
+
+		ranges, unlock = scratch.Ranges()
+		unlock()
+	}
+
+	// Reload the ranges into a fresh keyspace, with the leaves placed. This is
+	// easier than actually placing them via the orchestrator.
+	for _, r := range ranges {
+		if r.State == api.RsActive {
+			r.Placements = []*ranje.Placement{{
+				NodeID:       nID,
+				StateCurrent: api.PsActive,
+				StateDesired: api.PsActive,
+			}}
+		}
+	}
+
+	ks, err := keyspace.New(&FakePersister{ranges: ranges}, r1)
+	require.NoError(tb, err)
+
+	return ks
+}
+
+// historyRoster returns a roster containing a single node, which has every
+// active range in the given keyspace.
+func historyRoster(tb testing.TB, ks *keyspace.Keyspace, nID api.NodeID) *roster.Roster {
+	disc := mock_disc.NewDiscoverer()
+	disc.Add("node", api.Remote{
+		Ident: string(nID),
+		Host:  fmt.Sprintf("host-%s", nID),
+		Port:  1,
+	})
+
+	ros := roster.New(disc, nil, nil, nil)
+	ros.Discover()
+
+	rs, unlock := ks.RangesInState(api.RsActive)
+	defer unlock()
+
+	for _, r := range rs {
+		ros.Nodes[nID].UpdateRangeInfo(&api.RangeInfo{
+			Meta:  r.Meta,
+			State: api.NsActive,
+		})
+	}
+
+	return ros
+}
+
 // ----------------------------------------------------------- fixture factories
 
 type rangeStub struct {
@@ -2178,9 +3308,8 @@ func rosterFactory(t *testing.T, ctx context.Context, ks *keyspace.Keyspace, stu
 func orchFactory(t *testing.T, sKS, sRos string, strict bool, repl ranje.ReplicationConfig) (*Orchestrator, *actuator.Actuator) {
 	ks := keyspaceFactory(t, parseKeyspace(t, sKS), repl)
 	ros := rosterFactory(t, context.TODO(), ks, parseRoster(t, sRos))
-	srv := grpc.NewServer() // TODO: Allow this to be nil.
 	act := actuator.New(ks, ros, 0, mock_actuator.New(strict))
-	orch := New(ks, ros, srv)
+	orch := New(ks, ros, nil)
 
 	// Verify that the current state of the keyspace and roster is what was
 	// requested. (Require it, because if not, the test harness is broken.)
@@ -2262,12 +3391,18 @@ func moveOp(orch *Orchestrator, rID int, dest string) chan error {
 }
 
 func splitOp(orch *Orchestrator, rID int) chan error {
+	return splitOpKeys(orch, rID, "ccc")
+}
+
+// splitOpKeys injects a split operation at the given keys, which may be more
+// than one, to the given orchestrator.
+func splitOpKeys(orch *Orchestrator, rID int, keys ...api.Key) chan error {
 	ch := make(chan error, 1)
 	rID_ := api.RangeID(rID)
 
 	op := OpSplit{
 		Range: rID_,
-		Key:   "ccc",
+		Keys:  keys,
 		Err:   ch,
 	}
 
@@ -2278,17 +3413,40 @@ func splitOp(orch *Orchestrator, rID int) chan error {
 	return ch
 }
 
+// abortOp injects an abort of the operation which the given range is involved
+// in to the given orchestrator.
+func abortOp(orch *Orchestrator, rID int) chan error {
+	ch := make(chan error, 1)
+
+	orch.opAbortsMu.Lock()
+	orch.opAborts = append(orch.opAborts, OpAbort{
+		Range: api.RangeID(rID),
+		Err:   ch,
+	})
+	orch.opAbortsMu.Unlock()
+
+	return ch
+}
+
 // JoinOp injects a join operation to the given orchestrator, to kick off the
 // operation at the start of a test.
 func joinOp(orch *Orchestrator, r1ID, r2ID int, dest string) chan error {
+	return joinOpN(orch, []int{r1ID, r2ID}, dest)
+}
+
+// joinOpN is like joinOp, but joins any number of ranges.
+func joinOpN(orch *Orchestrator, rIDs []int, dest string) chan error {
 	ch := make(chan error, 1)
 
 	// TODO: Do this via the operator interface instead.
 
 	op := OpJoin{
-		Left:  api.RangeID(r1ID),
-		Right: api.RangeID(r2ID),
-		Err:   ch,
+		Ranges: make([]api.RangeID, len(rIDs)),
+		Err:    ch,
+	}
+
+	for i, rID := range rIDs {
+		op.Ranges[i] = api.RangeID(rID)
 	}
 
 	if dest != "" {
@@ -2336,6 +3494,15 @@ type Waiter interface {
 // like that.
 //
 // TODO: Get rid of this function, now that we have a mock actuator!
+// stepsString returns the steps of the given plan in a compact form.
+func stepsString(p *Plan) string {
+	steps := make([]string, len(p.Steps))
+	for i, s := range p.Steps {
+		steps[i] = fmt.Sprintf("R%d:%s:%s>%s", s.Range, s.NodeID, s.From, s.To)
+	}
+	return strings.Join(steps, ", ")
+}
+
 func tickWait(t *testing.T, orch *Orchestrator, act *actuator.Actuator, waiters ...Waiter) {
 	t.Helper()
 
@@ -2508,6 +3675,19 @@ func assertClosedError(t *testing.T, ch <-chan error, expected string) {
 	}
 }
 
+// waitOp waits (briefly) for the op with the given ID to finish, and returns it.
+func waitOp(t *testing.T, orch *Orchestrator, id ranje.OpID) ranje.Op {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	op, err := orch.WaitOp(ctx, id)
+	require.NoError(t, err)
+
+	return op
+}
+
 // assertClosed asserts that the given error channel is closed.
 func assertClosed(t *testing.T, ch <-chan error) {
 	select {
@@ -2564,3 +3744,53 @@ func (fp *FakePersister) GetRanges() ([]*ranje.Range, error) {
 func (fp *FakePersister) PutRanges([]*ranje.Range) error {
 	return nil
 }
+
+func (fp *FakePersister) DeleteRanges([]*ranje.Range) error {
+	return nil
+}
+
+// FakeOpPersister stores copies of ops in memory, so tests can check what would
+// have been persisted.
+type FakeOpPersister struct {
+	sync.Mutex
+	ops map[ranje.OpID]ranje.Op
+}
+
+func (fp *FakeOpPersister) GetOps() ([]*ranje.Op, error) {
+	fp.Lock()
+	defer fp.Unlock()
+
+	out := []*ranje.Op{}
+	for _, op := range fp.ops {
+		op := op
+		out = append(out, &op)
+	}
+
+	return out, nil
+}
+
+func (fp *FakeOpPersister) PutOps(ops []*ranje.Op) error {
+	fp.Lock()
+	defer fp.Unlock()
+
+	if fp.ops == nil {
+		fp.ops = map[ranje.OpID]ranje.Op{}
+	}
+
+	for _, op := range ops {
+		fp.ops[op.ID] = *op
+	}
+
+	return nil
+}
+
+func (fp *FakeOpPersister) DeleteOps(ops []*ranje.Op) error {
+	fp.Lock()
+	defer fp.Unlock()
+
+	for _, op := range ops {
+		delete(fp.ops, op.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package orchestrator
+
+import (
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/adammck/ranger/pkg/roster"
+)
+
+// Plan is what the orchestrator expects to happen if an op is submitted. See
+// Orchestrator.Plan.
+type Plan struct {
+
+	// The placements which the op would create, in the order that nodes were
+	// chosen for them, along with the nodes which were excluded from each. If
+	// no node could be chosen for one, it's the last, and has no NodeID.
+	Placements []PlannedPlacement
+
+	// The expected changes to the state of each placement involved, in the
+	// order that they're expected to happen.
+	Steps []PlanStep
+
+	// The fewest active placements which any key in the op is expected to have
+	// while it's in progress, and the MinActive of the ranges involved.
+	MinActive     int
+	ReplMinActive int
+
+	// Why the op would fail, if it would.
+	Error string
+}
+
+// BelowMinActive returns whether the number of active placements is expected
+// to dip below the minimum while the op is in progress.
+func (p *Plan) BelowMinActive() bool {
+	return p.MinActive < p.ReplMinActive
+}
+
+type PlannedPlacement struct {
+	Range    api.RangeID // zero if the range wasn't created before the error
+	NodeID   api.NodeID  // zero if no node could be chosen
+	Excluded []roster.Exclusion
+}
+
+type PlanStep struct {
+	Range  api.RangeID
+	NodeID api.NodeID
+	From   api.PlacementState
+	To     api.PlacementState
+}
+
+func (p *Plan) step(r *ranje.Range, pl *ranje.Placement, from, to api.PlacementState) {
+	p.Steps = append(p.Steps, PlanStep{
+		Range:  r.Meta.Ident,
+		NodeID: pl.NodeID,
+		From:   from,
+		To:     to,
+	})
+}
+
+// Plan returns what would happen if the given op was submitted, without
+// changing anything. The op is initiated just like it would be by the next
+// Tick, except against a copy of the keyspace, recording which node the roster
+// chooses (and excludes) for each new placement. The order in which placements
+// are then expected to change state is derived from the replication configs of
+// the ranges, which doesn't account for actions failing.
+//
+// If the op would fail to initiate, the plan says why, rather than returning an
+// error. Errors are only returned for ops which could never be valid.
+func (b *Orchestrator) Plan(op ranje.Op) (*Plan, error) {
+	err := validateOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	ks, err := b.ks.Copy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing else can see the copy, but the init funcs expect the keyspace
+	// lock to be held, like it is during Tick.
+	_, unlock := ks.Ranges()
+	defer unlock()
+
+	sim := New(ks, b.rost, nil)
+	sim.plan = &Plan{}
+
+	var dest api.NodeID
+	if len(op.Nodes) > 0 {
+		dest = op.Nodes[0]
+	}
+
+	switch op.Kind {
+	case ranje.OpKindMove:
+		err = planMove(sim, op.Ranges[0], OpMove{
+			Range: op.Ranges[0],
+			Src:   op.Src,
+			Dest:  dest,
+		})
+
+	case ranje.OpKindSplit:
+		err = planSplit(sim, op.Ranges[0], OpSplit{
+			Range: op.Ranges[0],
+			Keys:  op.Keys,
+			Dests: op.Nodes,
+		})
+
+	case ranje.OpKindJoin:
+		err = planJoin(sim, OpJoin{
+			Ranges: op.Ranges,
+			Dest:   dest,
+		})
+	}
+
+	if err != nil {
+		sim.plan.Error = err.Error()
+	}
+
+	return sim.plan, nil
+}
+
+// candidate returns the node to create the proposed placement on. When
+// planning, the choice (or the lack of one) is recorded, along with the nodes
+// excluded.
+func (b *Orchestrator) candidate(p roster.Proposal) (api.NodeID, error) {
+	nID, ex, err := b.rost.Choose(p)
+	if b.plan == nil {
+		return nID, err
+	}
+
+	pp := PlannedPlacement{
+		NodeID:   nID,
+		Excluded: ex,
+	}
+
+	if p.Range != nil {
+		pp.Range = p.Range.Meta.Ident
+	}
+
+	b.plan.Placements = append(b.plan.Placements, pp)
+
+	return nID, err
+}
+
+func planMove(sim *Orchestrator, rID api.RangeID, opMove OpMove) error {
+	r, err := sim.ks.GetRange(rID)
+	if err != nil {
+		return err
+	}
+
+	tainted := map[*ranje.Placement]bool{}
+	for _, p := range r.Placements {
+		tainted[p] = p.Tainted
+	}
+
+	err = sim.doMove(r, opMove)
+	if err != nil {
+		return err
+	}
+
+	// The new placement is appended, and the source is the one which became
+	// tainted.
+	dest := r.Placements[len(r.Placements)-1]
+	var src *ranje.Placement
+	for _, p := range r.Placements {
+		if p.Tainted && !tainted[p] {
+			src = p
+		}
+	}
+
+	plan := sim.plan
+	active := r.NumPlacementsInState(api.PsActive)
+	plan.MinActive = active
+	plan.ReplMinActive = r.MinActive()
+	plan.step(r, dest, api.PsPending, api.PsInactive)
+
+	// Moving a spare just replaces it with another.
+	if src.StateCurrent != api.PsActive {
+		plan.step(r, src, src.StateCurrent, api.PsDropped)
+		return nil
+	}
+
+	// If there's room for another active placement, the destination activates
+	// first. Otherwise the source must deactivate first. See MayActivate.
+	if active < r.MaxActive() {
+		plan.step(r, dest, api.PsInactive, api.PsActive)
+		plan.step(r, src, api.PsActive, api.PsInactive)
+	} else {
+		plan.step(r, src, api.PsActive, api.PsInactive)
+		plan.step(r, dest, api.PsInactive, api.PsActive)
+		plan.MinActive = active - 1
+	}
+
+	plan.step(r, src, api.PsInactive, api.PsDropped)
+	return nil
+}
+
+func planSplit(sim *Orchestrator, rID api.RangeID, opSplit OpSplit) error {
+	r, err := sim.ks.GetRange(rID)
+	if err != nil {
+		return err
+	}
+
+	err = initSplitInner(sim, r, opSplit)
+	if err != nil {
+		return err
+	}
+
+	children, err := rangesByID(sim, r.Children)
+	if err != nil {
+		return err
+	}
+
+	// Nodes are chosen for placement i of every child before placement i+1 of
+	// any. See initSplitInner.
+	for i := range sim.plan.Placements {
+		sim.plan.Placements[i].Range = children[i%len(children)].Meta.Ident
+	}
+
+	planReplace(sim.plan, []*ranje.Range{r}, children)
+	return nil
+}
+
+func planJoin(sim *Orchestrator, opJoin OpJoin) error {
+	r, err := initJoinInner(sim, opJoin)
+	if err != nil {
+		return err
+	}
+
+	parents, err := rangesByID(sim, opJoin.Ranges)
+	if err != nil {
+		return err
+	}
+
+	children, err := rangesByID(sim, r.Children)
+	if err != nil {
+		return err
+	}
+
+	for i := range sim.plan.Placements {
+		sim.plan.Placements[i].Range = children[0].Meta.Ident
+	}
+
+	planReplace(sim.plan, parents, children)
+	return nil
+}
+
+// planReplace adds the steps to replace the placements of the given parent
+// ranges with those of the given children, i.e. to complete a split or join.
+func planReplace(plan *Plan, parents, children []*ranje.Range) {
+	for _, r := range children {
+		for _, p := range r.Placements {
+			plan.step(r, p, api.PsPending, api.PsInactive)
+		}
+	}
+
+	// Every placement of every parent is deactivated before any child is
+	// activated, so that no key is ever active in two ranges at once.
+	for _, r := range parents {
+		for _, p := range r.Placements {
+			if p.StateCurrent == api.PsActive {
+				plan.step(r, p, api.PsActive, api.PsInactive)
+			}
+		}
+	}
+
+	for _, r := range children {
+		for _, p := range r.Placements {
+			plan.step(r, p, api.PsInactive, api.PsActive)
+		}
+	}
+
+	for _, r := range parents {
+		for _, p := range r.Placements {
+			from := p.StateCurrent
+			if from == api.PsActive {
+				from = api.PsInactive
+			}
+			plan.step(r, p, from, api.PsDropped)
+		}
+	}
+
+	// So there's a moment when none of the keys are active anywhere.
+	plan.MinActive = 0
+	plan.ReplMinActive = parents[0].MinActive()
+}
+
+func rangesByID(b *Orchestrator, rIDs []api.RangeID) ([]*ranje.Range, error) {
+	out := make([]*ranje.Range, len(rIDs))
+	for i, rID := range rIDs {
+		r, err := b.ks.GetRange(rID)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = r
+	}
+
+	return out, nil
+}
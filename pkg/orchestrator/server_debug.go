@@ -2,8 +2,11 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/adammck/ranger/pkg/api"
 	"github.com/adammck/ranger/pkg/keyspace"
 	"github.com/adammck/ranger/pkg/proto/conv"
 	pb "github.com/adammck/ranger/pkg/proto/gen"
@@ -34,6 +37,12 @@ func rangeResponse(r *ranje.Range, rost *roster.Roster) *pb.RangeResponse {
 		State:    conv.RangeStateToProto(r.State),
 		Parents:  parents,
 		Children: children,
+
+		Replication:         conv.ReplicationConfigToProto(r.ReplicationConfig()),
+		ReplicationOverride: r.Replication != nil,
+		PlacementPolicy:     conv.PlacementPolicyToProto(r.Policy),
+
+		History: historyToProto(r.History),
 	}
 
 	for _, p := range r.Placements {
@@ -42,6 +51,11 @@ func rangeResponse(r *ranje.Range, rost *roster.Roster) *pb.RangeResponse {
 				Node:  conv.NodeIDToProto(p.NodeID),
 				State: conv.PlacementStateToProto(p.StateCurrent),
 			},
+			Tainted: p.Tainted,
+		}
+
+		for _, a := range p.Failures() {
+			plc.Failures = append(plc.Failures, conv.ActionToProto(a))
 		}
 
 		// If RangeInfo is available include it.
@@ -59,12 +73,48 @@ func rangeResponse(r *ranje.Range, rost *roster.Roster) *pb.RangeResponse {
 	return res
 }
 
-func nodeResponse(ks *keyspace.Keyspace, n *roster.Node) *pb.NodeResponse {
+func historyToProto(history []ranje.StateChange) []*pb.RangeStateChange {
+	out := make([]*pb.RangeStateChange, len(history))
+	for i, sc := range history {
+		out[i] = &pb.RangeStateChange{
+			State: conv.RangeStateToProto(sc.State),
+			Time:  sc.When.UnixNano(),
+		}
+	}
+
+	return out
+}
+
+func rangeHistoryToProto(rh keyspace.RangeHistory) *pb.RangeHistory {
+	parents := make([]uint64, len(rh.Parents))
+	for i, rID := range rh.Parents {
+		parents[i] = conv.RangeIDToProto(rID)
+	}
+
+	children := make([]uint64, len(rh.Children))
+	for i, rID := range rh.Children {
+		children[i] = conv.RangeIDToProto(rID)
+	}
+
+	return &pb.RangeHistory{
+		Meta:     conv.MetaToProto(rh.Meta),
+		State:    conv.RangeStateToProto(rh.State),
+		Parents:  parents,
+		Children: children,
+		History:  historyToProto(rh.History),
+	}
+}
+
+func nodeResponse(ks *keyspace.Keyspace, rost *roster.Roster, n *roster.Node) *pb.NodeResponse {
 	res := &pb.NodeResponse{
 		Node: &pb.NodeMeta{
-			Ident:     conv.NodeIDToProto(n.Ident()),
-			Address:   n.Addr(),
-			WantDrain: n.WantDrain(),
+			Ident:      conv.NodeIDToProto(n.Ident()),
+			Address:    n.Addr(),
+			WantDrain:  n.WantDrain(),
+			AdminState: conv.AdminStateToProto(rost.AdminState(n.Ident())),
+			Labels:     n.Remote.Labels,
+			Load:       conv.LoadInfoToProto(n.Load()),
+			Capacity:   conv.LoadInfoToProto(n.Capacity()),
 		},
 	}
 
@@ -84,6 +134,10 @@ func (srv *debugServer) RangesList(ctx context.Context, req *pb.RangesListReques
 	ranges, unlocker := srv.orch.ks.Ranges()
 	defer unlocker()
 
+	// Ranges don't change while the keyspace is locked, so this is the
+	// revision that they reflect.
+	res.Revision = srv.orch.ks.Revision()
+
 	for _, r := range ranges {
 		r.Mutex.Lock()
 		res.Ranges = append(res.Ranges, rangeResponse(r, srv.orch.rost))
@@ -126,7 +180,7 @@ func (srv *debugServer) Node(ctx context.Context, req *pb.NodeRequest) (*pb.Node
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	res := nodeResponse(srv.orch.ks, node)
+	res := nodeResponse(srv.orch.ks, srv.orch.rost, node)
 
 	return res, nil
 }
@@ -139,8 +193,91 @@ func (srv *debugServer) NodesList(ctx context.Context, req *pb.NodesListRequest)
 	res := &pb.NodesListResponse{}
 
 	for _, n := range rost.Nodes {
-		res.Nodes = append(res.Nodes, nodeResponse(srv.orch.ks, n))
+		res.Nodes = append(res.Nodes, nodeResponse(srv.orch.ks, rost, n))
 	}
 
 	return res, nil
 }
+
+func (srv *debugServer) Lineage(ctx context.Context, req *pb.LineageRequest) (*pb.LineageResponse, error) {
+	if req.Range == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing: range")
+	}
+
+	rID, err := conv.RangeIDFromProto(req.Range)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("IdentFromProto failed: %v", err))
+	}
+
+	lineage, err := srv.orch.ks.Lineage(rID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := &pb.LineageResponse{}
+	for _, rh := range lineage {
+		res.Ranges = append(res.Ranges, rangeHistoryToProto(rh))
+	}
+
+	return res, nil
+}
+
+func (srv *debugServer) RangeAt(ctx context.Context, req *pb.RangeAtRequest) (*pb.RangeAtResponse, error) {
+	t := time.Now()
+	if req.Time != 0 {
+		t = time.Unix(0, req.Time)
+	}
+
+	rh, err := srv.orch.ks.RangeAt(api.Key(req.Key), t)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &pb.RangeAtResponse{
+		Range: rangeHistoryToProto(rh),
+	}, nil
+}
+
+func (srv *debugServer) Watch(req *pb.WatchRequest, stream pb.Debug_WatchServer) error {
+	ks := srv.orch.ks
+
+	rev := req.Revision
+	if rev == 0 {
+		rev = ks.Revision()
+	}
+
+	events, cancel, err := ks.Watch(rev)
+	if err != nil {
+		if errors.Is(err, keyspace.ErrCompacted) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+
+		case e, ok := <-events:
+			if !ok {
+				// The client fell too far behind, so the keyspace stopped
+				// buffering for it. It can resume from the last revision.
+				return status.Error(codes.ResourceExhausted, "watcher fell behind")
+			}
+
+			err := stream.Send(&pb.WatchResponse{
+				Revision:       e.Rev,
+				Type:           conv.EventTypeToProto(e.Type),
+				Range:          conv.RangeIDToProto(e.Range),
+				RangeState:     conv.RangeStateToProto(e.RangeState),
+				Node:           conv.NodeIDToProto(e.Node),
+				PlacementState: conv.PlacementStateToProto(e.PlacementState),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -2,13 +2,17 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/adammck/ranger/pkg/api"
 	"github.com/adammck/ranger/pkg/proto/conv"
 	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"github.com/adammck/ranger/pkg/ranje"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -30,33 +34,32 @@ func (bs *orchestratorServer) Move(ctx context.Context, req *pb.MoveRequest) (*p
 		return nil, err
 	}
 
-	op := OpMove{
-		Range: rID,
-		Dest:  nID, // Might be ZeroNodeID
-		Err:   make(chan error),
+	op := ranje.Op{
+		Kind:   ranje.OpKindMove,
+		Ranges: []api.RangeID{rID},
 	}
 
-	bs.orch.opMovesMu.Lock()
-	bs.orch.opMoves = append(bs.orch.opMoves, op)
-	bs.orch.opMovesMu.Unlock()
+	if nID != api.ZeroNodeID {
+		op.Nodes = []api.NodeID{nID}
+	}
 
-	errs := []string{}
-	for {
-		err, ok := <-op.Err
-		if !ok { // closed
-			break
+	if req.DryRun {
+		p, err := plan(bs, op)
+		if err != nil {
+			return nil, err
 		}
-		errs = append(errs, err.Error())
+
+		return &pb.MoveResponse{Plan: p}, nil
 	}
 
-	// There's probably only one error. But who knows.
-	if len(errs) > 0 {
-		return nil, status.Error(
-			codes.Aborted,
-			fmt.Sprintf("move operation failed: %v", strings.Join(errs, "; ")))
+	op, err = submit(bs, op)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.MoveResponse{}, nil
+	return &pb.MoveResponse{
+		Operation: conv.OpIDToProto(op.ID),
+	}, nil
 }
 
 func (bs *orchestratorServer) Split(ctx context.Context, req *pb.SplitRequest) (*pb.SplitResponse, error) {
@@ -65,33 +68,160 @@ func (bs *orchestratorServer) Split(ctx context.Context, req *pb.SplitRequest) (
 		return nil, err
 	}
 
-	boundary := api.Key(req.Boundary)
-	if boundary == "" {
+	// The single boundary is the common case, but several can be given to split
+	// the range into more than two parts in one operation.
+	if len(req.Boundary) > 0 && len(req.Boundaries) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "boundary and boundaries can't both be given")
+	}
+
+	keys := make([]api.Key, 0, len(req.Boundaries)+1)
+	if len(req.Boundary) > 0 {
+		keys = append(keys, api.Key(req.Boundary))
+	}
+	for i, b := range req.Boundaries {
+		if len(b) == 0 {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("missing: boundaries[%d]", i))
+		}
+		keys = append(keys, api.Key(b))
+	}
+
+	if len(keys) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "missing: boundary")
 	}
 
-	// NodeID (on both sides) is optional for this endpoint.
+	if (req.NodeLeft != "" || req.NodeRight != "") && len(req.Nodes) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "node_left/node_right and nodes can't both be given")
+	}
+
+	nodes := req.Nodes
+	if len(nodes) == 0 {
+		nodes = []string{req.NodeLeft, req.NodeRight}
+	}
+
+	if len(nodes) > len(keys)+1 {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("too many nodes: got %d, want at most %d", len(nodes), len(keys)+1))
+	}
+
+	// NodeIDs are optional for this endpoint.
 	// TODO: Verify that the NodeIDs are valid if given.
-	nID1, err := conv.NodeIDFromProto(req.NodeLeft)
-	if err != nil && err != conv.ErrMissingNodeID {
+	dests := make([]api.NodeID, len(nodes))
+	for i := range nodes {
+		dests[i], err = conv.NodeIDFromProto(nodes[i])
+		if err != nil && err != conv.ErrMissingNodeID {
+			return nil, err
+		}
+	}
+
+	op := ranje.Op{
+		Kind:   ranje.OpKindSplit,
+		Ranges: []api.RangeID{rID},
+		Keys:   keys,
+		Nodes:  dests,
+	}
+
+	if req.DryRun {
+		p, err := plan(bs, op)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.SplitResponse{Plan: p}, nil
+	}
+
+	op, err = submit(bs, op)
+	if err != nil {
 		return nil, err
 	}
-	nID2, err := conv.NodeIDFromProto(req.NodeLeft)
+
+	return &pb.SplitResponse{
+		Operation: conv.OpIDToProto(op.ID),
+	}, nil
+}
+
+func (bs *orchestratorServer) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinResponse, error) {
+	var err error
+	var rIDs []api.RangeID
+
+	// Either the left and right ranges, or a list of (two or more) ranges can be
+	// given, but not both.
+	if len(req.Ranges) > 0 {
+		if req.RangeLeft != 0 || req.RangeRight != 0 {
+			return nil, status.Error(codes.InvalidArgument, "range_left/range_right and ranges can't both be given")
+		}
+
+		if len(req.Ranges) < 2 {
+			return nil, status.Error(codes.InvalidArgument, "ranges must contain at least two ranges")
+		}
+
+		rIDs = make([]api.RangeID, len(req.Ranges))
+		for i, pbid := range req.Ranges {
+			rIDs[i], err = getRange(bs, pbid, fmt.Sprintf("ranges[%d]", i))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	} else {
+		left, err := getRange(bs, req.RangeLeft, "range_left")
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := getRange(bs, req.RangeRight, "range_right")
+		if err != nil {
+			return nil, err
+		}
+
+		rIDs = []api.RangeID{left, right}
+	}
+
+	// NodeID is optional for this endpoint.
+	// TODO: Verify that the NodeID is valid if given.
+	nID, err := conv.NodeIDFromProto(req.Node)
 	if err != nil && err != conv.ErrMissingNodeID {
 		return nil, err
 	}
 
-	op := OpSplit{
+	op := ranje.Op{
+		Kind:   ranje.OpKindJoin,
+		Ranges: rIDs,
+	}
+
+	if nID != api.ZeroNodeID {
+		op.Nodes = []api.NodeID{nID}
+	}
+
+	if req.DryRun {
+		p, err := plan(bs, op)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.JoinResponse{Plan: p}, nil
+	}
+
+	op, err = submit(bs, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.JoinResponse{
+		Operation: conv.OpIDToProto(op.ID),
+	}, nil
+}
+
+func (bs *orchestratorServer) Abort(ctx context.Context, req *pb.AbortRequest) (*pb.AbortResponse, error) {
+	rID, err := getRange(bs, req.Range, "range")
+	if err != nil {
+		return nil, err
+	}
+
+	op := OpAbort{
 		Range: rID,
-		Key:   boundary,
-		Left:  nID1,
-		Right: nID2,
 		Err:   make(chan error),
 	}
 
-	bs.orch.opSplitsMu.Lock()
-	bs.orch.opSplits[rID] = op
-	bs.orch.opSplitsMu.Unlock()
+	bs.orch.QueueAbort(op)
 
 	errs := []string{}
 	for {
@@ -104,58 +234,443 @@ func (bs *orchestratorServer) Split(ctx context.Context, req *pb.SplitRequest) (
 
 	if len(errs) > 0 {
 		return nil, status.Error(
-			codes.Aborted,
-			fmt.Sprintf("split operation failed: %v", strings.Join(errs, "; ")))
+			codes.FailedPrecondition,
+			fmt.Sprintf("abort operation failed: %v", strings.Join(errs, "; ")))
 	}
 
-	return &pb.SplitResponse{}, nil
+	return &pb.AbortResponse{}, nil
 }
 
-func (bs *orchestratorServer) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinResponse, error) {
-	left, err := getRange(bs, req.RangeLeft, "range_left")
+func (bs *orchestratorServer) SetReplication(ctx context.Context, req *pb.SetReplicationRequest) (*pb.SetReplicationResponse, error) {
+	rc := conv.ReplicationConfigFromProto(req.Config)
+
+	// Unlike the other operations, this one doesn't need to wait for a tick.
+	// The keyspace is changed right away, and the orchestrator converges on it
+	// as usual.
+
+	var rIDs []api.RangeID
+	if req.Range != 0 {
+		if len(req.Start) > 0 || len(req.End) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "range and start/end can't both be given")
+		}
+
+		rID, err := getRange(bs, req.Range, "range")
+		if err != nil {
+			return nil, err
+		}
+
+		err = bs.orch.ks.SetReplication(rID, rc)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		rIDs = []api.RangeID{rID}
+
+	} else {
+		var err error
+		rIDs, err = bs.orch.ks.SetReplicationSpan(api.Key(req.Start), api.Key(req.End), rc)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	res := &pb.SetReplicationResponse{
+		Ranges: make([]uint64, len(rIDs)),
+	}
+
+	for i := range rIDs {
+		res.Ranges[i] = conv.RangeIDToProto(rIDs[i])
+	}
+
+	return res, nil
+}
+
+func (bs *orchestratorServer) SetPlacementPolicy(ctx context.Context, req *pb.SetPlacementPolicyRequest) (*pb.SetPlacementPolicyResponse, error) {
+	pp := conv.PlacementPolicyFromProto(req.Policy)
+
+	// Like SetReplication, this changes the keyspace right away. Only new
+	// placements are affected, so nothing else happens until one is needed.
+
+	var rIDs []api.RangeID
+	if req.Range != 0 {
+		if len(req.Start) > 0 || len(req.End) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "range and start/end can't both be given")
+		}
+
+		rID, err := getRange(bs, req.Range, "range")
+		if err != nil {
+			return nil, err
+		}
+
+		err = bs.orch.ks.SetPlacementPolicy(rID, pp)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		rIDs = []api.RangeID{rID}
+
+	} else {
+		var err error
+		rIDs, err = bs.orch.ks.SetPlacementPolicySpan(api.Key(req.Start), api.Key(req.End), pp)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	res := &pb.SetPlacementPolicyResponse{
+		Ranges: make([]uint64, len(rIDs)),
+	}
+
+	for i := range rIDs {
+		res.Ranges[i] = conv.RangeIDToProto(rIDs[i])
+	}
+
+	return res, nil
+}
+
+// maxAckRanges is the most ranges which can be acked by one AckObsolete, so
+// that they can be persisted in a single transaction.
+const maxAckRanges = 48
+
+func (bs *orchestratorServer) AckObsolete(ctx context.Context, req *pb.AckObsoleteRequest) (*pb.AckObsoleteResponse, error) {
+	if len(req.Ranges) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing: ranges")
+	}
+
+	if len(req.Ranges) > maxAckRanges {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("too many ranges: %d > %d", len(req.Ranges), maxAckRanges))
+	}
+
+	rIDs := make([]api.RangeID, len(req.Ranges))
+	for i := range req.Ranges {
+		rID, err := getRange(bs, req.Ranges[i], "ranges")
+		if err != nil {
+			return nil, err
+		}
+
+		rIDs[i] = rID
+	}
+
+	err := bs.orch.ks.AckObsolete(rIDs...)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &pb.AckObsoleteResponse{}, nil
+}
+
+func (bs *orchestratorServer) GetOperation(ctx context.Context, req *pb.GetOperationRequest) (*pb.GetOperationResponse, error) {
+	id, err := getOp(req.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bs.orch.Op(id)
+	if err != nil {
+		return nil, opError(err)
+	}
+
+	return &pb.GetOperationResponse{
+		Operation: conv.OpToProto(op),
+	}, nil
+}
+
+func (bs *orchestratorServer) ListOperations(ctx context.Context, req *pb.ListOperationsRequest) (*pb.ListOperationsResponse, error) {
+	ops := bs.orch.Ops()
+
+	res := &pb.ListOperationsResponse{
+		Operations: make([]*pb.Operation, len(ops)),
+	}
+
+	for i := range ops {
+		res.Operations[i] = conv.OpToProto(ops[i])
+	}
+
+	return res, nil
+}
+
+func (bs *orchestratorServer) WaitOperation(ctx context.Context, req *pb.WaitOperationRequest) (*pb.WaitOperationResponse, error) {
+	id, err := getOp(req.Operation)
 	if err != nil {
 		return nil, err
 	}
 
-	right, err := getRange(bs, req.RangeRight, "range_right")
+	op, err := bs.orch.WaitOp(ctx, id)
+	if err != nil {
+		return nil, opError(err)
+	}
+
+	return &pb.WaitOperationResponse{
+		Operation: conv.OpToProto(op),
+	}, nil
+}
+
+func (bs *orchestratorServer) CancelOperation(ctx context.Context, req *pb.CancelOperationRequest) (*pb.CancelOperationResponse, error) {
+	id, err := getOp(req.Operation)
 	if err != nil {
 		return nil, err
 	}
 
-	// NodeID is optional for this endpoint.
-	// TODO: Verify that the NodeID is valid if given.
-	nID, err := conv.NodeIDFromProto(req.Node)
-	if err != nil && err != conv.ErrMissingNodeID {
+	op, err := bs.orch.CancelOp(id)
+	if err != nil {
+		return nil, opError(err)
+	}
+
+	return &pb.CancelOperationResponse{
+		Operation: conv.OpToProto(op),
+	}, nil
+}
+
+func (bs *orchestratorServer) Untaint(ctx context.Context, req *pb.UntaintRequest) (*pb.UntaintResponse, error) {
+	rID, nID, err := getPlacement(bs, req.Range, req.Node)
+	if err != nil {
 		return nil, err
 	}
 
-	op := OpJoin{
-		Left:  left,
-		Right: right,
-		Dest:  nID,
-		Err:   make(chan error),
+	err = bs.orch.ks.Untaint(rID, nID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
 	}
 
-	bs.orch.opJoinsMu.Lock()
-	bs.orch.opJoins = append(bs.orch.opJoins, op)
-	bs.orch.opJoinsMu.Unlock()
+	audit(ctx, "untainted placement (rID=%s, nID=%s)", rID, nID)
 
-	errs := []string{}
-	for {
-		err, ok := <-op.Err
-		if !ok { // closed
-			break
+	return &pb.UntaintResponse{}, nil
+}
+
+func (bs *orchestratorServer) ClearFailures(ctx context.Context, req *pb.ClearFailuresRequest) (*pb.ClearFailuresResponse, error) {
+	rID, nID, err := getPlacement(bs, req.Range, req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]api.Action, len(req.Actions))
+	for i := range req.Actions {
+		actions[i], err = conv.ActionFromProto(req.Actions[i])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid actions[%d]: %v", i, err))
 		}
-		errs = append(errs, err.Error())
 	}
 
-	if len(errs) > 0 {
-		return nil, status.Error(
-			codes.Aborted,
-			fmt.Sprintf("join operation failed: %v", strings.Join(errs, "; ")))
+	cleared, err := bs.orch.ks.ClearFailures(rID, nID, actions)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// The roster also remembers failed placements, to avoid placing the range
+	// on the same node again for a while. Forget those too, so that the retry
+	// isn't pointless.
+	if n, err := bs.orch.rost.NodeByIdent(nID); err == nil {
+		n.ClearPlacementFailures(rID)
+	}
+
+	audit(ctx, "cleared placement failures (rID=%s, nID=%s, actions=%v)", rID, nID, cleared)
+
+	res := &pb.ClearFailuresResponse{
+		Actions: make([]string, len(cleared)),
+	}
+
+	for i := range cleared {
+		res.Actions[i] = conv.ActionToProto(cleared[i])
+	}
+
+	return res, nil
+}
+
+func (bs *orchestratorServer) DropPlacement(ctx context.Context, req *pb.DropPlacementRequest) (*pb.DropPlacementResponse, error) {
+	rID, nID, err := getPlacement(bs, req.Range, req.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bs.orch.ks.DropPlacement(rID, nID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	audit(ctx, "dropped placement (rID=%s, nID=%s)", rID, nID)
+
+	return &pb.DropPlacementResponse{}, nil
+}
+
+func (bs *orchestratorServer) Cordon(ctx context.Context, req *pb.CordonRequest) (*pb.CordonResponse, error) {
+	nID, err := setAdminState(bs, req.Node, ranje.AsCordoned)
+	if err != nil {
+		return nil, err
+	}
+
+	audit(ctx, "cordoned node (nID=%s)", nID)
+
+	return &pb.CordonResponse{}, nil
+}
+
+func (bs *orchestratorServer) Drain(ctx context.Context, req *pb.DrainRequest) (*pb.DrainResponse, error) {
+	nID, err := setAdminState(bs, req.Node, ranje.AsDraining)
+	if err != nil {
+		return nil, err
+	}
+
+	audit(ctx, "draining node (nID=%s)", nID)
+
+	return &pb.DrainResponse{}, nil
+}
+
+func (bs *orchestratorServer) Uncordon(ctx context.Context, req *pb.UncordonRequest) (*pb.UncordonResponse, error) {
+	nID, err := setAdminState(bs, req.Node, ranje.AsNormal)
+	if err != nil {
+		return nil, err
+	}
+
+	audit(ctx, "uncordoned node (nID=%s)", nID)
+
+	return &pb.UncordonResponse{}, nil
+}
+
+func (bs *orchestratorServer) DrainStatus(ctx context.Context, req *pb.DrainStatusRequest) (*pb.DrainStatusResponse, error) {
+	nID, err := conv.NodeIDFromProto(req.Node)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "missing: node")
+	}
+
+	return &pb.DrainStatusResponse{
+		State:      conv.AdminStateToProto(bs.orch.rost.AdminState(nID)),
+		Placements: uint64(len(bs.orch.ks.PlacementsByNodeID(nID))),
+	}, nil
+}
+
+// setAdminState changes the admin state of the given node, and returns its ID
+// or an error suitable for a gRPC response.
+func setAdminState(bs *orchestratorServer, nodePB string, state ranje.AdminState) (api.NodeID, error) {
+	nID, err := conv.NodeIDFromProto(nodePB)
+	if err != nil {
+		return api.ZeroNodeID, status.Error(codes.InvalidArgument, "missing: node")
+	}
+
+	err = bs.orch.rost.SetAdminState(nID, state)
+	if err != nil {
+		return api.ZeroNodeID, status.Error(codes.Unavailable, fmt.Sprintf("error persisting node state: %v", err))
+	}
+
+	// Start draining right away. Admin state isn't part of the keyspace, so
+	// changing it doesn't wake the orchestrator by itself.
+	bs.orch.Wake()
+
+	return nID, nil
+}
+
+// audit logs an action taken by an operator, along with the address which the
+// request came from, since these bypass the usual orchestration.
+func audit(ctx context.Context, format string, args ...interface{}) {
+	from := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		from = p.Addr.String()
+	}
+
+	log.Printf("audit: %s (from=%s)", fmt.Sprintf(format, args...), from)
+}
+
+// submit submits the given op to the orchestrator, and returns it with its ID
+// filled in, or an error suitable for a gRPC response.
+func submit(bs *orchestratorServer, op ranje.Op) (ranje.Op, error) {
+	op, err := bs.orch.Submit(op)
+	if errors.Is(err, ErrRangeBusy) {
+		return op, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return op, status.Error(codes.Unavailable, fmt.Sprintf("error submitting %s: %v", op.Kind, err))
+	}
+
+	return op, nil
+}
+
+// plan returns what would happen if the given op was submitted, for dry runs.
+func plan(bs *orchestratorServer, op ranje.Op) (*pb.Plan, error) {
+	p, err := bs.orch.Plan(op)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("error planning %s: %v", op.Kind, err))
+	}
+
+	return planToProto(p), nil
+}
+
+func planToProto(p *Plan) *pb.Plan {
+	out := &pb.Plan{
+		Placements:           make([]*pb.PlannedPlacement, len(p.Placements)),
+		Steps:                make([]*pb.PlanStep, len(p.Steps)),
+		MinActive:            int32(p.MinActive),
+		ReplicationMinActive: int32(p.ReplMinActive),
+		BelowMinActive:       p.BelowMinActive(),
+		Error:                p.Error,
+	}
+
+	for i, pp := range p.Placements {
+		ex := make([]*pb.Exclusion, len(pp.Excluded))
+		for ii, e := range pp.Excluded {
+			ex[ii] = &pb.Exclusion{
+				Node:   conv.NodeIDToProto(e.NodeID),
+				Reason: e.Reason,
+			}
+		}
+
+		out.Placements[i] = &pb.PlannedPlacement{
+			Range:    conv.RangeIDToProto(pp.Range),
+			Node:     conv.NodeIDToProto(pp.NodeID),
+			Excluded: ex,
+		}
+	}
+
+	for i, s := range p.Steps {
+		out.Steps[i] = &pb.PlanStep{
+			Range: conv.RangeIDToProto(s.Range),
+			Node:  conv.NodeIDToProto(s.NodeID),
+			From:  conv.PlacementStateToProto(s.From),
+			To:    conv.PlacementStateToProto(s.To),
+		}
+	}
+
+	return out
+}
+
+// getOp examines the given op ident and returns the corresponding OpID or an
+// error suitable for a gRPC response.
+func getOp(pbid uint64) (ranje.OpID, error) {
+	id, err := conv.OpIDFromProto(pbid)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "missing: operation")
+	}
+
+	return id, nil
+}
+
+// opError converts an error returned by one of the orchestrator's op methods
+// into one suitable for a gRPC response.
+func opError(err error) error {
+	switch {
+	case errors.Is(err, ErrNoSuchOp):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrCantCancel):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// getPlacement examines the given range and node idents, which together
+// identify a placement, and returns them or an error suitable for a gRPC
+// response.
+func getPlacement(bs *orchestratorServer, rangePB uint64, nodePB string) (api.RangeID, api.NodeID, error) {
+	rID, err := getRange(bs, rangePB, "range")
+	if err != nil {
+		return api.ZeroRange, api.ZeroNodeID, err
+	}
+
+	nID, err := conv.NodeIDFromProto(nodePB)
+	if err != nil {
+		return api.ZeroRange, api.ZeroNodeID, status.Error(codes.InvalidArgument, "missing: node")
 	}
 
-	return &pb.JoinResponse{}, nil
+	return rID, nID, nil
 }
 
 // getRange examines the given range ident and returns the corresponding Range
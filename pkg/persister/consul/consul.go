@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	rapi "github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/persister"
 	"github.com/adammck/ranger/pkg/ranje"
 	capi "github.com/hashicorp/consul/api"
 )
@@ -16,23 +17,48 @@ import (
 type Persister struct {
 	kv *capi.KV
 
-	// keep track of the last ModifyIndex for each range.
-	// Note that the key is a *pointer* which is weird.
-	modifyIndex map[*ranje.Range]uint64
+	// prepended to every key, so that several keyspaces can share a Consul.
+	prefix string
 
-	// guards modifyIndex
+	// keep track of the last ModifyIndex for each range. This is replaced
+	// every time the ranges are reloaded by GetRanges.
+	modifyIndex map[rapi.RangeID]uint64
+
+	// prepended to every transaction once fenced, so that it fails if another
+	// controller has claimed the store with a newer epoch since. See Fence.
+	fence *capi.KVTxnOp
+
+	// guards modifyIndex and fence
 	sync.Mutex
 }
 
 func New(client *capi.Client) *Persister {
+	return NewForNamespace(client, "")
+}
+
+// NewForNamespace returns a persister which stores the ranges of the given
+// namespace under their own prefix, e.g. range 1 of namespace "foo" is stored at
+// "namespaces/foo/ranges/1". The ranges of the default (empty) namespace are
+// stored at e.g. "ranges/1", like New, for compatibility.
+func NewForNamespace(client *capi.Client, namespace string) *Persister {
+	prefix := ""
+	if namespace != "" {
+		prefix = fmt.Sprintf("namespaces/%s/", namespace)
+	}
+
 	return &Persister{
 		kv:          client.KV(),
-		modifyIndex: map[*ranje.Range]uint64{},
+		prefix:      prefix,
+		modifyIndex: map[rapi.RangeID]uint64{},
 	}
 }
 
+func (cp *Persister) key(rID rapi.RangeID) string {
+	return fmt.Sprintf("%sranges/%d", cp.prefix, rID)
+}
+
 func (cp *Persister) GetRanges() ([]*ranje.Range, error) {
-	pairs, _, err := cp.kv.List("/ranges", nil)
+	pairs, _, err := cp.kv.List(cp.prefix+"ranges/", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -43,14 +69,18 @@ func (cp *Persister) GetRanges() ([]*ranje.Range, error) {
 	cp.Lock()
 	defer cp.Unlock()
 
+	// Forget the indexes of ranges which no longer exist, e.g. because another
+	// controller collected them. This is called repeatedly by standbys.
+	cp.modifyIndex = map[rapi.RangeID]uint64{}
+
 	for _, kv := range pairs {
-		s := strings.SplitN(kv.Key, "/", 2)
-		if len(s) != 2 {
+		s := strings.TrimPrefix(kv.Key, cp.prefix+"ranges/")
+		if s == kv.Key {
 			log.Printf("warn: invalid Consul key: %s", kv.Key)
 			continue
 		}
 
-		key, err := strconv.ParseUint(s[1], 10, 64)
+		key, err := strconv.ParseUint(s, 10, 64)
 		if err != nil {
 			log.Printf("warn: invalid Consul key: %s", kv.Key)
 			continue
@@ -66,7 +96,7 @@ func (cp *Persister) GetRanges() ([]*ranje.Range, error) {
 		}
 
 		// Update
-		cp.modifyIndex[r] = kv.ModifyIndex
+		cp.modifyIndex[rID] = kv.ModifyIndex
 
 		out = append(out, r)
 	}
@@ -79,7 +109,7 @@ func (cp *Persister) PutRanges(ranges []*ranje.Range) error {
 	defer cp.Unlock()
 
 	var ops capi.KVTxnOps
-	keyToRange := map[string]*ranje.Range{}
+	keyToRange := map[string]rapi.RangeID{}
 
 	for _, r := range ranges {
 		v, err := json.Marshal(r)
@@ -89,38 +119,296 @@ func (cp *Persister) PutRanges(ranges []*ranje.Range) error {
 
 		op := &capi.KVTxnOp{
 			Verb:  capi.KVCAS,
-			Key:   fmt.Sprintf("ranges/%d", r.Meta.Ident),
+			Key:   cp.key(r.Meta.Ident),
 			Value: v,
 		}
 
 		// Keep track of which range each key came from, so we can update the
 		// modifyIndex cache when we receive the response.
 		// TODO: Maybe use the op.Key as the map key here instead?
-		keyToRange[op.Key] = r
+		keyToRange[op.Key] = r.Meta.Ident
 
-		if index, ok := cp.modifyIndex[r]; ok {
+		if index, ok := cp.modifyIndex[r.Meta.Ident]; ok {
 			op.Index = index
 		}
 
 		ops = append(ops, op)
 	}
 
+	ops = cp.fenced(ops)
+
 	ok, res, _, err := cp.kv.Txn(ops, nil)
 	if err != nil {
 		return err
 	}
 	if !ok {
-		// This should never happen
-		panic("got no err but !ok from Txn?")
+		return cp.rolledBack(res)
 	}
 	if len(res.Results) != len(ops) {
 		panic(fmt.Sprintf("expected %d result from Txn, got %d", len(ops), len(res.Results)))
 	}
 
 	for _, res := range res.Results {
-		r := keyToRange[res.Key]
-		cp.modifyIndex[r] = res.ModifyIndex
+		if rID, ok := keyToRange[res.Key]; ok {
+			cp.modifyIndex[rID] = res.ModifyIndex
+		}
+	}
+
+	return nil
+}
+
+func (cp *Persister) DeleteRanges(ranges []*ranje.Range) error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	var ops capi.KVTxnOps
+
+	for _, r := range ranges {
+		op := &capi.KVTxnOp{
+			Verb: capi.KVDelete,
+			Key:  cp.key(r.Meta.Ident),
+		}
+
+		// Only delete the range if it hasn't changed since we last wrote it.
+		if index, ok := cp.modifyIndex[r.Meta.Ident]; ok {
+			op.Verb = capi.KVDeleteCAS
+			op.Index = index
+		}
+
+		ops = append(ops, op)
+	}
+
+	ok, res, _, err := cp.kv.Txn(cp.fenced(ops), nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return cp.rolledBack(res)
+	}
+
+	for _, r := range ranges {
+		delete(cp.modifyIndex, r.Meta.Ident)
+	}
+
+	return nil
+}
+
+func (cp *Persister) opKey(id ranje.OpID) string {
+	return fmt.Sprintf("%sops/%d", cp.prefix, id)
+}
+
+func (cp *Persister) GetOps() ([]*ranje.Op, error) {
+	pairs, _, err := cp.kv.List(cp.prefix+"ops/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []*ranje.Op{}
+
+	for _, kv := range pairs {
+		op := &ranje.Op{}
+		err = json.Unmarshal(kv.Value, op)
+		if err != nil {
+			log.Printf("warn: invalid op at Consul key: %s: %v", kv.Key, err)
+			continue
+		}
+
+		if kv.Key != cp.opKey(op.ID) {
+			log.Printf("warn: mismatch between Consul KV key and encoded op: key=%v, op.ID=%v", kv.Key, op.ID)
+			continue
+		}
+
+		out = append(out, op)
+	}
+
+	return out, nil
+}
+
+func (cp *Persister) PutOps(ops []*ranje.Op) error {
+	var txn capi.KVTxnOps
+
+	for _, op := range ops {
+		v, err := json.Marshal(op)
+		if err != nil {
+			return err
+		}
+
+		txn = append(txn, &capi.KVTxnOp{
+			Verb:  capi.KVSet,
+			Key:   cp.opKey(op.ID),
+			Value: v,
+		})
+	}
+
+	return cp.txn(txn)
+}
+
+func (cp *Persister) DeleteOps(ops []*ranje.Op) error {
+	var txn capi.KVTxnOps
+
+	for _, op := range ops {
+		txn = append(txn, &capi.KVTxnOp{
+			Verb: capi.KVDelete,
+			Key:  cp.opKey(op.ID),
+		})
+	}
+
+	return cp.txn(txn)
+}
+
+func (cp *Persister) nodeKey(nID rapi.NodeID) string {
+	return fmt.Sprintf("%snodes/%s", cp.prefix, nID)
+}
+
+func (cp *Persister) GetNodeAdmins() ([]*ranje.NodeAdmin, error) {
+	pairs, _, err := cp.kv.List(cp.prefix+"nodes/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []*ranje.NodeAdmin{}
+
+	for _, kv := range pairs {
+		na := &ranje.NodeAdmin{}
+		err = json.Unmarshal(kv.Value, na)
+		if err != nil {
+			log.Printf("warn: invalid node admin state at Consul key: %s: %v", kv.Key, err)
+			continue
+		}
+
+		if kv.Key != cp.nodeKey(na.NodeID) {
+			log.Printf("warn: mismatch between Consul KV key and encoded node admin state: key=%v, na.NodeID=%v", kv.Key, na.NodeID)
+			continue
+		}
+
+		out = append(out, na)
+	}
+
+	return out, nil
+}
+
+func (cp *Persister) PutNodeAdmins(nas []*ranje.NodeAdmin) error {
+	var txn capi.KVTxnOps
+
+	for _, na := range nas {
+		v, err := json.Marshal(na)
+		if err != nil {
+			return err
+		}
+
+		txn = append(txn, &capi.KVTxnOp{
+			Verb:  capi.KVSet,
+			Key:   cp.nodeKey(na.NodeID),
+			Value: v,
+		})
+	}
+
+	return cp.txn(txn)
+}
+
+func (cp *Persister) DeleteNodeAdmins(nas []*ranje.NodeAdmin) error {
+	var txn capi.KVTxnOps
+
+	for _, na := range nas {
+		txn = append(txn, &capi.KVTxnOp{
+			Verb: capi.KVDelete,
+			Key:  cp.nodeKey(na.NodeID),
+		})
+	}
+
+	return cp.txn(txn)
+}
+
+// txn performs the given ops in a single transaction.
+func (cp *Persister) txn(ops capi.KVTxnOps) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	cp.Lock()
+	defer cp.Unlock()
+
+	ok, res, _, err := cp.kv.Txn(cp.fenced(ops), nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return cp.rolledBack(res)
 	}
 
 	return nil
 }
+
+func (cp *Persister) epochKey() string {
+	return cp.prefix + "epoch"
+}
+
+// Fence claims the store for the given epoch, by writing it to a key which every
+// subsequent transaction checks hasn't been modified since.
+func (cp *Persister) Fence(epoch uint64) error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	key := cp.epochKey()
+	pair, _, err := cp.kv.Get(key, &capi.QueryOptions{RequireConsistent: true})
+	if err != nil {
+		return err
+	}
+
+	// A CAS with index zero only succeeds if the key doesn't exist yet.
+	var index uint64
+	if pair != nil {
+		prev, err := strconv.ParseUint(string(pair.Value), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid epoch at Consul key: %s: %w", key, err)
+		}
+		if prev > epoch {
+			return persister.ErrFenced
+		}
+		index = pair.ModifyIndex
+	}
+
+	ok, res, _, err := cp.kv.Txn(capi.KVTxnOps{{
+		Verb:  capi.KVCAS,
+		Key:   key,
+		Value: []byte(strconv.FormatUint(epoch, 10)),
+		Index: index,
+	}}, nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Another controller claimed the store since we read the key.
+		return persister.ErrFenced
+	}
+
+	cp.fence = &capi.KVTxnOp{
+		Verb:  capi.KVCheckIndex,
+		Key:   key,
+		Index: res.Results[0].ModifyIndex,
+	}
+
+	return nil
+}
+
+// fenced prepends the fence check (if any) to the given ops. The caller must
+// hold the lock.
+func (cp *Persister) fenced(ops capi.KVTxnOps) capi.KVTxnOps {
+	if cp.fence == nil {
+		return ops
+	}
+
+	return append(capi.KVTxnOps{cp.fence}, ops...)
+}
+
+// rolledBack returns the error for a transaction which was rolled back. The
+// caller must hold the lock.
+func (cp *Persister) rolledBack(res *capi.KVTxnResponse) error {
+	for _, e := range res.Errors {
+		if cp.fence != nil && e.OpIndex == 0 {
+			return persister.ErrFenced
+		}
+	}
+
+	return fmt.Errorf("transaction rolled back: %v", res.Errors)
+}
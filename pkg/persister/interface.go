@@ -1,6 +1,14 @@
 package persister
 
-import "github.com/adammck/ranger/pkg/ranje"
+import (
+	"errors"
+
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// ErrFenced is returned by writes to a store which has been claimed by a newer
+// leadership epoch than the persister was fenced with. See Fencer.
+var ErrFenced = errors.New("fenced by a newer leadership epoch")
 
 type Persister interface {
 
@@ -11,4 +19,58 @@ type Persister interface {
 	// PutRanges writes all of the given Ranges to the store. Implementations
 	// must be transactional, so either they all succeed or none do.
 	PutRanges([]*ranje.Range) error
+
+	// DeleteRanges removes all of the given Ranges from the store. Like
+	// PutRanges, implementations must be transactional. It's called when
+	// obsolete ranges are garbage collected.
+	DeleteRanges([]*ranje.Range) error
+}
+
+// OpPersister is implemented by persisters which can also store the ops which
+// were requested via the Orchestrator API, so that they survive the controller
+// restarting. It's optional; without it, ops are only tracked in memory.
+type OpPersister interface {
+
+	// GetOps returns every stored op. It's called once, at controller startup,
+	// after GetRanges.
+	GetOps() ([]*ranje.Op, error)
+
+	// PutOps writes all of the given ops to the store, replacing any with the
+	// same ID.
+	PutOps([]*ranje.Op) error
+
+	// DeleteOps removes all of the given ops from the store. It's called when
+	// old finished ops are forgotten.
+	DeleteOps([]*ranje.Op) error
+}
+
+// NodePersister is implemented by persisters which can also store the admin
+// state of nodes (i.e. whether they're cordoned or draining), so that it
+// survives the controller restarting. Like OpPersister, it's optional.
+type NodePersister interface {
+
+	// GetNodeAdmins returns the admin state of every node which isn't in the
+	// default state. It's called once, at controller startup.
+	GetNodeAdmins() ([]*ranje.NodeAdmin, error)
+
+	// PutNodeAdmins writes the given admin states to the store, replacing any
+	// with the same NodeID.
+	PutNodeAdmins([]*ranje.NodeAdmin) error
+
+	// DeleteNodeAdmins removes the given admin states from the store. It's
+	// called when nodes return to the default state.
+	DeleteNodeAdmins([]*ranje.NodeAdmin) error
+}
+
+// Fencer is implemented by persisters which can refuse writes from a controller
+// which is no longer the leader, when several are running. Once fenced, every
+// write (including those of OpPersister and NodePersister) atomically checks
+// that no newer epoch has claimed the store since, and fails with ErrFenced if
+// one has. It's optional; without it, only a single controller is safe.
+type Fencer interface {
+
+	// Fence claims the store for the given leadership epoch, which must be
+	// greater than that of every previous leader. See election.Term. It fails
+	// with ErrFenced if a greater epoch has already claimed the store.
+	Fence(epoch uint64) error
 }
@@ -3,9 +3,12 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log"
+	"time"
 
 	rapi "github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/persister"
 	"github.com/adammck/ranger/pkg/ranje"
 	"github.com/hashicorp/go-multierror"
 )
@@ -13,9 +16,24 @@ import (
 //
 // What might a SQL schema for holding some ranje.Ranges look like? Maybe something like this:
 //
-// CREATE TABLE range (id INTEGER PRIMARY KEY, start TEXT, end TEXT, state TEXT);
+// CREATE TABLE range (id INTEGER PRIMARY KEY, start TEXT, end TEXT, state TEXT, replication TEXT, policy TEXT, acked INTEGER);
 // CREATE TABLE child (parentId INTEGER, childId INTEGER, PRIMARY KEY (parentId, childId));
 // CREATE TABLE placement (rangeId INTEGER, nodeId TEXT, stateCurrent TEXT, stateDesired TEXT PRIMARY KEY (rangeId, nodeId));
+// CREATE TABLE history (rangeId INTEGER, state TEXT, time INTEGER);
+//
+// Ops are stored as JSON, since nothing needs to query them. The table is only
+// needed if the persister is used as a persister.OpPersister.
+//
+// CREATE TABLE op (id INTEGER PRIMARY KEY, data TEXT);
+//
+// Likewise the admin state of nodes, for persister.NodePersister.
+//
+// CREATE TABLE node (id TEXT PRIMARY KEY, data TEXT);
+//
+// And the leadership epoch which the store was last claimed by, for
+// persister.Fencer. It only ever has a single row.
+//
+// CREATE TABLE fence (id INTEGER PRIMARY KEY, epoch INTEGER);
 //
 
 type Persister struct {
@@ -24,6 +42,14 @@ type Persister struct {
 	insertRange     *sql.Stmt
 	insertChild     *sql.Stmt
 	insertPlacement *sql.Stmt
+	insertHistory   *sql.Stmt
+	deleteRange     *sql.Stmt
+	deleteChild     *sql.Stmt
+	deletePlacement *sql.Stmt
+	deleteHistory   *sql.Stmt
+
+	// The epoch which writes are fenced by, or zero if they aren't.
+	epoch uint64
 }
 
 // TODO: consider whether a return type that includes a cleanup function,
@@ -31,11 +57,12 @@ type Persister struct {
 // https://github.com/google/wire/blob/main/docs/guide.md#cleanup-functionse
 func New(dbConnectionPool *sql.DB) (*Persister, error) {
 	var prepareErr error
-	insertRange, err := dbConnectionPool.Prepare("INSERT INTO range (id, start, end, state) VALUES (?, ?, ?, ?)")
+	insertRange, err := dbConnectionPool.Prepare("INSERT INTO range (id, start, end, state, replication, policy, acked) VALUES (?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		prepareErr = multierror.Append(prepareErr, err)
 	}
-	insertChild, err := dbConnectionPool.Prepare("INSERT INTO child (parentId, childId) VALUES (?, ?)")
+	// Links are written from both ends, so the same one is often inserted twice.
+	insertChild, err := dbConnectionPool.Prepare("INSERT OR IGNORE INTO child (parentId, childId) VALUES (?, ?)")
 	if err != nil {
 		prepareErr = multierror.Append(prepareErr, err)
 	}
@@ -43,6 +70,26 @@ func New(dbConnectionPool *sql.DB) (*Persister, error) {
 	if err != nil {
 		prepareErr = multierror.Append(prepareErr, err)
 	}
+	insertHistory, err := dbConnectionPool.Prepare("INSERT INTO history (rangeId, state, time) VALUES (?, ?, ?)")
+	if err != nil {
+		prepareErr = multierror.Append(prepareErr, err)
+	}
+	deleteRange, err := dbConnectionPool.Prepare("DELETE FROM range WHERE id = ?")
+	if err != nil {
+		prepareErr = multierror.Append(prepareErr, err)
+	}
+	deleteChild, err := dbConnectionPool.Prepare("DELETE FROM child WHERE parentId = ? OR childId = ?")
+	if err != nil {
+		prepareErr = multierror.Append(prepareErr, err)
+	}
+	deletePlacement, err := dbConnectionPool.Prepare("DELETE FROM placement WHERE rangeId = ?")
+	if err != nil {
+		prepareErr = multierror.Append(prepareErr, err)
+	}
+	deleteHistory, err := dbConnectionPool.Prepare("DELETE FROM history WHERE rangeId = ?")
+	if err != nil {
+		prepareErr = multierror.Append(prepareErr, err)
+	}
 	if prepareErr != nil {
 		return nil, prepareErr
 	}
@@ -51,6 +98,11 @@ func New(dbConnectionPool *sql.DB) (*Persister, error) {
 		insertRange:     insertRange,
 		insertChild:     insertChild,
 		insertPlacement: insertPlacement,
+		insertHistory:   insertHistory,
+		deleteRange:     deleteRange,
+		deleteChild:     deleteChild,
+		deletePlacement: deletePlacement,
+		deleteHistory:   deleteHistory,
 	}, nil
 }
 
@@ -177,14 +229,46 @@ func parseRangeStateString(in string) rapi.RangeState {
 		return rapi.RsSubsuming
 	case "RsObsolete":
 		return rapi.RsObsolete
+	case "RsNew":
+		return rapi.RsNew
+	case "RsAborted":
+		return rapi.RsAborted
 	default:
 		return rapi.RsUnknown
 	}
 }
 
+// GetHistory returns the state changes of the given range, oldest first. Times
+// are stored as Unix nanoseconds, so come back in the local time zone.
+func (p *Persister) GetHistory(rangeId rapi.RangeID) ([]ranje.StateChange, error) {
+	var out []ranje.StateChange
+	rows, err := p.db.Query("SELECT state, time FROM history WHERE rangeId = ? ORDER BY time, rowid", rangeId)
+	if err != nil {
+		log.Println("Maybe the sql query above is malformed?")
+		return nil, err
+	}
+	for rows.Next() {
+		var stateString string
+		var nanos int64
+		if err = rows.Scan(&stateString, &nanos); err != nil {
+			log.Println("Maybe the sql query above is malformed?")
+			return nil, err
+		}
+		out = append(out, ranje.StateChange{
+			State: parseRangeStateString(stateString),
+			When:  time.Unix(0, nanos),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		log.Println("Maybe the sql query above is malformed?")
+		return nil, err
+	}
+	return out, nil
+}
+
 func (p *Persister) GetRanges() ([]*ranje.Range, error) {
 	out := []*ranje.Range{}
-	ranges, err := p.db.Query("SELECT id, start, end, state FROM range")
+	ranges, err := p.db.Query("SELECT id, start, end, state, replication, policy, acked FROM range")
 	if err != nil {
 		log.Println("Maybe the sql query above is malformed?")
 		return nil, err
@@ -197,7 +281,10 @@ func (p *Persister) GetRanges() ([]*ranje.Range, error) {
 		var start string
 		var end string
 		var stateString string
-		if err = ranges.Scan(&idSigned, &start, &end, &stateString); err != nil {
+		var replString sql.NullString
+		var policyString sql.NullString
+		var acked sql.NullBool
+		if err = ranges.Scan(&idSigned, &start, &end, &stateString, &replString, &policyString, &acked); err != nil {
 			log.Println("Maybe the sql query above is malformed?")
 			return nil, err
 		}
@@ -209,7 +296,27 @@ func (p *Persister) GetRanges() ([]*ranje.Range, error) {
 				End:   rapi.Key(end),
 			},
 			State: parseRangeStateString(stateString),
+			Acked: acked.Bool,
+		}
+
+		// Null unless the range has its own replication config.
+		if replString.Valid {
+			r.Replication = &ranje.ReplicationConfig{}
+			if err = json.Unmarshal([]byte(replString.String), r.Replication); err != nil {
+				log.Println("error unmarshaling replication config")
+				return nil, err
+			}
+		}
+
+		// Likewise the placement policy.
+		if policyString.Valid {
+			r.Policy = &ranje.PlacementPolicy{}
+			if err = json.Unmarshal([]byte(policyString.String), r.Policy); err != nil {
+				log.Println("error unmarshaling placement policy")
+				return nil, err
+			}
 		}
+
 		out = append(out, r)
 	}
 	if err = ranges.Err(); err != nil {
@@ -236,6 +343,12 @@ func (p *Persister) GetRanges() ([]*ranje.Range, error) {
 			return nil, err
 		}
 		r.Placements = placements
+		history, err := p.GetHistory(r.Meta.Ident)
+		if err != nil {
+			log.Println("Maybe the sql query above is malformed?")
+			return nil, err
+		}
+		r.History = history
 	}
 
 	return out, nil
@@ -250,10 +363,19 @@ func (p *Persister) PutRanges(ranges []*ranje.Range) error {
 	}
 	defer tx.Rollback()
 
+	if err := p.checkFence(ctx, tx); err != nil {
+		return err
+	}
+
 	// make transaction-specific prepared statements from the existing prepared statements
 	insertRange := tx.StmtContext(ctx, p.insertRange)
 	insertChild := tx.StmtContext(ctx, p.insertChild)
 	insertPlacement := tx.StmtContext(ctx, p.insertPlacement)
+	insertHistory := tx.StmtContext(ctx, p.insertHistory)
+	deleteRange := tx.StmtContext(ctx, p.deleteRange)
+	deleteChild := tx.StmtContext(ctx, p.deleteChild)
+	deletePlacement := tx.StmtContext(ctx, p.deletePlacement)
+	deleteHistory := tx.StmtContext(ctx, p.deleteHistory)
 
 	for _, r := range ranges {
 		id := r.Meta.Ident              // uint64
@@ -261,7 +383,44 @@ func (p *Persister) PutRanges(ranges []*ranje.Range) error {
 		end := r.Meta.End               // string
 		stateString := r.State.String() // string
 
-		if _, err := insertRange.ExecContext(ctx, id, start, end, stateString); err != nil {
+		// Replace whatever was previously stored for this range. Links in both
+		// directions are removed, and then reinserted from the range.
+		if _, err := deleteRange.ExecContext(ctx, id); err != nil {
+			log.Println("error in deleteRange exec")
+			return err
+		}
+		if _, err := deleteChild.ExecContext(ctx, id, id); err != nil {
+			log.Println("error in deleteChild exec")
+			return err
+		}
+		if _, err := deletePlacement.ExecContext(ctx, id); err != nil {
+			log.Println("error in deletePlacement exec")
+			return err
+		}
+		if _, err := deleteHistory.ExecContext(ctx, id); err != nil {
+			log.Println("error in deleteHistory exec")
+			return err
+		}
+
+		var replString sql.NullString // JSON, or null
+		if r.Replication != nil {
+			b, err := json.Marshal(r.Replication)
+			if err != nil {
+				return err
+			}
+			replString = sql.NullString{String: string(b), Valid: true}
+		}
+
+		var policyString sql.NullString // JSON, or null
+		if r.Policy != nil {
+			b, err := json.Marshal(r.Policy)
+			if err != nil {
+				return err
+			}
+			policyString = sql.NullString{String: string(b), Valid: true}
+		}
+
+		if _, err := insertRange.ExecContext(ctx, id, start, end, stateString, replString, policyString, r.Acked); err != nil {
 			log.Println("error in insertRange exec")
 			return err
 		}
@@ -285,6 +444,12 @@ func (p *Persister) PutRanges(ranges []*ranje.Range) error {
 				return err
 			}
 		}
+		for _, sc := range r.History {
+			if _, err := insertHistory.ExecContext(ctx, id, sc.State.String(), sc.When.UnixNano()); err != nil {
+				log.Println("error in insertHistory exec")
+				return err
+			}
+		}
 	}
 
 	err = tx.Commit()
@@ -293,3 +458,246 @@ func (p *Persister) PutRanges(ranges []*ranje.Range) error {
 	}
 	return nil
 }
+
+func (p *Persister) DeleteRanges(ranges []*ranje.Range) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := p.checkFence(ctx, tx); err != nil {
+		return err
+	}
+
+	deleteRange := tx.StmtContext(ctx, p.deleteRange)
+	deleteChild := tx.StmtContext(ctx, p.deleteChild)
+	deletePlacement := tx.StmtContext(ctx, p.deletePlacement)
+	deleteHistory := tx.StmtContext(ctx, p.deleteHistory)
+
+	for _, r := range ranges {
+		id := r.Meta.Ident
+
+		// Links in both directions are removed, since the other end is either
+		// being deleted too, or has had this range removed from its parents.
+		if _, err := deleteChild.ExecContext(ctx, id, id); err != nil {
+			log.Println("error in deleteChild exec")
+			return err
+		}
+		if _, err := deletePlacement.ExecContext(ctx, id); err != nil {
+			log.Println("error in deletePlacement exec")
+			return err
+		}
+		if _, err := deleteHistory.ExecContext(ctx, id); err != nil {
+			log.Println("error in deleteHistory exec")
+			return err
+		}
+		if _, err := deleteRange.ExecContext(ctx, id); err != nil {
+			log.Println("error in deleteRange exec")
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (p *Persister) GetOps() ([]*ranje.Op, error) {
+	rows, err := p.db.Query("SELECT data FROM op ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*ranje.Op{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		op := &ranje.Op{}
+		if err := json.Unmarshal([]byte(data), op); err != nil {
+			return nil, err
+		}
+
+		out = append(out, op)
+	}
+
+	return out, rows.Err()
+}
+
+func (p *Persister) PutOps(ops []*ranje.Op) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := p.checkFence(ctx, tx); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		b, err := json.Marshal(op)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO op (id, data) VALUES (?, ?)", op.ID, string(b)); err != nil {
+			log.Println("error in insertOp exec")
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (p *Persister) DeleteOps(ops []*ranje.Op) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := p.checkFence(ctx, tx); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM op WHERE id = ?", op.ID); err != nil {
+			log.Println("error in deleteOp exec")
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (p *Persister) GetNodeAdmins() ([]*ranje.NodeAdmin, error) {
+	rows, err := p.db.Query("SELECT data FROM node ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []*ranje.NodeAdmin{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		na := &ranje.NodeAdmin{}
+		if err := json.Unmarshal([]byte(data), na); err != nil {
+			return nil, err
+		}
+
+		out = append(out, na)
+	}
+
+	return out, rows.Err()
+}
+
+func (p *Persister) PutNodeAdmins(nas []*ranje.NodeAdmin) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := p.checkFence(ctx, tx); err != nil {
+		return err
+	}
+
+	for _, na := range nas {
+		b, err := json.Marshal(na)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO node (id, data) VALUES (?, ?)", string(na.NodeID), string(b)); err != nil {
+			log.Println("error in insertNode exec")
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (p *Persister) DeleteNodeAdmins(nas []*ranje.NodeAdmin) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := p.checkFence(ctx, tx); err != nil {
+		return err
+	}
+
+	for _, na := range nas {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM node WHERE id = ?", string(na.NodeID)); err != nil {
+			log.Println("error in deleteNode exec")
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Fence claims the store for the given epoch. Every subsequent write checks, in
+// the same transaction, that the store hasn't since been claimed by another.
+func (p *Persister) Fence(epoch uint64) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var prev uint64
+	err = tx.QueryRowContext(ctx, "SELECT epoch FROM fence WHERE id = 0").Scan(&prev)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if prev > epoch {
+		return persister.ErrFenced
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO fence (id, epoch) VALUES (0, ?)", epoch); err != nil {
+		log.Println("error in insertFence exec")
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.epoch = epoch
+	return nil
+}
+
+// checkFence returns persister.ErrFenced if the store has been claimed by an
+// epoch other than the one that this persister was fenced with, if any.
+func (p *Persister) checkFence(ctx context.Context, tx *sql.Tx) error {
+	if p.epoch == 0 {
+		return nil
+	}
+
+	var epoch uint64
+	err := tx.QueryRowContext(ctx, "SELECT epoch FROM fence WHERE id = 0").Scan(&epoch)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if epoch != p.epoch {
+		return persister.ErrFenced
+	}
+
+	return nil
+}
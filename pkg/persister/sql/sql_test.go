@@ -5,8 +5,10 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/persister"
 	persisterSQL "github.com/adammck/ranger/pkg/persister/sql"
 	"github.com/adammck/ranger/pkg/ranje"
 	"github.com/google/go-cmp/cmp"
@@ -26,7 +28,7 @@ func freshTestDB() *sql.DB {
 	if err != nil {
 		panic(err)
 	}
-	_, err = db.Exec("CREATE TABLE IF NOT EXISTS range (id INTEGER PRIMARY KEY, start TEXT, end TEXT, state TEXT)")
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS range (id INTEGER PRIMARY KEY, start TEXT, end TEXT, state TEXT, replication TEXT, policy TEXT, acked INTEGER)")
 	if err != nil {
 		panic(err)
 	}
@@ -38,6 +40,22 @@ func freshTestDB() *sql.DB {
 	if err != nil {
 		panic(err)
 	}
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS history (rangeId INTEGER, state TEXT, time INTEGER)")
+	if err != nil {
+		panic(err)
+	}
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS op (id INTEGER PRIMARY KEY, data TEXT)")
+	if err != nil {
+		panic(err)
+	}
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS node (id TEXT PRIMARY KEY, data TEXT)")
+	if err != nil {
+		panic(err)
+	}
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS fence (id INTEGER PRIMARY KEY, epoch INTEGER)")
+	if err != nil {
+		panic(err)
+	}
 	return db
 }
 
@@ -283,3 +301,412 @@ func TestPutSomethingGetSomething(t *testing.T) {
 		t.Errorf("GetRanges() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestPutSomethingGetReplication(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := ranje.NewRange(api.RangeID(1234), &ranje.R1)
+	b := ranje.NewRange(api.RangeID(5678), &ranje.R1)
+	b.Replication = &ranje.R3
+
+	// Act
+	err = systemUnderTest.PutRanges([]*ranje.Range{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetRanges()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if len(got) != 2 {
+		t.Fatalf("GetRanges() returned %d ranges, want 2", len(got))
+	}
+	if got[0].Replication != nil {
+		t.Errorf("GetRanges()[0].Replication = %v, want nil", got[0].Replication)
+	}
+	if diff := cmp.Diff(&ranje.R3, got[1].Replication); diff != "" {
+		t.Errorf("GetRanges()[1].Replication mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPutSomethingGetPlacementPolicy(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	pp := &ranje.PlacementPolicy{Require: "pool=a", Avoid: "canary", ColocateAdjacent: true}
+	a := ranje.NewRange(api.RangeID(1234), &ranje.R1)
+	b := ranje.NewRange(api.RangeID(5678), &ranje.R1)
+	b.Policy = pp
+
+	// Act
+	err = systemUnderTest.PutRanges([]*ranje.Range{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetRanges()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if len(got) != 2 {
+		t.Fatalf("GetRanges() returned %d ranges, want 2", len(got))
+	}
+	if got[0].Policy != nil {
+		t.Errorf("GetRanges()[0].Policy = %v, want nil", got[0].Policy)
+	}
+	if diff := cmp.Diff(pp, got[1].Policy); diff != "" {
+		t.Errorf("GetRanges()[1].Policy mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPutSomethingGetAcked(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := ranje.NewRange(api.RangeID(1234), &ranje.R1)
+	b := ranje.NewRange(api.RangeID(5678), &ranje.R1)
+	b.State = api.RsObsolete
+	b.Acked = true
+
+	// Act
+	err = systemUnderTest.PutRanges([]*ranje.Range{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetRanges()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if len(got) != 2 {
+		t.Fatalf("GetRanges() returned %d ranges, want 2", len(got))
+	}
+	if got[0].Acked {
+		t.Errorf("GetRanges()[0].Acked = true, want false")
+	}
+	if !got[1].Acked {
+		t.Errorf("GetRanges()[1].Acked = false, want true")
+	}
+}
+
+func TestPutSomethingGetHistory(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t0 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := ranje.NewRange(api.RangeID(1234), &ranje.R1)
+	a.State = api.RsObsolete
+	a.History = []ranje.StateChange{
+		{State: api.RsActive, When: t0},
+		{State: api.RsSubsuming, When: t0.Add(time.Second)},
+		{State: api.RsObsolete, When: t0.Add(time.Minute)},
+	}
+	b := ranje.NewRange(api.RangeID(5678), &ranje.R1)
+
+	// Act
+	err = systemUnderTest.PutRanges([]*ranje.Range{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetRanges()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if len(got) != 2 {
+		t.Fatalf("GetRanges() returned %d ranges, want 2", len(got))
+	}
+	if diff := cmp.Diff(a.History, got[0].History); diff != "" {
+		t.Errorf("GetRanges()[0].History mismatch (-want +got):\n%s", diff)
+	}
+	if got[1].History != nil {
+		t.Errorf("GetRanges()[1].History = %v, want nil", got[1].History)
+	}
+}
+
+func TestPutSomethingDeleteSomething(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := ranje.NewRange(api.RangeID(1234), &ranje.ReplicationConfig{})
+	a.State = api.RsObsolete
+	a.Placements = []*ranje.Placement{{NodeID: "node-aaa"}}
+	b := ranje.NewRange(api.RangeID(5678), &ranje.ReplicationConfig{})
+	b.Parents = []api.RangeID{a.Meta.Ident}
+
+	err = systemUnderTest.PutRanges([]*ranje.Range{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Act
+	err = systemUnderTest.DeleteRanges([]*ranje.Range{a})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetRanges()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	want := []*ranje.Range{
+		ranje.NewRange(api.RangeID(5678), &ranje.ReplicationConfig{}),
+	}
+	opts := []cmp.Option{
+		cmpopts.IgnoreUnexported(ranje.Range{}),
+		cmpopts.IgnoreFields(ranje.Range{}, "Mutex"),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("GetRanges() mismatch (-want +got):\n%s", diff)
+	}
+
+	placements, err := systemUnderTest.GetPlacements(a.Meta.Ident)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(placements) != 0 {
+		t.Errorf("GetPlacements() returned %d placements for deleted range", len(placements))
+	}
+}
+
+func TestPutSomethingTwice(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := ranje.NewRange(api.RangeID(1234), &ranje.ReplicationConfig{})
+	a.State = api.RsObsolete
+	a.Children = []api.RangeID{5678}
+	a.Placements = []*ranje.Placement{{NodeID: "node-aaa"}}
+	b := ranje.NewRange(api.RangeID(5678), &ranje.ReplicationConfig{})
+	b.Parents = []api.RangeID{a.Meta.Ident}
+
+	err = systemUnderTest.PutRanges([]*ranje.Range{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Act
+	a.Placements = nil
+	err = systemUnderTest.PutRanges([]*ranje.Range{a})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetRanges()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if len(got) != 2 {
+		t.Fatalf("GetRanges() returned %d ranges, want 2", len(got))
+	}
+	if len(got[0].Placements) != 0 {
+		t.Errorf("GetRanges()[0].Placements = %v, want none", got[0].Placements)
+	}
+	if diff := cmp.Diff(b.Parents, got[1].Parents); diff != "" {
+		t.Errorf("GetRanges()[1].Parents mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPutOpsGetOpsDeleteOps(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := &ranje.Op{ID: 1, Kind: ranje.OpKindSplit, State: ranje.OsQueued, Ranges: []api.RangeID{1}, Keys: []api.Key{"ccc"}}
+	b := &ranje.Op{ID: 2, Kind: ranje.OpKindMove, State: ranje.OsRunning, Ranges: []api.RangeID{2}, Src: "node-aaa"}
+
+	// Act
+	err = systemUnderTest.PutOps([]*ranje.Op{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a.State = ranje.OsSucceeded
+	err = systemUnderTest.PutOps([]*ranje.Op{a})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = systemUnderTest.DeleteOps([]*ranje.Op{b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetOps()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if diff := cmp.Diff([]*ranje.Op{a}, got); diff != "" {
+		t.Errorf("GetOps() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPutNodeAdminsGetNodeAdminsDeleteNodeAdmins(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	systemUnderTest, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a := &ranje.NodeAdmin{NodeID: "node-aaa", State: ranje.AsCordoned}
+	b := &ranje.NodeAdmin{NodeID: "node-bbb", State: ranje.AsDraining}
+
+	// Act
+	err = systemUnderTest.PutNodeAdmins([]*ranje.NodeAdmin{a, b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	a.State = ranje.AsDraining
+	err = systemUnderTest.PutNodeAdmins([]*ranje.NodeAdmin{a})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = systemUnderTest.DeleteNodeAdmins([]*ranje.NodeAdmin{b})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := systemUnderTest.GetNodeAdmins()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if diff := cmp.Diff([]*ranje.NodeAdmin{a}, got); diff != "" {
+		t.Errorf("GetNodeAdmins() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFence(t *testing.T) {
+	// Arrange
+	db := freshTestDB()
+	defer db.Close()
+	oldLeader, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	newLeader, err := persisterSQL.New(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	r := &ranje.Range{
+		Meta:  api.Meta{Ident: 1},
+		State: api.RsActive,
+	}
+
+	// Act
+	if err := oldLeader.Fence(1); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := oldLeader.PutRanges([]*ranje.Range{r}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := newLeader.Fence(2); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// Assert
+	if err := oldLeader.PutRanges([]*ranje.Range{r}); err != persister.ErrFenced {
+		t.Errorf("PutRanges() by deposed leader: want %v, got %v", persister.ErrFenced, err)
+	}
+	if err := oldLeader.PutOps([]*ranje.Op{{ID: 1}}); err != persister.ErrFenced {
+		t.Errorf("PutOps() by deposed leader: want %v, got %v", persister.ErrFenced, err)
+	}
+	if err := oldLeader.Fence(1); err != persister.ErrFenced {
+		t.Errorf("Fence() with older epoch: want %v, got %v", persister.ErrFenced, err)
+	}
+	if err := newLeader.PutRanges([]*ranje.Range{r}); err != nil {
+		t.Errorf("PutRanges() by new leader: %v", err)
+	}
+}
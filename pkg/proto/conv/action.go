@@ -0,0 +1,23 @@
+package conv
+
+import (
+	"fmt"
+
+	"github.com/adammck/ranger/pkg/api"
+)
+
+// ActionFromProto parses the name of an action, like Activate. These are sent
+// as strings rather than an enum, since they only appear in operator requests.
+func ActionFromProto(s string) (api.Action, error) {
+	for _, a := range []api.Action{api.Prepare, api.Activate, api.Deactivate, api.Drop} {
+		if s == a.String() {
+			return a, nil
+		}
+	}
+
+	return api.NoAction, fmt.Errorf("unknown action: %q", s)
+}
+
+func ActionToProto(a api.Action) string {
+	return a.String()
+}
@@ -0,0 +1,21 @@
+package conv
+
+import (
+	"fmt"
+
+	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+func AdminStateToProto(s ranje.AdminState) pb.AdminState {
+	switch s {
+	case ranje.AsNormal:
+		return pb.AdminState_AS_NORMAL
+	case ranje.AsCordoned:
+		return pb.AdminState_AS_CORDONED
+	case ranje.AsDraining:
+		return pb.AdminState_AS_DRAINING
+	}
+
+	panic(fmt.Sprintf("unknown AdminState: %#v", s))
+}
@@ -0,0 +1,25 @@
+package conv
+
+import (
+	"fmt"
+
+	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+func EventTypeToProto(t ranje.EventType) pb.EventType {
+	switch t {
+	case ranje.EvUnknown:
+		return pb.EventType_EV_UNKNOWN
+	case ranje.EvRangeState:
+		return pb.EventType_EV_RANGE_STATE
+	case ranje.EvPlacementCreated:
+		return pb.EventType_EV_PLACEMENT_CREATED
+	case ranje.EvPlacementState:
+		return pb.EventType_EV_PLACEMENT_STATE
+	case ranje.EvPlacementDestroyed:
+		return pb.EventType_EV_PLACEMENT_DESTROYED
+	}
+
+	panic(fmt.Sprintf("unknown EventType: %#v", t))
+}
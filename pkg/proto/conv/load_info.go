@@ -6,25 +6,55 @@ import (
 )
 
 func LoadInfoFromProto(li *pb.LoadInfo) api.LoadInfo {
+	if li == nil {
+		return api.LoadInfo{}
+	}
+
 	splits := make([]api.Key, len(li.Splits))
 	for i := range li.Splits {
 		splits[i] = api.Key(li.Splits[i])
 	}
 
+	var metrics map[string]float64
+	if len(li.Metrics) > 0 {
+		metrics = make(map[string]float64, len(li.Metrics))
+		for k, v := range li.Metrics {
+			metrics[k] = v
+		}
+	}
+
 	return api.LoadInfo{
-		Keys:   int(li.Keys),
-		Splits: splits,
+		Keys:    int(li.Keys),
+		Bytes:   int(li.Bytes),
+		QPS:     li.Qps,
+		CPU:     li.Cpu,
+		Memory:  int(li.Memory),
+		Metrics: metrics,
+		Splits:  splits,
 	}
 }
 
 func LoadInfoToProto(li api.LoadInfo) *pb.LoadInfo {
-	splits := make([]string, len(li.Splits))
+	splits := make([][]byte, len(li.Splits))
 	for i := range li.Splits {
-		splits[i] = string(li.Splits[i])
+		splits[i] = []byte(li.Splits[i])
+	}
+
+	var metrics map[string]float64
+	if len(li.Metrics) > 0 {
+		metrics = make(map[string]float64, len(li.Metrics))
+		for k, v := range li.Metrics {
+			metrics[k] = v
+		}
 	}
 
 	return &pb.LoadInfo{
-		Keys:   uint64(li.Keys),
-		Splits: splits,
+		Keys:    uint64(li.Keys),
+		Bytes:   uint64(li.Bytes),
+		Qps:     li.QPS,
+		Cpu:     li.CPU,
+		Memory:  uint64(li.Memory),
+		Metrics: metrics,
+		Splits:  splits,
 	}
 }
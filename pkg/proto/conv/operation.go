@@ -0,0 +1,98 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+func OpKindToProto(k ranje.OpKind) pb.OperationKind {
+	switch k {
+	case ranje.OpKindUnknown:
+		return pb.OperationKind_OPERATION_KIND_UNKNOWN
+	case ranje.OpKindMove:
+		return pb.OperationKind_OPERATION_KIND_MOVE
+	case ranje.OpKindSplit:
+		return pb.OperationKind_OPERATION_KIND_SPLIT
+	case ranje.OpKindJoin:
+		return pb.OperationKind_OPERATION_KIND_JOIN
+	}
+
+	panic(fmt.Sprintf("unknown OpKind: %#v", k))
+}
+
+func OpStateToProto(s ranje.OpState) pb.OperationState {
+	switch s {
+	case ranje.OsUnknown:
+		return pb.OperationState_OPERATION_STATE_UNKNOWN
+	case ranje.OsQueued:
+		return pb.OperationState_OPERATION_STATE_QUEUED
+	case ranje.OsRunning:
+		return pb.OperationState_OPERATION_STATE_RUNNING
+	case ranje.OsSucceeded:
+		return pb.OperationState_OPERATION_STATE_SUCCEEDED
+	case ranje.OsFailed:
+		return pb.OperationState_OPERATION_STATE_FAILED
+	case ranje.OsCancelled:
+		return pb.OperationState_OPERATION_STATE_CANCELLED
+	}
+
+	panic(fmt.Sprintf("unknown OpState: %#v", s))
+}
+
+func OpToProto(op ranje.Op) *pb.Operation {
+	ranges := make([]uint64, len(op.Ranges))
+	for i := range op.Ranges {
+		ranges[i] = RangeIDToProto(op.Ranges[i])
+	}
+
+	keys := make([][]byte, len(op.Keys))
+	for i := range op.Keys {
+		keys[i] = []byte(op.Keys[i])
+	}
+
+	nodes := make([]string, len(op.Nodes))
+	for i := range op.Nodes {
+		nodes[i] = NodeIDToProto(op.Nodes[i])
+	}
+
+	return &pb.Operation{
+		Id:      OpIDToProto(op.ID),
+		Kind:    OpKindToProto(op.Kind),
+		State:   OpStateToProto(op.State),
+		Error:   op.Error,
+		Ranges:  ranges,
+		Keys:    keys,
+		Nodes:   nodes,
+		Cancel:  op.Cancel,
+		Created: timeToProto(op.Created),
+		Updated: timeToProto(op.Updated),
+	}
+}
+
+func timeToProto(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+var ErrMissingOpID = errors.New("missing operation ID")
+
+func OpIDFromProto(p uint64) (ranje.OpID, error) {
+	id := ranje.OpID(p)
+
+	if id == 0 {
+		return id, ErrMissingOpID
+	}
+
+	return id, nil
+}
+
+func OpIDToProto(id ranje.OpID) uint64 {
+	return uint64(id)
+}
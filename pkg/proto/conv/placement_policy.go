@@ -0,0 +1,32 @@
+package conv
+
+import (
+	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+func PlacementPolicyFromProto(pp *pb.PlacementPolicy) *ranje.PlacementPolicy {
+	if pp == nil {
+		return nil
+	}
+
+	return &ranje.PlacementPolicy{
+		Require:          ranje.Selector(pp.Require),
+		Prefer:           ranje.Selector(pp.Prefer),
+		Avoid:            ranje.Selector(pp.Avoid),
+		ColocateAdjacent: pp.ColocateAdjacent,
+	}
+}
+
+func PlacementPolicyToProto(pp *ranje.PlacementPolicy) *pb.PlacementPolicy {
+	if pp == nil {
+		return nil
+	}
+
+	return &pb.PlacementPolicy{
+		Require:          string(pp.Require),
+		Prefer:           string(pp.Prefer),
+		Avoid:            string(pp.Avoid),
+		ColocateAdjacent: pp.ColocateAdjacent,
+	}
+}
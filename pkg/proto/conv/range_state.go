@@ -18,6 +18,10 @@ func RangeStateFromProto(rs pb.RangeState) api.RangeState {
 		return api.RsSubsuming
 	case pb.RangeState_RS_OBSOLETE:
 		return api.RsObsolete
+	case pb.RangeState_RS_NEW:
+		return api.RsNew
+	case pb.RangeState_RS_ABORTED:
+		return api.RsAborted
 	}
 
 	log.Printf("warn: unknown pb.RangeState: %#v", rs)
@@ -34,6 +38,10 @@ func RangeStateToProto(rs api.RangeState) pb.RangeState {
 		return pb.RangeState_RS_SUBSUMING
 	case api.RsObsolete:
 		return pb.RangeState_RS_OBSOLETE
+	case api.RsNew:
+		return pb.RangeState_RS_NEW
+	case api.RsAborted:
+		return pb.RangeState_RS_ABORTED
 	}
 
 	panic(fmt.Sprintf("unknown RangeState: %#v", rs))
@@ -0,0 +1,32 @@
+package conv
+
+import (
+	pb "github.com/adammck/ranger/pkg/proto/gen"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+func ReplicationConfigFromProto(rc *pb.ReplicationConfig) *ranje.ReplicationConfig {
+	if rc == nil {
+		return nil
+	}
+
+	return &ranje.ReplicationConfig{
+		TargetActive:  int(rc.TargetActive),
+		MinActive:     int(rc.MinActive),
+		MaxActive:     int(rc.MaxActive),
+		MinPlacements: int(rc.MinPlacements),
+		MaxPlacements: int(rc.MaxPlacements),
+		SpreadBy:      rc.SpreadBy,
+	}
+}
+
+func ReplicationConfigToProto(rc ranje.ReplicationConfig) *pb.ReplicationConfig {
+	return &pb.ReplicationConfig{
+		TargetActive:  int32(rc.TargetActive),
+		MinActive:     int32(rc.MinActive),
+		MaxActive:     int32(rc.MaxActive),
+		MinPlacements: int32(rc.MinPlacements),
+		MaxPlacements: int32(rc.MaxPlacements),
+		SpreadBy:      rc.SpreadBy,
+	}
+}
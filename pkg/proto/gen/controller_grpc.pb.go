@@ -25,10 +25,53 @@ type OrchestratorClient interface {
 	// Place a range on specific node, moving it from the node it is currently
 	// placed on, if any.
 	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*MoveResponse, error)
-	// Split a range in two.
+	// Split a range in two, or more.
 	Split(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*SplitResponse, error)
-	// Join two ranges into one.
+	// Join two (or more) adjacent ranges into one.
 	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	// Give up on a split or join which is in progress, if none of the resulting
+	// ranges have been activated yet. The parent ranges go back to being active.
+	Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*AbortResponse, error)
+	// Change the replication config of a range, or of every range in a span of
+	// keys. Placements are added or removed to match.
+	SetReplication(ctx context.Context, in *SetReplicationRequest, opts ...grpc.CallOption) (*SetReplicationResponse, error)
+	// Change the placement policy of a range, or of every range in a span of
+	// keys. It only affects placements created from then on.
+	SetPlacementPolicy(ctx context.Context, in *SetPlacementPolicyRequest, opts ...grpc.CallOption) (*SetPlacementPolicyResponse, error)
+	// Record that the service no longer needs some obsolete ranges, so they can
+	// be garbage collected, if the controller is keeping them until then (see
+	// rangerd -retain-ack). Acks are persisted, so only need sending once.
+	AckObsolete(ctx context.Context, in *AckObsoleteRequest, opts ...grpc.CallOption) (*AckObsoleteResponse, error)
+	// Get the current state of an operation, which was started by Move, Split,
+	// or Join.
+	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error)
+	// List the operations which are queued or running, and the most recent ones
+	// which have finished.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// Block until an operation finishes, and return it.
+	WaitOperation(ctx context.Context, in *WaitOperationRequest, opts ...grpc.CallOption) (*WaitOperationResponse, error)
+	// Cancel an operation. Queued operations are simply removed from the queue.
+	// Splits and joins which are running are aborted, if that's still possible.
+	// Moves which are running can't be cancelled.
+	CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationResponse, error)
+	// Remove the taint from a placement, so it's no longer deactivated and
+	// dropped when possible, and can be moved again.
+	Untaint(ctx context.Context, in *UntaintRequest, opts ...grpc.CallOption) (*UntaintResponse, error)
+	// Clear the flags which record that actions (e.g. Activate) have failed too
+	// many times on a placement, so the actuator will try them again.
+	ClearFailures(ctx context.Context, in *ClearFailuresRequest, opts ...grpc.CallOption) (*ClearFailuresResponse, error)
+	// Forget about a placement, as if the node had lost it, so it's destroyed
+	// (and replaced, if necessary) without sending any more RPCs to the node.
+	DropPlacement(ctx context.Context, in *DropPlacementRequest, opts ...grpc.CallOption) (*DropPlacementResponse, error)
+	// Stop creating new placements on a node, but leave its existing ones.
+	Cordon(ctx context.Context, in *CordonRequest, opts ...grpc.CallOption) (*CordonResponse, error)
+	// Stop creating new placements on a node, and move its existing ones to
+	// other nodes. Use DrainStatus to find out when it's finished.
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+	// Undo Cordon or Drain, so the node is used as normal again.
+	Uncordon(ctx context.Context, in *UncordonRequest, opts ...grpc.CallOption) (*UncordonResponse, error)
+	// Get the admin state of a node, and how many placements it still has.
+	DrainStatus(ctx context.Context, in *DrainStatusRequest, opts ...grpc.CallOption) (*DrainStatusResponse, error)
 }
 
 type orchestratorClient struct {
@@ -66,6 +109,141 @@ func (c *orchestratorClient) Join(ctx context.Context, in *JoinRequest, opts ...
 	return out, nil
 }
 
+func (c *orchestratorClient) Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*AbortResponse, error) {
+	out := new(AbortResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/Abort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) SetReplication(ctx context.Context, in *SetReplicationRequest, opts ...grpc.CallOption) (*SetReplicationResponse, error) {
+	out := new(SetReplicationResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/SetReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) SetPlacementPolicy(ctx context.Context, in *SetPlacementPolicyRequest, opts ...grpc.CallOption) (*SetPlacementPolicyResponse, error) {
+	out := new(SetPlacementPolicyResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/SetPlacementPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) AckObsolete(ctx context.Context, in *AckObsoleteRequest, opts ...grpc.CallOption) (*AckObsoleteResponse, error) {
+	out := new(AckObsoleteResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/AckObsolete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error) {
+	out := new(GetOperationResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/GetOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/ListOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) WaitOperation(ctx context.Context, in *WaitOperationRequest, opts ...grpc.CallOption) (*WaitOperationResponse, error) {
+	out := new(WaitOperationResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/WaitOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationResponse, error) {
+	out := new(CancelOperationResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/CancelOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) Untaint(ctx context.Context, in *UntaintRequest, opts ...grpc.CallOption) (*UntaintResponse, error) {
+	out := new(UntaintResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/Untaint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) ClearFailures(ctx context.Context, in *ClearFailuresRequest, opts ...grpc.CallOption) (*ClearFailuresResponse, error) {
+	out := new(ClearFailuresResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/ClearFailures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) DropPlacement(ctx context.Context, in *DropPlacementRequest, opts ...grpc.CallOption) (*DropPlacementResponse, error) {
+	out := new(DropPlacementResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/DropPlacement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) Cordon(ctx context.Context, in *CordonRequest, opts ...grpc.CallOption) (*CordonResponse, error) {
+	out := new(CordonResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/Cordon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/Drain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) Uncordon(ctx context.Context, in *UncordonRequest, opts ...grpc.CallOption) (*UncordonResponse, error) {
+	out := new(UncordonResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/Uncordon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) DrainStatus(ctx context.Context, in *DrainStatusRequest, opts ...grpc.CallOption) (*DrainStatusResponse, error) {
+	out := new(DrainStatusResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Orchestrator/DrainStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServer is the server API for Orchestrator service.
 // All implementations must embed UnimplementedOrchestratorServer
 // for forward compatibility
@@ -73,10 +251,53 @@ type OrchestratorServer interface {
 	// Place a range on specific node, moving it from the node it is currently
 	// placed on, if any.
 	Move(context.Context, *MoveRequest) (*MoveResponse, error)
-	// Split a range in two.
+	// Split a range in two, or more.
 	Split(context.Context, *SplitRequest) (*SplitResponse, error)
-	// Join two ranges into one.
+	// Join two (or more) adjacent ranges into one.
 	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	// Give up on a split or join which is in progress, if none of the resulting
+	// ranges have been activated yet. The parent ranges go back to being active.
+	Abort(context.Context, *AbortRequest) (*AbortResponse, error)
+	// Change the replication config of a range, or of every range in a span of
+	// keys. Placements are added or removed to match.
+	SetReplication(context.Context, *SetReplicationRequest) (*SetReplicationResponse, error)
+	// Change the placement policy of a range, or of every range in a span of
+	// keys. It only affects placements created from then on.
+	SetPlacementPolicy(context.Context, *SetPlacementPolicyRequest) (*SetPlacementPolicyResponse, error)
+	// Record that the service no longer needs some obsolete ranges, so they can
+	// be garbage collected, if the controller is keeping them until then (see
+	// rangerd -retain-ack). Acks are persisted, so only need sending once.
+	AckObsolete(context.Context, *AckObsoleteRequest) (*AckObsoleteResponse, error)
+	// Get the current state of an operation, which was started by Move, Split,
+	// or Join.
+	GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error)
+	// List the operations which are queued or running, and the most recent ones
+	// which have finished.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// Block until an operation finishes, and return it.
+	WaitOperation(context.Context, *WaitOperationRequest) (*WaitOperationResponse, error)
+	// Cancel an operation. Queued operations are simply removed from the queue.
+	// Splits and joins which are running are aborted, if that's still possible.
+	// Moves which are running can't be cancelled.
+	CancelOperation(context.Context, *CancelOperationRequest) (*CancelOperationResponse, error)
+	// Remove the taint from a placement, so it's no longer deactivated and
+	// dropped when possible, and can be moved again.
+	Untaint(context.Context, *UntaintRequest) (*UntaintResponse, error)
+	// Clear the flags which record that actions (e.g. Activate) have failed too
+	// many times on a placement, so the actuator will try them again.
+	ClearFailures(context.Context, *ClearFailuresRequest) (*ClearFailuresResponse, error)
+	// Forget about a placement, as if the node had lost it, so it's destroyed
+	// (and replaced, if necessary) without sending any more RPCs to the node.
+	DropPlacement(context.Context, *DropPlacementRequest) (*DropPlacementResponse, error)
+	// Stop creating new placements on a node, but leave its existing ones.
+	Cordon(context.Context, *CordonRequest) (*CordonResponse, error)
+	// Stop creating new placements on a node, and move its existing ones to
+	// other nodes. Use DrainStatus to find out when it's finished.
+	Drain(context.Context, *DrainRequest) (*DrainResponse, error)
+	// Undo Cordon or Drain, so the node is used as normal again.
+	Uncordon(context.Context, *UncordonRequest) (*UncordonResponse, error)
+	// Get the admin state of a node, and how many placements it still has.
+	DrainStatus(context.Context, *DrainStatusRequest) (*DrainStatusResponse, error)
 	mustEmbedUnimplementedOrchestratorServer()
 }
 
@@ -93,6 +314,51 @@ func (UnimplementedOrchestratorServer) Split(context.Context, *SplitRequest) (*S
 func (UnimplementedOrchestratorServer) Join(context.Context, *JoinRequest) (*JoinResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
 }
+func (UnimplementedOrchestratorServer) Abort(context.Context, *AbortRequest) (*AbortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Abort not implemented")
+}
+func (UnimplementedOrchestratorServer) SetReplication(context.Context, *SetReplicationRequest) (*SetReplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReplication not implemented")
+}
+func (UnimplementedOrchestratorServer) SetPlacementPolicy(context.Context, *SetPlacementPolicyRequest) (*SetPlacementPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPlacementPolicy not implemented")
+}
+func (UnimplementedOrchestratorServer) AckObsolete(context.Context, *AckObsoleteRequest) (*AckObsoleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckObsolete not implemented")
+}
+func (UnimplementedOrchestratorServer) GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
+}
+func (UnimplementedOrchestratorServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedOrchestratorServer) WaitOperation(context.Context, *WaitOperationRequest) (*WaitOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaitOperation not implemented")
+}
+func (UnimplementedOrchestratorServer) CancelOperation(context.Context, *CancelOperationRequest) (*CancelOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOperation not implemented")
+}
+func (UnimplementedOrchestratorServer) Untaint(context.Context, *UntaintRequest) (*UntaintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Untaint not implemented")
+}
+func (UnimplementedOrchestratorServer) ClearFailures(context.Context, *ClearFailuresRequest) (*ClearFailuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFailures not implemented")
+}
+func (UnimplementedOrchestratorServer) DropPlacement(context.Context, *DropPlacementRequest) (*DropPlacementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropPlacement not implemented")
+}
+func (UnimplementedOrchestratorServer) Cordon(context.Context, *CordonRequest) (*CordonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cordon not implemented")
+}
+func (UnimplementedOrchestratorServer) Drain(context.Context, *DrainRequest) (*DrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (UnimplementedOrchestratorServer) Uncordon(context.Context, *UncordonRequest) (*UncordonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Uncordon not implemented")
+}
+func (UnimplementedOrchestratorServer) DrainStatus(context.Context, *DrainStatusRequest) (*DrainStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainStatus not implemented")
+}
 func (UnimplementedOrchestratorServer) mustEmbedUnimplementedOrchestratorServer() {}
 
 // UnsafeOrchestratorServer may be embedded to opt out of forward compatibility for this service.
@@ -160,6 +426,276 @@ func _Orchestrator_Join_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Orchestrator_Abort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).Abort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/Abort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).Abort(ctx, req.(*AbortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_SetReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).SetReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/SetReplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).SetReplication(ctx, req.(*SetReplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_SetPlacementPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPlacementPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).SetPlacementPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/SetPlacementPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).SetPlacementPolicy(ctx, req.(*SetPlacementPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_AckObsolete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckObsoleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).AckObsolete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/AckObsolete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).AckObsolete(ctx, req.(*AckObsoleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/GetOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).GetOperation(ctx, req.(*GetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/ListOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_WaitOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).WaitOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/WaitOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).WaitOperation(ctx, req.(*WaitOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_CancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).CancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/CancelOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).CancelOperation(ctx, req.(*CancelOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_Untaint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UntaintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).Untaint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/Untaint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).Untaint(ctx, req.(*UntaintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_ClearFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).ClearFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/ClearFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).ClearFailures(ctx, req.(*ClearFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_DropPlacement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropPlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).DropPlacement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/DropPlacement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).DropPlacement(ctx, req.(*DropPlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_Cordon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CordonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).Cordon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/Cordon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).Cordon(ctx, req.(*CordonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_Uncordon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncordonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).Uncordon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/Uncordon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).Uncordon(ctx, req.(*UncordonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_DrainStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).DrainStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Orchestrator/DrainStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).DrainStatus(ctx, req.(*DrainStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Orchestrator_ServiceDesc is the grpc.ServiceDesc for Orchestrator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -179,6 +715,66 @@ var Orchestrator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Join",
 			Handler:    _Orchestrator_Join_Handler,
 		},
+		{
+			MethodName: "Abort",
+			Handler:    _Orchestrator_Abort_Handler,
+		},
+		{
+			MethodName: "SetReplication",
+			Handler:    _Orchestrator_SetReplication_Handler,
+		},
+		{
+			MethodName: "SetPlacementPolicy",
+			Handler:    _Orchestrator_SetPlacementPolicy_Handler,
+		},
+		{
+			MethodName: "AckObsolete",
+			Handler:    _Orchestrator_AckObsolete_Handler,
+		},
+		{
+			MethodName: "GetOperation",
+			Handler:    _Orchestrator_GetOperation_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _Orchestrator_ListOperations_Handler,
+		},
+		{
+			MethodName: "WaitOperation",
+			Handler:    _Orchestrator_WaitOperation_Handler,
+		},
+		{
+			MethodName: "CancelOperation",
+			Handler:    _Orchestrator_CancelOperation_Handler,
+		},
+		{
+			MethodName: "Untaint",
+			Handler:    _Orchestrator_Untaint_Handler,
+		},
+		{
+			MethodName: "ClearFailures",
+			Handler:    _Orchestrator_ClearFailures_Handler,
+		},
+		{
+			MethodName: "DropPlacement",
+			Handler:    _Orchestrator_DropPlacement_Handler,
+		},
+		{
+			MethodName: "Cordon",
+			Handler:    _Orchestrator_Cordon_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _Orchestrator_Drain_Handler,
+		},
+		{
+			MethodName: "Uncordon",
+			Handler:    _Orchestrator_Uncordon_Handler,
+		},
+		{
+			MethodName: "DrainStatus",
+			Handler:    _Orchestrator_DrainStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller.proto",
@@ -20,10 +20,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EventType int32
+
+const (
+	EventType_EV_UNKNOWN             EventType = 0
+	EventType_EV_RANGE_STATE         EventType = 1
+	EventType_EV_PLACEMENT_CREATED   EventType = 2
+	EventType_EV_PLACEMENT_STATE     EventType = 3
+	EventType_EV_PLACEMENT_DESTROYED EventType = 4
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EV_UNKNOWN",
+		1: "EV_RANGE_STATE",
+		2: "EV_PLACEMENT_CREATED",
+		3: "EV_PLACEMENT_STATE",
+		4: "EV_PLACEMENT_DESTROYED",
+	}
+	EventType_value = map[string]int32{
+		"EV_UNKNOWN":             0,
+		"EV_RANGE_STATE":         1,
+		"EV_PLACEMENT_CREATED":   2,
+		"EV_PLACEMENT_STATE":     3,
+		"EV_PLACEMENT_DESTROYED": 4,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_debug_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_debug_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{0}
+}
+
 type RangesListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The namespace (keyspace) to operate on. Empty means the default one, which
+	// is the only one unless the controller is configured with namespaces.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
 func (x *RangesListRequest) Reset() {
@@ -53,36 +112,593 @@ func (x *RangesListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RangesListRequest.ProtoReflect.Descriptor instead.
-func (*RangesListRequest) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RangesListRequest.ProtoReflect.Descriptor instead.
+func (*RangesListRequest) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RangesListRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type RangesListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranges []*RangeResponse `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	// The revision of the keyspace when the ranges were listed. Pass this to
+	// Watch to receive every change since then.
+	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *RangesListResponse) Reset() {
+	*x = RangesListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangesListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangesListResponse) ProtoMessage() {}
+
+func (x *RangesListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangesListResponse.ProtoReflect.Descriptor instead.
+func (*RangesListResponse) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RangesListResponse) GetRanges() []*RangeResponse {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *RangesListResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type RangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Range     uint64 `protobuf:"varint,1,opt,name=range,proto3" json:"range,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (x *RangeRequest) Reset() {
+	*x = RangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeRequest) ProtoMessage() {}
+
+func (x *RangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeRequest.ProtoReflect.Descriptor instead.
+func (*RangeRequest) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RangeRequest) GetRange() uint64 {
+	if x != nil {
+		return x.Range
+	}
+	return 0
+}
+
+func (x *RangeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Similar to Placement (from ranje.proto), but includes extra junk for debug
+// views. The other one is used on the control plane, so should be minimal.
+type PlacementWithRangeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Placement *Placement `protobuf:"bytes,1,opt,name=placement,proto3" json:"placement,omitempty"`
+	RangeInfo *RangeInfo `protobuf:"bytes,2,opt,name=range_info,json=rangeInfo,proto3" json:"range_info,omitempty"`
+	// Whether the placement will be deactivated and dropped when possible.
+	Tainted bool `protobuf:"varint,3,opt,name=tainted,proto3" json:"tainted,omitempty"`
+	// The actions which have failed too many times, so won't be attempted again
+	// until they're cleared with ClearFailures.
+	Failures []string `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *PlacementWithRangeInfo) Reset() {
+	*x = PlacementWithRangeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlacementWithRangeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementWithRangeInfo) ProtoMessage() {}
+
+func (x *PlacementWithRangeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementWithRangeInfo.ProtoReflect.Descriptor instead.
+func (*PlacementWithRangeInfo) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlacementWithRangeInfo) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *PlacementWithRangeInfo) GetRangeInfo() *RangeInfo {
+	if x != nil {
+		return x.RangeInfo
+	}
+	return nil
+}
+
+func (x *PlacementWithRangeInfo) GetTainted() bool {
+	if x != nil {
+		return x.Tainted
+	}
+	return false
+}
+
+func (x *PlacementWithRangeInfo) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type RangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Meta  *RangeMeta `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	State RangeState `protobuf:"varint,2,opt,name=state,proto3,enum=ranger.RangeState" json:"state,omitempty"`
+	// These two are obviously redundant when all ranges are dumped, but the
+	// output is intended to be useful, not correct.
+	Parents    []uint64                  `protobuf:"varint,3,rep,packed,name=parents,proto3" json:"parents,omitempty"`
+	Children   []uint64                  `protobuf:"varint,4,rep,packed,name=children,proto3" json:"children,omitempty"`
+	Placements []*PlacementWithRangeInfo `protobuf:"bytes,5,rep,name=placements,proto3" json:"placements,omitempty"`
+	// The replication config which applies to the range, and whether that's the
+	// keyspace default (false) or was set specifically for it (true).
+	Replication         *ReplicationConfig `protobuf:"bytes,6,opt,name=replication,proto3" json:"replication,omitempty"`
+	ReplicationOverride bool               `protobuf:"varint,7,opt,name=replication_override,json=replicationOverride,proto3" json:"replication_override,omitempty"`
+	// When the range entered each of the states it has been in, oldest first.
+	History []*RangeStateChange `protobuf:"bytes,8,rep,name=history,proto3" json:"history,omitempty"`
+	// The placement policy of the range, if it has one.
+	PlacementPolicy *PlacementPolicy `protobuf:"bytes,9,opt,name=placement_policy,json=placementPolicy,proto3" json:"placement_policy,omitempty"`
+}
+
+func (x *RangeResponse) Reset() {
+	*x = RangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeResponse) ProtoMessage() {}
+
+func (x *RangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeResponse.ProtoReflect.Descriptor instead.
+func (*RangeResponse) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RangeResponse) GetMeta() *RangeMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetState() RangeState {
+	if x != nil {
+		return x.State
+	}
+	return RangeState_RS_UNKNOWN
+}
+
+func (x *RangeResponse) GetParents() []uint64 {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetChildren() []uint64 {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetPlacements() []*PlacementWithRangeInfo {
+	if x != nil {
+		return x.Placements
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetReplication() *ReplicationConfig {
+	if x != nil {
+		return x.Replication
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetReplicationOverride() bool {
+	if x != nil {
+		return x.ReplicationOverride
+	}
+	return false
+}
+
+func (x *RangeResponse) GetHistory() []*RangeStateChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetPlacementPolicy() *PlacementPolicy {
+	if x != nil {
+		return x.PlacementPolicy
+	}
+	return nil
+}
+
+type RangeStateChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State RangeState `protobuf:"varint,1,opt,name=state,proto3,enum=ranger.RangeState" json:"state,omitempty"`
+	// Unix nanoseconds.
+	Time int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *RangeStateChange) Reset() {
+	*x = RangeStateChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeStateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeStateChange) ProtoMessage() {}
+
+func (x *RangeStateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeStateChange.ProtoReflect.Descriptor instead.
+func (*RangeStateChange) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RangeStateChange) GetState() RangeState {
+	if x != nil {
+		return x.State
+	}
+	return RangeState_RS_UNKNOWN
+}
+
+func (x *RangeStateChange) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// The lineage of a single range. Unlike RangeResponse, this is a snapshot of a
+// (possibly obsolete) range, so includes nothing about its placements.
+type RangeHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Meta     *RangeMeta          `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	State    RangeState          `protobuf:"varint,2,opt,name=state,proto3,enum=ranger.RangeState" json:"state,omitempty"`
+	Parents  []uint64            `protobuf:"varint,3,rep,packed,name=parents,proto3" json:"parents,omitempty"`
+	Children []uint64            `protobuf:"varint,4,rep,packed,name=children,proto3" json:"children,omitempty"`
+	History  []*RangeStateChange `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+}
+
+func (x *RangeHistory) Reset() {
+	*x = RangeHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeHistory) ProtoMessage() {}
+
+func (x *RangeHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeHistory.ProtoReflect.Descriptor instead.
+func (*RangeHistory) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RangeHistory) GetMeta() *RangeMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *RangeHistory) GetState() RangeState {
+	if x != nil {
+		return x.State
+	}
+	return RangeState_RS_UNKNOWN
+}
+
+func (x *RangeHistory) GetParents() []uint64 {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *RangeHistory) GetChildren() []uint64 {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *RangeHistory) GetHistory() []*RangeStateChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type LineageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Range     uint64 `protobuf:"varint,1,opt,name=range,proto3" json:"range,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (x *LineageRequest) Reset() {
+	*x = LineageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LineageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageRequest) ProtoMessage() {}
+
+func (x *LineageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageRequest.ProtoReflect.Descriptor instead.
+func (*LineageRequest) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LineageRequest) GetRange() uint64 {
+	if x != nil {
+		return x.Range
+	}
+	return 0
+}
+
+func (x *LineageRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type LineageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The requested range first, followed by each of its ancestors (which have
+	// not been garbage collected) once, nearest first. Use the parents of each
+	// to rebuild the tree.
+	Ranges []*RangeHistory `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+}
+
+func (x *LineageResponse) Reset() {
+	*x = LineageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debug_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LineageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageResponse) ProtoMessage() {}
+
+func (x *LineageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageResponse.ProtoReflect.Descriptor instead.
+func (*LineageResponse) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LineageResponse) GetRanges() []*RangeHistory {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
 }
 
-type RangesListResponse struct {
+type RangeAtRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ranges []*RangeResponse `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Unix nanoseconds. Zero means now.
+	Time      int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
-func (x *RangesListResponse) Reset() {
-	*x = RangesListResponse{}
+func (x *RangeAtRequest) Reset() {
+	*x = RangeAtRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[1]
+		mi := &file_debug_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RangesListResponse) String() string {
+func (x *RangeAtRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RangesListResponse) ProtoMessage() {}
+func (*RangeAtRequest) ProtoMessage() {}
 
-func (x *RangesListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[1]
+func (x *RangeAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -93,43 +709,59 @@ func (x *RangesListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RangesListResponse.ProtoReflect.Descriptor instead.
-func (*RangesListResponse) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RangeAtRequest.ProtoReflect.Descriptor instead.
+func (*RangeAtRequest) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *RangesListResponse) GetRanges() []*RangeResponse {
+func (x *RangeAtRequest) GetKey() []byte {
 	if x != nil {
-		return x.Ranges
+		return x.Key
 	}
 	return nil
 }
 
-type RangeRequest struct {
+func (x *RangeAtRequest) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *RangeAtRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type RangeAtResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Range uint64 `protobuf:"varint,1,opt,name=range,proto3" json:"range,omitempty"`
+	// The range which owned the key at the given time, i.e. was active or
+	// subsuming, and so may have had placements serving it.
+	Range *RangeHistory `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
 }
 
-func (x *RangeRequest) Reset() {
-	*x = RangeRequest{}
+func (x *RangeAtResponse) Reset() {
+	*x = RangeAtResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[2]
+		mi := &file_debug_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RangeRequest) String() string {
+func (x *RangeAtResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RangeRequest) ProtoMessage() {}
+func (*RangeAtResponse) ProtoMessage() {}
 
-func (x *RangeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[2]
+func (x *RangeAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -140,46 +772,46 @@ func (x *RangeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RangeRequest.ProtoReflect.Descriptor instead.
-func (*RangeRequest) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RangeAtResponse.ProtoReflect.Descriptor instead.
+func (*RangeAtResponse) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RangeRequest) GetRange() uint64 {
+func (x *RangeAtResponse) GetRange() *RangeHistory {
 	if x != nil {
 		return x.Range
 	}
-	return 0
+	return nil
 }
 
-// Similar to Placement (from ranje.proto), but includes extra junk for debug
-// views. The other one is used on the control plane, so should be minimal.
-type PlacementWithRangeInfo struct {
+type WatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Placement *Placement `protobuf:"bytes,1,opt,name=placement,proto3" json:"placement,omitempty"`
-	RangeInfo *RangeInfo `protobuf:"bytes,2,opt,name=range_info,json=rangeInfo,proto3" json:"range_info,omitempty"`
+	// Send every event after this revision, then every new event. Zero means
+	// only new events.
+	Revision  uint64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
-func (x *PlacementWithRangeInfo) Reset() {
-	*x = PlacementWithRangeInfo{}
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[3]
+		mi := &file_debug_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlacementWithRangeInfo) String() string {
+func (x *WatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlacementWithRangeInfo) ProtoMessage() {}
+func (*WatchRequest) ProtoMessage() {}
 
-func (x *PlacementWithRangeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[3]
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -190,56 +822,59 @@ func (x *PlacementWithRangeInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlacementWithRangeInfo.ProtoReflect.Descriptor instead.
-func (*PlacementWithRangeInfo) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *PlacementWithRangeInfo) GetPlacement() *Placement {
+func (x *WatchRequest) GetRevision() uint64 {
 	if x != nil {
-		return x.Placement
+		return x.Revision
 	}
-	return nil
+	return 0
 }
 
-func (x *PlacementWithRangeInfo) GetRangeInfo() *RangeInfo {
+func (x *WatchRequest) GetNamespace() string {
 	if x != nil {
-		return x.RangeInfo
+		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-type RangeResponse struct {
+type WatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Meta  *RangeMeta `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
-	State RangeState `protobuf:"varint,2,opt,name=state,proto3,enum=ranger.RangeState" json:"state,omitempty"`
-	// These two are obviously redundant when all ranges are dumped, but the
-	// output is intended to be useful, not correct.
-	Parents    []uint64                  `protobuf:"varint,3,rep,packed,name=parents,proto3" json:"parents,omitempty"`
-	Children   []uint64                  `protobuf:"varint,4,rep,packed,name=children,proto3" json:"children,omitempty"`
-	Placements []*PlacementWithRangeInfo `protobuf:"bytes,5,rep,name=placements,proto3" json:"placements,omitempty"`
+	// Increases by one with every event. Pass the last one seen to Watch to
+	// resume after disconnecting.
+	Revision uint64    `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Type     EventType `protobuf:"varint,2,opt,name=type,proto3,enum=ranger.EventType" json:"type,omitempty"`
+	Range    uint64    `protobuf:"varint,3,opt,name=range,proto3" json:"range,omitempty"`
+	// The state of the range after the event.
+	RangeState RangeState `protobuf:"varint,4,opt,name=range_state,json=rangeState,proto3,enum=ranger.RangeState" json:"range_state,omitempty"`
+	// Only set for placement events.
+	Node           string         `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
+	PlacementState PlacementState `protobuf:"varint,6,opt,name=placement_state,json=placementState,proto3,enum=ranger.PlacementState" json:"placement_state,omitempty"`
 }
 
-func (x *RangeResponse) Reset() {
-	*x = RangeResponse{}
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[4]
+		mi := &file_debug_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RangeResponse) String() string {
+func (x *WatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RangeResponse) ProtoMessage() {}
+func (*WatchResponse) ProtoMessage() {}
 
-func (x *RangeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[4]
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_debug_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,56 +885,65 @@ func (x *RangeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RangeResponse.ProtoReflect.Descriptor instead.
-func (*RangeResponse) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_debug_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *RangeResponse) GetMeta() *RangeMeta {
+func (x *WatchResponse) GetRevision() uint64 {
 	if x != nil {
-		return x.Meta
+		return x.Revision
 	}
-	return nil
+	return 0
 }
 
-func (x *RangeResponse) GetState() RangeState {
+func (x *WatchResponse) GetType() EventType {
 	if x != nil {
-		return x.State
+		return x.Type
 	}
-	return RangeState_RS_UNKNOWN
+	return EventType_EV_UNKNOWN
 }
 
-func (x *RangeResponse) GetParents() []uint64 {
+func (x *WatchResponse) GetRange() uint64 {
 	if x != nil {
-		return x.Parents
+		return x.Range
 	}
-	return nil
+	return 0
 }
 
-func (x *RangeResponse) GetChildren() []uint64 {
+func (x *WatchResponse) GetRangeState() RangeState {
 	if x != nil {
-		return x.Children
+		return x.RangeState
 	}
-	return nil
+	return RangeState_RS_UNKNOWN
 }
 
-func (x *RangeResponse) GetPlacements() []*PlacementWithRangeInfo {
+func (x *WatchResponse) GetNode() string {
 	if x != nil {
-		return x.Placements
+		return x.Node
 	}
-	return nil
+	return ""
+}
+
+func (x *WatchResponse) GetPlacementState() PlacementState {
+	if x != nil {
+		return x.PlacementState
+	}
+	return PlacementState_PS_UNKNOWN
 }
 
 type NodesListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
 func (x *NodesListRequest) Reset() {
 	*x = NodesListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[5]
+		mi := &file_debug_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -312,7 +956,7 @@ func (x *NodesListRequest) String() string {
 func (*NodesListRequest) ProtoMessage() {}
 
 func (x *NodesListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[5]
+	mi := &file_debug_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +969,14 @@ func (x *NodesListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodesListRequest.ProtoReflect.Descriptor instead.
 func (*NodesListRequest) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{5}
+	return file_debug_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NodesListRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
 }
 
 type NodesListResponse struct {
@@ -339,7 +990,7 @@ type NodesListResponse struct {
 func (x *NodesListResponse) Reset() {
 	*x = NodesListResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[6]
+		mi := &file_debug_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -352,7 +1003,7 @@ func (x *NodesListResponse) String() string {
 func (*NodesListResponse) ProtoMessage() {}
 
 func (x *NodesListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[6]
+	mi := &file_debug_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +1016,7 @@ func (x *NodesListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodesListResponse.ProtoReflect.Descriptor instead.
 func (*NodesListResponse) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{6}
+	return file_debug_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NodesListResponse) GetNodes() []*NodeResponse {
@@ -380,13 +1031,14 @@ type NodeRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Node string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Node      string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
 func (x *NodeRequest) Reset() {
 	*x = NodeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[7]
+		mi := &file_debug_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -399,7 +1051,7 @@ func (x *NodeRequest) String() string {
 func (*NodeRequest) ProtoMessage() {}
 
 func (x *NodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[7]
+	mi := &file_debug_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +1064,7 @@ func (x *NodeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeRequest.ProtoReflect.Descriptor instead.
 func (*NodeRequest) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{7}
+	return file_debug_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *NodeRequest) GetNode() string {
@@ -422,6 +1074,13 @@ func (x *NodeRequest) GetNode() string {
 	return ""
 }
 
+func (x *NodeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
 type NodeMeta struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -430,12 +1089,21 @@ type NodeMeta struct {
 	Ident     string `protobuf:"bytes,1,opt,name=ident,proto3" json:"ident,omitempty"`
 	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	WantDrain bool   `protobuf:"varint,3,opt,name=want_drain,json=wantDrain,proto3" json:"want_drain,omitempty"`
+	// Whether the node has been cordoned or drained by an operator. Unlike
+	// want_drain, which is set by the node itself.
+	AdminState AdminState `protobuf:"varint,4,opt,name=admin_state,json=adminState,proto3,enum=ranger.AdminState" json:"admin_state,omitempty"`
+	// The labels which the node was discovered with, e.g. its zone.
+	Labels map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The total load of the ranges on the node, and its capacity, as it last
+	// reported them.
+	Load     *LoadInfo `protobuf:"bytes,6,opt,name=load,proto3" json:"load,omitempty"`
+	Capacity *LoadInfo `protobuf:"bytes,7,opt,name=capacity,proto3" json:"capacity,omitempty"`
 }
 
 func (x *NodeMeta) Reset() {
 	*x = NodeMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[8]
+		mi := &file_debug_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -448,7 +1116,7 @@ func (x *NodeMeta) String() string {
 func (*NodeMeta) ProtoMessage() {}
 
 func (x *NodeMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[8]
+	mi := &file_debug_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +1129,7 @@ func (x *NodeMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeMeta.ProtoReflect.Descriptor instead.
 func (*NodeMeta) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{8}
+	return file_debug_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *NodeMeta) GetIdent() string {
@@ -485,6 +1153,34 @@ func (x *NodeMeta) GetWantDrain() bool {
 	return false
 }
 
+func (x *NodeMeta) GetAdminState() AdminState {
+	if x != nil {
+		return x.AdminState
+	}
+	return AdminState_AS_NORMAL
+}
+
+func (x *NodeMeta) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *NodeMeta) GetLoad() *LoadInfo {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+func (x *NodeMeta) GetCapacity() *LoadInfo {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
 // TODO: Remove this, and use PlacementWithRangeInfo
 type NodeRange struct {
 	state         protoimpl.MessageState
@@ -498,7 +1194,7 @@ type NodeRange struct {
 func (x *NodeRange) Reset() {
 	*x = NodeRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[9]
+		mi := &file_debug_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -511,7 +1207,7 @@ func (x *NodeRange) String() string {
 func (*NodeRange) ProtoMessage() {}
 
 func (x *NodeRange) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[9]
+	mi := &file_debug_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -524,7 +1220,7 @@ func (x *NodeRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeRange.ProtoReflect.Descriptor instead.
 func (*NodeRange) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{9}
+	return file_debug_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *NodeRange) GetMeta() *RangeMeta {
@@ -553,7 +1249,7 @@ type NodeResponse struct {
 func (x *NodeResponse) Reset() {
 	*x = NodeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_debug_proto_msgTypes[10]
+		mi := &file_debug_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -566,7 +1262,7 @@ func (x *NodeResponse) String() string {
 func (*NodeResponse) ProtoMessage() {}
 
 func (x *NodeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_debug_proto_msgTypes[10]
+	mi := &file_debug_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -579,7 +1275,7 @@ func (x *NodeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeResponse.ProtoReflect.Descriptor instead.
 func (*NodeResponse) Descriptor() ([]byte, []int) {
-	return file_debug_proto_rawDescGZIP(), []int{10}
+	return file_debug_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *NodeResponse) GetNode() *NodeMeta {
@@ -601,80 +1297,198 @@ var File_debug_proto protoreflect.FileDescriptor
 var file_debug_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72,
 	0x61, 0x6e, 0x67, 0x65, 0x72, 0x1a, 0x0b, 0x72, 0x61, 0x6e, 0x6a, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x13, 0x0a, 0x11, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x43, 0x0a, 0x12, 0x52, 0x61, 0x6e, 0x67, 0x65,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a,
-	0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x24, 0x0a, 0x0c,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x61, 0x6e,
-	0x67, 0x65, 0x22, 0x7b, 0x0a, 0x16, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x57,
-	0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2f, 0x0a, 0x09,
-	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a,
-	0x0a, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22,
-	0xd6, 0x01, 0x0a, 0x0d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x65,
-	0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72,
-	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08,
-	0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08,
-	0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x63,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72,
-	0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x57,
-	0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x70, 0x6c,
-	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x4e, 0x6f, 0x64, 0x65,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x11,
-	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x2a, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x21, 0x0a,
-	0x0b, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65,
-	0x22, 0x59, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05,
-	0x69, 0x64, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x64, 0x65,
-	0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
-	0x77, 0x61, 0x6e, 0x74, 0x5f, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x09, 0x77, 0x61, 0x6e, 0x74, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x22, 0x60, 0x0a, 0x09, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12,
-	0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
-	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x5f, 0x0a,
-	0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
-	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6e,
-	0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64,
-	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x32, 0xff,
-	0x01, 0x0a, 0x05, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x45, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x36, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65,
-	0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
-	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x73,
-	0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
-	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4c, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x04, 0x4e,
-	0x6f, 0x64, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65,
-	0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
-	0x64, 0x61, 0x6d, 0x6d, 0x63, 0x6b, 0x2f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x6f, 0x22, 0x31, 0x0a, 0x11, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x5f, 0x0a, 0x12, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0xb1, 0x01, 0x0a, 0x16, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2f, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x61, 0x69, 0x6e, 0x74,
+	0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0xbe,
+	0x03, 0x0a, 0x0d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x65, 0x74,
+	0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x57, 0x69,
+	0x74, 0x68, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x13, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x42, 0x0a, 0x10, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
+	0x50, 0x0a, 0x10, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x22, 0xc9, 0x01, 0x0a, 0x0c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d,
+	0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x07, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x44, 0x0a,
+	0x0e, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x22, 0x3f, 0x0a, 0x0f, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x22, 0x54, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x3d, 0x0a, 0x0f, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a,
+	0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x48, 0x0a, 0x0c, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x22, 0xf2, 0x01, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x33,
+	0x0a, 0x0b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x16, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x30, 0x0a, 0x10, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x3f, 0x0a, 0x11, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2a, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x3f, 0x0a, 0x0b, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0xd3, 0x02, 0x0a,
+	0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x61, 0x6e,
+	0x74, 0x5f, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x77,
+	0x61, 0x6e, 0x74, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x33, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x34, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x2e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x60, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61,
+	0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x22, 0x5f, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x2a, 0x7d, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x56, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x56, 0x5f, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x56, 0x5f, 0x50, 0x4c, 0x41,
+	0x43, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x5f, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x56, 0x5f, 0x50,
+	0x4c, 0x41, 0x43, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x44, 0x45, 0x53, 0x54, 0x52, 0x4f, 0x59,
+	0x45, 0x44, 0x10, 0x04, 0x32, 0xb5, 0x03, 0x0a, 0x05, 0x44, 0x65, 0x62, 0x75, 0x67, 0x12, 0x45,
+	0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72,
+	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14,
+	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
+	0x09, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x33, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67,
+	0x65, 0x12, 0x16, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x72, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x74, 0x12,
+	0x16, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72,
+	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x25, 0x5a, 0x23,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x64, 0x61, 0x6d, 0x6d,
+	0x63, 0x6b, 0x2f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -689,50 +1503,87 @@ func file_debug_proto_rawDescGZIP() []byte {
 	return file_debug_proto_rawDescData
 }
 
-var file_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_debug_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_debug_proto_goTypes = []interface{}{
-	(*RangesListRequest)(nil),      // 0: ranger.RangesListRequest
-	(*RangesListResponse)(nil),     // 1: ranger.RangesListResponse
-	(*RangeRequest)(nil),           // 2: ranger.RangeRequest
-	(*PlacementWithRangeInfo)(nil), // 3: ranger.PlacementWithRangeInfo
-	(*RangeResponse)(nil),          // 4: ranger.RangeResponse
-	(*NodesListRequest)(nil),       // 5: ranger.NodesListRequest
-	(*NodesListResponse)(nil),      // 6: ranger.NodesListResponse
-	(*NodeRequest)(nil),            // 7: ranger.NodeRequest
-	(*NodeMeta)(nil),               // 8: ranger.NodeMeta
-	(*NodeRange)(nil),              // 9: ranger.NodeRange
-	(*NodeResponse)(nil),           // 10: ranger.NodeResponse
-	(*Placement)(nil),              // 11: ranger.Placement
-	(*RangeInfo)(nil),              // 12: ranger.RangeInfo
-	(*RangeMeta)(nil),              // 13: ranger.RangeMeta
-	(RangeState)(0),                // 14: ranger.RangeState
-	(PlacementState)(0),            // 15: ranger.PlacementState
+	(EventType)(0),                 // 0: ranger.EventType
+	(*RangesListRequest)(nil),      // 1: ranger.RangesListRequest
+	(*RangesListResponse)(nil),     // 2: ranger.RangesListResponse
+	(*RangeRequest)(nil),           // 3: ranger.RangeRequest
+	(*PlacementWithRangeInfo)(nil), // 4: ranger.PlacementWithRangeInfo
+	(*RangeResponse)(nil),          // 5: ranger.RangeResponse
+	(*RangeStateChange)(nil),       // 6: ranger.RangeStateChange
+	(*RangeHistory)(nil),           // 7: ranger.RangeHistory
+	(*LineageRequest)(nil),         // 8: ranger.LineageRequest
+	(*LineageResponse)(nil),        // 9: ranger.LineageResponse
+	(*RangeAtRequest)(nil),         // 10: ranger.RangeAtRequest
+	(*RangeAtResponse)(nil),        // 11: ranger.RangeAtResponse
+	(*WatchRequest)(nil),           // 12: ranger.WatchRequest
+	(*WatchResponse)(nil),          // 13: ranger.WatchResponse
+	(*NodesListRequest)(nil),       // 14: ranger.NodesListRequest
+	(*NodesListResponse)(nil),      // 15: ranger.NodesListResponse
+	(*NodeRequest)(nil),            // 16: ranger.NodeRequest
+	(*NodeMeta)(nil),               // 17: ranger.NodeMeta
+	(*NodeRange)(nil),              // 18: ranger.NodeRange
+	(*NodeResponse)(nil),           // 19: ranger.NodeResponse
+	nil,                            // 20: ranger.NodeMeta.LabelsEntry
+	(*Placement)(nil),              // 21: ranger.Placement
+	(*RangeInfo)(nil),              // 22: ranger.RangeInfo
+	(*RangeMeta)(nil),              // 23: ranger.RangeMeta
+	(RangeState)(0),                // 24: ranger.RangeState
+	(*ReplicationConfig)(nil),      // 25: ranger.ReplicationConfig
+	(*PlacementPolicy)(nil),        // 26: ranger.PlacementPolicy
+	(PlacementState)(0),            // 27: ranger.PlacementState
+	(AdminState)(0),                // 28: ranger.AdminState
+	(*LoadInfo)(nil),               // 29: ranger.LoadInfo
 }
 var file_debug_proto_depIdxs = []int32{
-	4,  // 0: ranger.RangesListResponse.ranges:type_name -> ranger.RangeResponse
-	11, // 1: ranger.PlacementWithRangeInfo.placement:type_name -> ranger.Placement
-	12, // 2: ranger.PlacementWithRangeInfo.range_info:type_name -> ranger.RangeInfo
-	13, // 3: ranger.RangeResponse.meta:type_name -> ranger.RangeMeta
-	14, // 4: ranger.RangeResponse.state:type_name -> ranger.RangeState
-	3,  // 5: ranger.RangeResponse.placements:type_name -> ranger.PlacementWithRangeInfo
-	10, // 6: ranger.NodesListResponse.nodes:type_name -> ranger.NodeResponse
-	13, // 7: ranger.NodeRange.meta:type_name -> ranger.RangeMeta
-	15, // 8: ranger.NodeRange.state:type_name -> ranger.PlacementState
-	8,  // 9: ranger.NodeResponse.node:type_name -> ranger.NodeMeta
-	9,  // 10: ranger.NodeResponse.ranges:type_name -> ranger.NodeRange
-	0,  // 11: ranger.Debug.RangesList:input_type -> ranger.RangesListRequest
-	2,  // 12: ranger.Debug.Range:input_type -> ranger.RangeRequest
-	5,  // 13: ranger.Debug.NodesList:input_type -> ranger.NodesListRequest
-	7,  // 14: ranger.Debug.Node:input_type -> ranger.NodeRequest
-	1,  // 15: ranger.Debug.RangesList:output_type -> ranger.RangesListResponse
-	4,  // 16: ranger.Debug.Range:output_type -> ranger.RangeResponse
-	6,  // 17: ranger.Debug.NodesList:output_type -> ranger.NodesListResponse
-	10, // 18: ranger.Debug.Node:output_type -> ranger.NodeResponse
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	5,  // 0: ranger.RangesListResponse.ranges:type_name -> ranger.RangeResponse
+	21, // 1: ranger.PlacementWithRangeInfo.placement:type_name -> ranger.Placement
+	22, // 2: ranger.PlacementWithRangeInfo.range_info:type_name -> ranger.RangeInfo
+	23, // 3: ranger.RangeResponse.meta:type_name -> ranger.RangeMeta
+	24, // 4: ranger.RangeResponse.state:type_name -> ranger.RangeState
+	4,  // 5: ranger.RangeResponse.placements:type_name -> ranger.PlacementWithRangeInfo
+	25, // 6: ranger.RangeResponse.replication:type_name -> ranger.ReplicationConfig
+	6,  // 7: ranger.RangeResponse.history:type_name -> ranger.RangeStateChange
+	26, // 8: ranger.RangeResponse.placement_policy:type_name -> ranger.PlacementPolicy
+	24, // 9: ranger.RangeStateChange.state:type_name -> ranger.RangeState
+	23, // 10: ranger.RangeHistory.meta:type_name -> ranger.RangeMeta
+	24, // 11: ranger.RangeHistory.state:type_name -> ranger.RangeState
+	6,  // 12: ranger.RangeHistory.history:type_name -> ranger.RangeStateChange
+	7,  // 13: ranger.LineageResponse.ranges:type_name -> ranger.RangeHistory
+	7,  // 14: ranger.RangeAtResponse.range:type_name -> ranger.RangeHistory
+	0,  // 15: ranger.WatchResponse.type:type_name -> ranger.EventType
+	24, // 16: ranger.WatchResponse.range_state:type_name -> ranger.RangeState
+	27, // 17: ranger.WatchResponse.placement_state:type_name -> ranger.PlacementState
+	19, // 18: ranger.NodesListResponse.nodes:type_name -> ranger.NodeResponse
+	28, // 19: ranger.NodeMeta.admin_state:type_name -> ranger.AdminState
+	20, // 20: ranger.NodeMeta.labels:type_name -> ranger.NodeMeta.LabelsEntry
+	29, // 21: ranger.NodeMeta.load:type_name -> ranger.LoadInfo
+	29, // 22: ranger.NodeMeta.capacity:type_name -> ranger.LoadInfo
+	23, // 23: ranger.NodeRange.meta:type_name -> ranger.RangeMeta
+	27, // 24: ranger.NodeRange.state:type_name -> ranger.PlacementState
+	17, // 25: ranger.NodeResponse.node:type_name -> ranger.NodeMeta
+	18, // 26: ranger.NodeResponse.ranges:type_name -> ranger.NodeRange
+	1,  // 27: ranger.Debug.RangesList:input_type -> ranger.RangesListRequest
+	3,  // 28: ranger.Debug.Range:input_type -> ranger.RangeRequest
+	14, // 29: ranger.Debug.NodesList:input_type -> ranger.NodesListRequest
+	16, // 30: ranger.Debug.Node:input_type -> ranger.NodeRequest
+	8,  // 31: ranger.Debug.Lineage:input_type -> ranger.LineageRequest
+	10, // 32: ranger.Debug.RangeAt:input_type -> ranger.RangeAtRequest
+	12, // 33: ranger.Debug.Watch:input_type -> ranger.WatchRequest
+	2,  // 34: ranger.Debug.RangesList:output_type -> ranger.RangesListResponse
+	5,  // 35: ranger.Debug.Range:output_type -> ranger.RangeResponse
+	15, // 36: ranger.Debug.NodesList:output_type -> ranger.NodesListResponse
+	19, // 37: ranger.Debug.Node:output_type -> ranger.NodeResponse
+	9,  // 38: ranger.Debug.Lineage:output_type -> ranger.LineageResponse
+	11, // 39: ranger.Debug.RangeAt:output_type -> ranger.RangeAtResponse
+	13, // 40: ranger.Debug.Watch:output_type -> ranger.WatchResponse
+	34, // [34:41] is the sub-list for method output_type
+	27, // [27:34] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_debug_proto_init() }
@@ -803,7 +1654,7 @@ func file_debug_proto_init() {
 			}
 		}
 		file_debug_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodesListRequest); i {
+			switch v := v.(*RangeStateChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -815,7 +1666,7 @@ func file_debug_proto_init() {
 			}
 		}
 		file_debug_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodesListResponse); i {
+			switch v := v.(*RangeHistory); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -827,7 +1678,7 @@ func file_debug_proto_init() {
 			}
 		}
 		file_debug_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeRequest); i {
+			switch v := v.(*LineageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -839,7 +1690,7 @@ func file_debug_proto_init() {
 			}
 		}
 		file_debug_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeMeta); i {
+			switch v := v.(*LineageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -851,7 +1702,7 @@ func file_debug_proto_init() {
 			}
 		}
 		file_debug_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeRange); i {
+			switch v := v.(*RangeAtRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -863,6 +1714,102 @@ func file_debug_proto_init() {
 			}
 		}
 		file_debug_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RangeAtResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodesListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodesListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeMeta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debug_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodeResponse); i {
 			case 0:
 				return &v.state
@@ -880,13 +1827,14 @@ func file_debug_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_debug_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_debug_proto_goTypes,
 		DependencyIndexes: file_debug_proto_depIdxs,
+		EnumInfos:         file_debug_proto_enumTypes,
 		MessageInfos:      file_debug_proto_msgTypes,
 	}.Build()
 	File_debug_proto = out.File
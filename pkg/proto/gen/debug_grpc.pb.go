@@ -26,6 +26,14 @@ type DebugClient interface {
 	Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error)
 	NodesList(ctx context.Context, in *NodesListRequest, opts ...grpc.CallOption) (*NodesListResponse, error)
 	Node(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*NodeResponse, error)
+	// Lineage returns the history of a range and all of its ancestors.
+	Lineage(ctx context.Context, in *LineageRequest, opts ...grpc.CallOption) (*LineageResponse, error)
+	// RangeAt returns the range which owned a key at some point in the past.
+	RangeAt(ctx context.Context, in *RangeAtRequest, opts ...grpc.CallOption) (*RangeAtResponse, error)
+	// Watch streams every change to the ranges and their placements. If the
+	// requested revision is too old to resume from, it fails with OutOfRange,
+	// and the client should call RangesList and watch from its revision.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Debug_WatchClient, error)
 }
 
 type debugClient struct {
@@ -72,6 +80,56 @@ func (c *debugClient) Node(ctx context.Context, in *NodeRequest, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *debugClient) Lineage(ctx context.Context, in *LineageRequest, opts ...grpc.CallOption) (*LineageResponse, error) {
+	out := new(LineageResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Debug/Lineage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) RangeAt(ctx context.Context, in *RangeAtRequest, opts ...grpc.CallOption) (*RangeAtResponse, error) {
+	out := new(RangeAtResponse)
+	err := c.cc.Invoke(ctx, "/ranger.Debug/RangeAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Debug_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Debug_ServiceDesc.Streams[0], "/ranger.Debug/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &debugWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Debug_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type debugWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *debugWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DebugServer is the server API for Debug service.
 // All implementations must embed UnimplementedDebugServer
 // for forward compatibility
@@ -80,6 +138,14 @@ type DebugServer interface {
 	Range(context.Context, *RangeRequest) (*RangeResponse, error)
 	NodesList(context.Context, *NodesListRequest) (*NodesListResponse, error)
 	Node(context.Context, *NodeRequest) (*NodeResponse, error)
+	// Lineage returns the history of a range and all of its ancestors.
+	Lineage(context.Context, *LineageRequest) (*LineageResponse, error)
+	// RangeAt returns the range which owned a key at some point in the past.
+	RangeAt(context.Context, *RangeAtRequest) (*RangeAtResponse, error)
+	// Watch streams every change to the ranges and their placements. If the
+	// requested revision is too old to resume from, it fails with OutOfRange,
+	// and the client should call RangesList and watch from its revision.
+	Watch(*WatchRequest, Debug_WatchServer) error
 	mustEmbedUnimplementedDebugServer()
 }
 
@@ -99,6 +165,15 @@ func (UnimplementedDebugServer) NodesList(context.Context, *NodesListRequest) (*
 func (UnimplementedDebugServer) Node(context.Context, *NodeRequest) (*NodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Node not implemented")
 }
+func (UnimplementedDebugServer) Lineage(context.Context, *LineageRequest) (*LineageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lineage not implemented")
+}
+func (UnimplementedDebugServer) RangeAt(context.Context, *RangeAtRequest) (*RangeAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RangeAt not implemented")
+}
+func (UnimplementedDebugServer) Watch(*WatchRequest, Debug_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedDebugServer) mustEmbedUnimplementedDebugServer() {}
 
 // UnsafeDebugServer may be embedded to opt out of forward compatibility for this service.
@@ -184,6 +259,63 @@ func _Debug_Node_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_Lineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Lineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Debug/Lineage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Lineage(ctx, req.(*LineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_RangeAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RangeAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).RangeAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ranger.Debug/RangeAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).RangeAt(ctx, req.(*RangeAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DebugServer).Watch(m, &debugWatchServer{stream})
+}
+
+type Debug_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type debugWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *debugWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Debug_ServiceDesc is the grpc.ServiceDesc for Debug service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,7 +339,21 @@ var Debug_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Node",
 			Handler:    _Debug_Node_Handler,
 		},
+		{
+			MethodName: "Lineage",
+			Handler:    _Debug_Lineage_Handler,
+		},
+		{
+			MethodName: "RangeAt",
+			Handler:    _Debug_RangeAt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Debug_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "debug.proto",
 }
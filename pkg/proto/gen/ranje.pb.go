@@ -99,6 +99,8 @@ const (
 	RangeState_RS_ACTIVE    RangeState = 1
 	RangeState_RS_SUBSUMING RangeState = 2
 	RangeState_RS_OBSOLETE  RangeState = 3
+	RangeState_RS_NEW       RangeState = 4
+	RangeState_RS_ABORTED   RangeState = 5
 )
 
 // Enum value maps for RangeState.
@@ -108,12 +110,16 @@ var (
 		1: "RS_ACTIVE",
 		2: "RS_SUBSUMING",
 		3: "RS_OBSOLETE",
+		4: "RS_NEW",
+		5: "RS_ABORTED",
 	}
 	RangeState_value = map[string]int32{
 		"RS_UNKNOWN":   0,
 		"RS_ACTIVE":    1,
 		"RS_SUBSUMING": 2,
 		"RS_OBSOLETE":  3,
+		"RS_NEW":       4,
+		"RS_ABORTED":   5,
 	}
 )
 
@@ -204,6 +210,56 @@ func (PlacementState) EnumDescriptor() ([]byte, []int) {
 	return file_ranje_proto_rawDescGZIP(), []int{2}
 }
 
+// Keep synced with ranje.AdminState (in pkg/ranje/node_admin.go)
+type AdminState int32
+
+const (
+	AdminState_AS_NORMAL   AdminState = 0
+	AdminState_AS_CORDONED AdminState = 1
+	AdminState_AS_DRAINING AdminState = 2
+)
+
+// Enum value maps for AdminState.
+var (
+	AdminState_name = map[int32]string{
+		0: "AS_NORMAL",
+		1: "AS_CORDONED",
+		2: "AS_DRAINING",
+	}
+	AdminState_value = map[string]int32{
+		"AS_NORMAL":   0,
+		"AS_CORDONED": 1,
+		"AS_DRAINING": 2,
+	}
+)
+
+func (x AdminState) Enum() *AdminState {
+	p := new(AdminState)
+	*p = x
+	return p
+}
+
+func (x AdminState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdminState) Descriptor() protoreflect.EnumDescriptor {
+	return file_ranje_proto_enumTypes[3].Descriptor()
+}
+
+func (AdminState) Type() protoreflect.EnumType {
+	return &file_ranje_proto_enumTypes[3]
+}
+
+func (x AdminState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdminState.Descriptor instead.
+func (AdminState) EnumDescriptor() ([]byte, []int) {
+	return file_ranje_proto_rawDescGZIP(), []int{3}
+}
+
 type RangeMeta struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -340,8 +396,20 @@ type LoadInfo struct {
 	Keys uint64 `protobuf:"varint,1,opt,name=keys,proto3" json:"keys,omitempty"`
 	// Where the node would suggest that this range be split, in order for the
 	// resulting ranges to be evenly loaded. Otherwise the mid-point between start
-	// and end keys will be used, which is probably not an even split.
-	Splits []string `protobuf:"bytes,2,rep,name=splits,proto3" json:"splits,omitempty"`
+	// and end keys will be used, which is probably not an even split. These are
+	// bytes (like range boundaries) rather than strings, since keys needn't be
+	// valid UTF-8. The two are compatible on the wire.
+	Splits [][]byte `protobuf:"bytes,2,rep,name=splits,proto3" json:"splits,omitempty"`
+	// Number of bytes which this range is storing, on disk or in memory.
+	Bytes uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Number of requests per second which this range is serving.
+	Qps float64 `protobuf:"fixed64,4,opt,name=qps,proto3" json:"qps,omitempty"`
+	// Amount of CPU which this range is using, in cores.
+	Cpu float64 `protobuf:"fixed64,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// Amount of memory which this range is using, in bytes.
+	Memory uint64 `protobuf:"varint,6,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Any other service-specific metrics, by name.
+	Metrics map[string]float64 `protobuf:"bytes,7,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
 }
 
 func (x *LoadInfo) Reset() {
@@ -383,13 +451,48 @@ func (x *LoadInfo) GetKeys() uint64 {
 	return 0
 }
 
-func (x *LoadInfo) GetSplits() []string {
+func (x *LoadInfo) GetSplits() [][]byte {
 	if x != nil {
 		return x.Splits
 	}
 	return nil
 }
 
+func (x *LoadInfo) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetQps() float64 {
+	if x != nil {
+		return x.Qps
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetMemory() uint64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *LoadInfo) GetMetrics() map[string]float64 {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
 // TODO: Rename to RemoteRangeInfo, since this is the view from the remote.
 type RangeInfo struct {
 	state         protoimpl.MessageState
@@ -460,6 +563,169 @@ func (x *RangeInfo) GetInfo() *LoadInfo {
 	return nil
 }
 
+// Keep synced with ranje.ReplicationConfig (in pkg/ranje/replication_config.go)
+type ReplicationConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetActive  int32 `protobuf:"varint,1,opt,name=target_active,json=targetActive,proto3" json:"target_active,omitempty"`
+	MinActive     int32 `protobuf:"varint,2,opt,name=min_active,json=minActive,proto3" json:"min_active,omitempty"`
+	MaxActive     int32 `protobuf:"varint,3,opt,name=max_active,json=maxActive,proto3" json:"max_active,omitempty"`
+	MinPlacements int32 `protobuf:"varint,4,opt,name=min_placements,json=minPlacements,proto3" json:"min_placements,omitempty"`
+	MaxPlacements int32 `protobuf:"varint,5,opt,name=max_placements,json=maxPlacements,proto3" json:"max_placements,omitempty"`
+	// The label key to spread placements across distinct values of, e.g. "zone".
+	// See ranje.ReplicationConfig.SpreadBy.
+	SpreadBy string `protobuf:"bytes,6,opt,name=spread_by,json=spreadBy,proto3" json:"spread_by,omitempty"`
+}
+
+func (x *ReplicationConfig) Reset() {
+	*x = ReplicationConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ranje_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicationConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationConfig) ProtoMessage() {}
+
+func (x *ReplicationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_ranje_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationConfig.ProtoReflect.Descriptor instead.
+func (*ReplicationConfig) Descriptor() ([]byte, []int) {
+	return file_ranje_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplicationConfig) GetTargetActive() int32 {
+	if x != nil {
+		return x.TargetActive
+	}
+	return 0
+}
+
+func (x *ReplicationConfig) GetMinActive() int32 {
+	if x != nil {
+		return x.MinActive
+	}
+	return 0
+}
+
+func (x *ReplicationConfig) GetMaxActive() int32 {
+	if x != nil {
+		return x.MaxActive
+	}
+	return 0
+}
+
+func (x *ReplicationConfig) GetMinPlacements() int32 {
+	if x != nil {
+		return x.MinPlacements
+	}
+	return 0
+}
+
+func (x *ReplicationConfig) GetMaxPlacements() int32 {
+	if x != nil {
+		return x.MaxPlacements
+	}
+	return 0
+}
+
+func (x *ReplicationConfig) GetSpreadBy() string {
+	if x != nil {
+		return x.SpreadBy
+	}
+	return ""
+}
+
+// See ranje.PlacementPolicy. The selectors are comma-separated requirements on
+// node labels, like "pool=a,canary!=true".
+type PlacementPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Require          string `protobuf:"bytes,1,opt,name=require,proto3" json:"require,omitempty"`
+	Prefer           string `protobuf:"bytes,2,opt,name=prefer,proto3" json:"prefer,omitempty"`
+	Avoid            string `protobuf:"bytes,3,opt,name=avoid,proto3" json:"avoid,omitempty"`
+	ColocateAdjacent bool   `protobuf:"varint,4,opt,name=colocate_adjacent,json=colocateAdjacent,proto3" json:"colocate_adjacent,omitempty"`
+}
+
+func (x *PlacementPolicy) Reset() {
+	*x = PlacementPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ranje_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlacementPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementPolicy) ProtoMessage() {}
+
+func (x *PlacementPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ranje_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementPolicy.ProtoReflect.Descriptor instead.
+func (*PlacementPolicy) Descriptor() ([]byte, []int) {
+	return file_ranje_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlacementPolicy) GetRequire() string {
+	if x != nil {
+		return x.Require
+	}
+	return ""
+}
+
+func (x *PlacementPolicy) GetPrefer() string {
+	if x != nil {
+		return x.Prefer
+	}
+	return ""
+}
+
+func (x *PlacementPolicy) GetAvoid() string {
+	if x != nil {
+		return x.Avoid
+	}
+	return ""
+}
+
+func (x *PlacementPolicy) GetColocateAdjacent() bool {
+	if x != nil {
+		return x.ColocateAdjacent
+	}
+	return false
+}
+
 var File_ranje_proto protoreflect.FileDescriptor
 
 var file_ranje_proto_rawDesc = []byte{
@@ -474,39 +740,80 @@ var file_ranje_proto_rawDesc = []byte{
 	0x65, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x16, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
 	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22,
-	0x36, 0x0a, 0x08, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6b,
-	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e,
-	0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x69, 0x6e,
-	0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65,
-	0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
-	0x2a, 0x85, 0x01, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
-	0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0a,
-	0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x52,
-	0x45, 0x50, 0x41, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x43, 0x54,
-	0x49, 0x56, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x45, 0x41,
-	0x43, 0x54, 0x49, 0x56, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x44,
-	0x52, 0x4f, 0x50, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54,
-	0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x07, 0x2a, 0x4e, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x53, 0x5f, 0x55, 0x4e, 0x4b,
-	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x53, 0x5f, 0x41, 0x43, 0x54,
-	0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x53, 0x5f, 0x53, 0x55, 0x42, 0x53,
-	0x55, 0x4d, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x53, 0x5f, 0x4f, 0x42,
-	0x53, 0x4f, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x2a, 0x70, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x63,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x53,
-	0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x53,
-	0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x53,
-	0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50,
-	0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x53,
-	0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x53,
-	0x5f, 0x44, 0x52, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x06, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69,
+	0xfd, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x06, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x71, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x71, 0x70, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x63,
+	0x70, 0x75, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x86, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a,
+	0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04,
+	0x6d, 0x65, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xe1, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x23,
+	0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x6d, 0x61, 0x78, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x22, 0x86, 0x01, 0x0a,
+	0x0f, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x76, 0x6f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x61, 0x76, 0x6f, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x41, 0x64, 0x6a,
+	0x61, 0x63, 0x65, 0x6e, 0x74, 0x2a, 0x85, 0x01, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56,
+	0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12,
+	0x0d, 0x0a, 0x09, 0x50, 0x52, 0x45, 0x50, 0x41, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x0e,
+	0x0a, 0x0a, 0x41, 0x43, 0x54, 0x49, 0x56, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x10,
+	0x0a, 0x0c, 0x44, 0x45, 0x41, 0x43, 0x54, 0x49, 0x56, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x05,
+	0x12, 0x0c, 0x0a, 0x08, 0x44, 0x52, 0x4f, 0x50, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x06, 0x12, 0x0d,
+	0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x07, 0x2a, 0x6a, 0x0a,
+	0x0a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x52,
+	0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52,
+	0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x53,
+	0x5f, 0x53, 0x55, 0x42, 0x53, 0x55, 0x4d, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b,
+	0x52, 0x53, 0x5f, 0x4f, 0x42, 0x53, 0x4f, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0a, 0x0a,
+	0x06, 0x52, 0x53, 0x5f, 0x4e, 0x45, 0x57, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x53, 0x5f,
+	0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x70, 0x0a, 0x0e, 0x50, 0x6c, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
+	0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
+	0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x50,
+	0x53, 0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
+	0x50, 0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
+	0x53, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
+	0x53, 0x5f, 0x44, 0x52, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x06, 0x2a, 0x3d, 0x0a, 0x0a, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x53, 0x5f,
+	0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x53, 0x5f, 0x43,
+	0x4f, 0x52, 0x44, 0x4f, 0x4e, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x53, 0x5f,
+	0x44, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x64, 0x61, 0x6d, 0x6d, 0x63, 0x6b,
 	0x2f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
@@ -524,27 +831,32 @@ func file_ranje_proto_rawDescGZIP() []byte {
 	return file_ranje_proto_rawDescData
 }
 
-var file_ranje_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_ranje_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_ranje_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_ranje_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_ranje_proto_goTypes = []interface{}{
-	(RangeNodeState)(0), // 0: ranger.RangeNodeState
-	(RangeState)(0),     // 1: ranger.RangeState
-	(PlacementState)(0), // 2: ranger.PlacementState
-	(*RangeMeta)(nil),   // 3: ranger.RangeMeta
-	(*Placement)(nil),   // 4: ranger.Placement
-	(*LoadInfo)(nil),    // 5: ranger.LoadInfo
-	(*RangeInfo)(nil),   // 6: ranger.RangeInfo
+	(RangeNodeState)(0),       // 0: ranger.RangeNodeState
+	(RangeState)(0),           // 1: ranger.RangeState
+	(PlacementState)(0),       // 2: ranger.PlacementState
+	(AdminState)(0),           // 3: ranger.AdminState
+	(*RangeMeta)(nil),         // 4: ranger.RangeMeta
+	(*Placement)(nil),         // 5: ranger.Placement
+	(*LoadInfo)(nil),          // 6: ranger.LoadInfo
+	(*RangeInfo)(nil),         // 7: ranger.RangeInfo
+	(*ReplicationConfig)(nil), // 8: ranger.ReplicationConfig
+	(*PlacementPolicy)(nil),   // 9: ranger.PlacementPolicy
+	nil,                       // 10: ranger.LoadInfo.MetricsEntry
 }
 var file_ranje_proto_depIdxs = []int32{
-	2, // 0: ranger.Placement.state:type_name -> ranger.PlacementState
-	3, // 1: ranger.RangeInfo.meta:type_name -> ranger.RangeMeta
-	0, // 2: ranger.RangeInfo.state:type_name -> ranger.RangeNodeState
-	5, // 3: ranger.RangeInfo.info:type_name -> ranger.LoadInfo
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	2,  // 0: ranger.Placement.state:type_name -> ranger.PlacementState
+	10, // 1: ranger.LoadInfo.metrics:type_name -> ranger.LoadInfo.MetricsEntry
+	4,  // 2: ranger.RangeInfo.meta:type_name -> ranger.RangeMeta
+	0,  // 3: ranger.RangeInfo.state:type_name -> ranger.RangeNodeState
+	6,  // 4: ranger.RangeInfo.info:type_name -> ranger.LoadInfo
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_ranje_proto_init() }
@@ -601,14 +913,38 @@ func file_ranje_proto_init() {
 				return nil
 			}
 		}
+		file_ranje_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicationConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ranje_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlacementPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ranje_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   4,
+			NumEnums:      4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
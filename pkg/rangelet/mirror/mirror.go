@@ -47,6 +47,9 @@ type Mirror struct {
 	// Dialler takes a remote and returns a gRPC client connection. This is only
 	// parameterized for testing.
 	dialler Dialler
+
+	// Applied to keys passed to Find. Nil means keys are used as-is.
+	keyTransform api.KeyTransform
 }
 
 type node struct {
@@ -76,6 +79,13 @@ func (m *Mirror) WithDialler(d Dialler) *Mirror {
 	return m
 }
 
+// WithKeyTransform sets the func which Find applies to keys before looking for
+// the ranges containing them. It must be the same one that the nodes use.
+func (m *Mirror) WithKeyTransform(t api.KeyTransform) *Mirror {
+	m.keyTransform = t
+	return m
+}
+
 func (m *Mirror) add(rem api.Remote) {
 	log.Printf("Adding: %s", rem.NodeID())
 
@@ -165,6 +175,7 @@ func (m *Mirror) Conn(nID api.NodeID) (*grpc.ClientConn, bool) {
 
 func (m *Mirror) Find(key api.Key, states ...api.RemoteState) []Result {
 	results := []Result{}
+	key = m.keyTransform.Apply(key)
 
 	m.nodesMu.RLock()
 	defer m.nodesMu.RUnlock()
@@ -92,18 +92,52 @@ func TestStatic(t *testing.T) {
 	}}, res)
 }
 
+func TestHashed(t *testing.T) {
+	h := setup(t)
+	h.mirror.WithKeyTransform(api.HashKey)
+
+	h.add(t, api.Remote{
+		Ident: "aaa",
+		Host:  "host-aaa",
+		Port:  1,
+	}, []api.RangeInfo{
+		{
+			Meta:  api.Meta{Ident: 1, End: api.Key("8000000000000000")},
+			State: api.NsActive,
+		},
+		{
+			Meta:  api.Meta{Ident: 2, Start: api.Key("8000000000000000")},
+			State: api.NsActive,
+		},
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Unhashed, both of these keys would be in range 2.
+	// HashKey("bbb") = 00431619134167a5
+	// HashKey("aaa") = e71cbc19053f4da2
+
+	res := h.mirror.Find(api.Key("bbb"))
+	assert.Assert(t, cmp.Len(res, 1))
+	assert.Equal(t, api.RangeID(1), res[0].RangeID)
+
+	res = h.mirror.Find(api.Key("aaa"))
+	assert.Assert(t, cmp.Len(res, 1))
+	assert.Equal(t, api.RangeID(2), res[0].RangeID)
+}
+
 // -----------------------------------------------------------------------------
 
 type testHarness struct {
 	disc   *mock_disc.Discoverer
-	nodes  map[api.Remote]*nodeServer
+	nodes  map[string]*nodeServer // by ident
 	mirror *Mirror
 }
 
 func setup(t *testing.T) *testHarness {
 	h := &testHarness{
 		disc:  mock_disc.NewDiscoverer(),
-		nodes: map[api.Remote]*nodeServer{},
+		nodes: map[string]*nodeServer{},
 	}
 
 	h.mirror = New(h.disc).WithDialler(h.dial) // SUT
@@ -119,12 +153,12 @@ func setup(t *testing.T) *testHarness {
 func (h *testHarness) add(t *testing.T, rem api.Remote, ranges []api.RangeInfo) {
 	ns := newNodeServer(ranges)
 	t.Cleanup(ns.stop)
-	h.nodes[rem] = ns
+	h.nodes[rem.Ident] = ns
 	h.disc.Add("node", rem)
 }
 
 func (h *testHarness) dial(ctx context.Context, rem api.Remote) (*grpc.ClientConn, error) {
-	node, ok := h.nodes[rem]
+	node, ok := h.nodes[rem.Ident]
 	if !ok {
 		log.Printf("No such remote: %s", rem.Ident)
 		return nil, fmt.Errorf("No such remote: %s", rem.Ident)
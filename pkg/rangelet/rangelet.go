@@ -32,8 +32,16 @@ type Rangelet struct {
 	//       for *some* ranges to be moved.
 	xWantDrain uint32
 
+	// The total load which the node can handle, reported to the controller
+	// along with the load of each range. See SetCapacity.
+	capacity api.LoadInfo
+
 	gracePeriod time.Duration
 
+	// Applied to keys passed to Find. Nil means keys are used as-is. See
+	// SetKeyTransform.
+	keyTransform api.KeyTransform
+
 	// Holds functions to be called when a specific range leaves a state. This
 	// is just for testing. Register callbacks via the OnLeaveState method.
 	callbacks map[callback]func()
@@ -310,6 +318,8 @@ func (r *Rangelet) drop(rID api.RangeID) (api.RangeInfo, error) {
 //       during an operation where both src and dest are on this node. Currently
 //       just returns the first one it finds.
 func (r *Rangelet) Find(k api.Key) (api.RangeID, bool) {
+	k = r.keyTransform.Apply(k)
+
 	for _, ri := range r.info {
 
 		// Play dumb in some cases: a range can be known to the rangelet but
@@ -392,8 +402,20 @@ func (r *Rangelet) gatherLoadInfo() error {
 // TODO: Remove once roster/info import is gone from rangelet.
 func updateLoadInfo(rostLI *api.LoadInfo, rgltLI api.LoadInfo) {
 	rostLI.Keys = int(rgltLI.Keys)
+	rostLI.Bytes = rgltLI.Bytes
+	rostLI.QPS = rgltLI.QPS
+	rostLI.CPU = rgltLI.CPU
+	rostLI.Memory = rgltLI.Memory
 	rostLI.Splits = make([]api.Key, len(rgltLI.Splits))
 	copy(rostLI.Splits, rgltLI.Splits)
+
+	rostLI.Metrics = nil
+	if len(rgltLI.Metrics) > 0 {
+		rostLI.Metrics = make(map[string]float64, len(rgltLI.Metrics))
+		for k, v := range rgltLI.Metrics {
+			rostLI.Metrics[k] = v
+		}
+	}
 }
 
 func (r *Rangelet) walk(f func(*api.RangeInfo) bool) {
@@ -474,6 +496,15 @@ func (r *Rangelet) wantDrain() bool {
 	return b == 1
 }
 
+// SetKeyTransform sets the func which Find applies to keys before looking for
+// the range containing them, e.g. api.HashKey. This must be called before Find
+// is, and every node (and mirror) in the cluster must use the same one.
+func (r *Rangelet) SetKeyTransform(t api.KeyTransform) {
+	r.Lock()
+	defer r.Unlock()
+	r.keyTransform = t
+}
+
 func (r *Rangelet) SetWantDrain(b bool) {
 	var v uint32
 	if b {
@@ -483,6 +514,23 @@ func (r *Rangelet) SetWantDrain(b bool) {
 	atomic.StoreUint32(&r.xWantDrain, v)
 }
 
+// SetCapacity sets the total load which the node can handle, in the same units
+// as the LoadInfo returned by GetLoadInfo, so the controller can compare the
+// load on the node to it when placing ranges. Metrics which are left zero are
+// unlimited. This can be called at any time, e.g. when memory is resized.
+func (r *Rangelet) SetCapacity(li api.LoadInfo) {
+	r.Lock()
+	defer r.Unlock()
+	r.capacity = li
+	r.capacity.Splits = nil
+}
+
+func (r *Rangelet) getCapacity() api.LoadInfo {
+	r.RLock()
+	defer r.RUnlock()
+	return r.capacity
+}
+
 // State returns the state that the given range is currently in, or NsNotFound
 // if the range doesn't exist. This should not be used for anything other than
 // sanity-checking and testing. Clients should react to changes via the Node
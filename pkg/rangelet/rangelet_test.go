@@ -524,6 +524,33 @@ func TestDropErrorSlow(t *testing.T) {
 	}, waitFor, tick)
 }
 
+func TestFindHashed(t *testing.T) {
+	_, rglt := Setup()
+
+	setupDeactivate(rglt.info, api.Meta{Ident: 1, End: api.Key("8000000000000000")})
+	setupDeactivate(rglt.info, api.Meta{Ident: 2, Start: api.Key("8000000000000000")})
+
+	// Unhashed, both keys sort after the boundary.
+	rID, ok := rglt.Find(api.Key("aaa"))
+	require.True(t, ok)
+	assert.Equal(t, api.RangeID(2), rID)
+	rID, ok = rglt.Find(api.Key("bbb"))
+	require.True(t, ok)
+	assert.Equal(t, api.RangeID(2), rID)
+
+	// The hash must never change, or keys would move between ranges.
+	assert.Equal(t, api.Key("e71cbc19053f4da2"), api.HashKey("aaa"))
+	assert.Equal(t, api.Key("00431619134167a5"), api.HashKey("bbb"))
+
+	rglt.SetKeyTransform(api.HashKey)
+	rID, ok = rglt.Find(api.Key("aaa"))
+	require.True(t, ok)
+	assert.Equal(t, api.RangeID(2), rID)
+	rID, ok = rglt.Find(api.Key("bbb"))
+	require.True(t, ok)
+	assert.Equal(t, api.RangeID(1), rID)
+}
+
 // ----
 
 type MockNode struct {
@@ -144,6 +144,7 @@ func (ns *NodeServer) Info(ctx context.Context, req *pb.InfoRequest) (*pb.InfoRe
 
 	res := &pb.InfoResponse{
 		WantDrain: ns.r.wantDrain(),
+		Capacity:  conv.LoadInfoToProto(ns.r.getCapacity()),
 	}
 
 	ns.r.walk(func(ri *api.RangeInfo) bool {
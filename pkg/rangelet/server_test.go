@@ -49,6 +49,57 @@ func TestRanges(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+// loadNode is an api.Node which only reports load.
+type loadNode struct {
+	api.Node
+	info api.LoadInfo
+}
+
+func (n *loadNode) GetLoadInfo(rID api.RangeID) (api.LoadInfo, error) {
+	return n.info, nil
+}
+
+func TestInfo(t *testing.T) {
+	h := setup(t, singleRange())
+	h.rglt.n = &loadNode{info: api.LoadInfo{
+		Keys:    10,
+		Bytes:   2048,
+		QPS:     1.5,
+		CPU:     0.25,
+		Memory:  4096,
+		Metrics: map[string]float64{"conns": 3},
+		Splits:  []api.Key{"ccc"},
+	}}
+	h.rglt.SetCapacity(api.LoadInfo{
+		Bytes: 1 << 20,
+		CPU:   4,
+	})
+
+	res, err := h.client.Info(h.ctx, &pb.InfoRequest{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &pb.InfoResponse{
+		Ranges: []*pb.RangeInfo{
+			{
+				Meta:  &pb.RangeMeta{Ident: 1},
+				State: pb.RangeNodeState_ACTIVE,
+				Info: &pb.LoadInfo{
+					Keys:    10,
+					Bytes:   2048,
+					Qps:     1.5,
+					Cpu:     0.25,
+					Memory:  4096,
+					Metrics: map[string]float64{"conns": 3},
+					Splits:  [][]byte{[]byte("ccc")},
+				},
+			},
+		},
+		Capacity: &pb.LoadInfo{
+			Bytes: 1 << 20,
+			Cpu:   4,
+		},
+	}, res, protocmp.Transform())
+}
+
 type testHarness struct {
 	ctx    context.Context
 	rglt   *Rangelet
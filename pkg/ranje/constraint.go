@@ -10,15 +10,42 @@ import (
 type Constraint struct {
 	NodeID api.NodeID
 	Not    []api.NodeID
+
+	// If SpreadBy is set, nodes are preferred which have a different value of
+	// that label than the Peers, i.e. the nodes with the other placements of
+	// the range which will remain once this one is created. Those with fewest
+	// peers sharing their value are preferred. See ReplicationConfig.SpreadBy.
+	SpreadBy string
+	Peers    []api.NodeID
+
+	// Nodes which don't match Require are excluded. Among the rest, nodes which
+	// don't match Avoid are preferred, then those which match Prefer, then
+	// (after spreading, above) those in Affinity. See PlacementPolicy.
+	Require  Selector
+	Prefer   Selector
+	Avoid    Selector
+	Affinity []api.NodeID
 }
 
 func (c Constraint) Copy() Constraint {
 	not := make([]api.NodeID, len(c.Not))
 	copy(not, c.Not)
 
+	peers := make([]api.NodeID, len(c.Peers))
+	copy(peers, c.Peers)
+
+	aff := make([]api.NodeID, len(c.Affinity))
+	copy(aff, c.Affinity)
+
 	return Constraint{
-		NodeID: c.NodeID,
-		Not:    not,
+		NodeID:   c.NodeID,
+		Not:      not,
+		SpreadBy: c.SpreadBy,
+		Peers:    peers,
+		Require:  c.Require,
+		Prefer:   c.Prefer,
+		Avoid:    c.Avoid,
+		Affinity: aff,
 	}
 }
 
@@ -37,6 +64,34 @@ func (c Constraint) String() string {
 		tokens = append(tokens, fmt.Sprintf("not(%s)", strings.Join(nots, ",")))
 	}
 
+	if c.SpreadBy != "" {
+		peers := make([]string, len(c.Peers))
+		for i := range c.Peers {
+			peers[i] = c.Peers[i].String()
+		}
+		tokens = append(tokens, fmt.Sprintf("spread(%s:%s)", c.SpreadBy, strings.Join(peers, ",")))
+	}
+
+	if c.Require != "" {
+		tokens = append(tokens, fmt.Sprintf("require(%s)", c.Require))
+	}
+
+	if c.Prefer != "" {
+		tokens = append(tokens, fmt.Sprintf("prefer(%s)", c.Prefer))
+	}
+
+	if c.Avoid != "" {
+		tokens = append(tokens, fmt.Sprintf("avoid(%s)", c.Avoid))
+	}
+
+	if len(c.Affinity) > 0 {
+		aff := make([]string, len(c.Affinity))
+		for i := range c.Affinity {
+			aff[i] = c.Affinity[i].String()
+		}
+		tokens = append(tokens, fmt.Sprintf("affinity(%s)", strings.Join(aff, ",")))
+	}
+
 	// TODO: Include Not nIDs in here.
 
 	if len(tokens) == 0 {
@@ -58,5 +113,32 @@ func (c Constraint) WithNodeID(nID api.NodeID) Constraint {
 	return new
 }
 
+// WithPeer returns a copy of the constraint which also prefers nodes in another
+// failure domain than the given node. It does nothing unless SpreadBy is set.
+func (c Constraint) WithPeer(nID api.NodeID) Constraint {
+	new := c.Copy()
+	new.Peers = append(new.Peers, nID)
+	return new
+}
+
+// WithPolicy returns a copy of the constraint which also applies the selectors
+// of the given placement policy. Affinity must be added separately, since the
+// policy doesn't know which nodes the adjacent ranges are on.
+func (c Constraint) WithPolicy(pp PlacementPolicy) Constraint {
+	new := c.Copy()
+	new.Require = pp.Require
+	new.Prefer = pp.Prefer
+	new.Avoid = pp.Avoid
+	return new
+}
+
+// WithAffinity returns a copy of the constraint which also prefers the given
+// node, e.g. because it has a placement of an adjacent range.
+func (c Constraint) WithAffinity(nID api.NodeID) Constraint {
+	new := c.Copy()
+	new.Affinity = append(new.Affinity, nID)
+	return new
+}
+
 // AnyNode is an empty constraint, which matches... any node.
 var AnyNode = Constraint{}
@@ -0,0 +1,64 @@
+package ranje
+
+import (
+	"fmt"
+
+	"github.com/adammck/ranger/pkg/api"
+)
+
+type EventType uint8
+
+const (
+	EvUnknown EventType = iota
+
+	// The range was created, or changed state. RangeState is the new state.
+	EvRangeState
+
+	// A placement was created on Node, in PlacementState (always PsPending).
+	EvPlacementCreated
+
+	// The placement on Node changed state. PlacementState is the new state.
+	EvPlacementState
+
+	// The placement on Node was destroyed. PlacementState is the state it was
+	// last in.
+	EvPlacementDestroyed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EvRangeState:
+		return "EvRangeState"
+	case EvPlacementCreated:
+		return "EvPlacementCreated"
+	case EvPlacementState:
+		return "EvPlacementState"
+	case EvPlacementDestroyed:
+		return "EvPlacementDestroyed"
+	default:
+		return fmt.Sprintf("EventType(%d)", t)
+	}
+}
+
+// Event is a change to a range or one of its placements. They're emitted to
+// the func passed to Range.OnEvent, in the order they happen.
+type Event struct {
+	Type  EventType
+	Range api.RangeID
+
+	// The state of the range after the event. This is included in placement
+	// events too, for convenience.
+	RangeState api.RangeState
+
+	// Only set for placement events.
+	Node           api.NodeID
+	PlacementState api.PlacementState
+}
+
+func (e Event) String() string {
+	if e.Type == EvRangeState {
+		return fmt.Sprintf("%s(R%s, %s)", e.Type, e.Range, e.RangeState)
+	}
+
+	return fmt.Sprintf("%s(R%s, %s, %s)", e.Type, e.Range, e.Node, e.PlacementState)
+}
@@ -0,0 +1,53 @@
+package ranje
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+)
+
+// AdminState is the state of a node which was set by an operator via the
+// controller, as opposed to by the node itself (see Rangelet.SetWantDrain).
+// Unlike the rest of the node state, it's persisted, and applies whether or
+// not the node is currently registered.
+type AdminState uint8
+
+const (
+	// The node is used as normal. Nodes with no NodeAdmin are in this state.
+	AsNormal AdminState = iota
+
+	// No new placements will be created on the node, but the existing ones
+	// are left alone.
+	AsCordoned
+
+	// Like AsCordoned, but the existing placements are also moved away.
+	AsDraining
+)
+
+func (s AdminState) String() string {
+	switch s {
+	case AsNormal:
+		return "AsNormal"
+	case AsCordoned:
+		return "AsCordoned"
+	case AsDraining:
+		return "AsDraining"
+	default:
+		return fmt.Sprintf("AdminState(%d)", s)
+	}
+}
+
+// Cordoned returns whether new placements should be kept off the node.
+func (s AdminState) Cordoned() bool {
+	return s == AsCordoned || s == AsDraining
+}
+
+// NodeAdmin is the persisted admin state of a single node.
+type NodeAdmin struct {
+	NodeID api.NodeID
+	State  AdminState
+
+	// When the state was last changed.
+	Updated time.Time
+}
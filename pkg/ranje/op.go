@@ -0,0 +1,118 @@
+package ranje
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+)
+
+// OpID is the unique identity of an Op. They're assigned in ascending order,
+// starting at one, and never reused.
+type OpID uint64
+
+func (id OpID) String() string {
+	return fmt.Sprintf("%d", id)
+}
+
+type OpKind uint8
+
+const (
+	OpKindUnknown OpKind = iota
+	OpKindMove
+	OpKindSplit
+	OpKindJoin
+)
+
+func (k OpKind) String() string {
+	switch k {
+	case OpKindMove:
+		return "move"
+	case OpKindSplit:
+		return "split"
+	case OpKindJoin:
+		return "join"
+	default:
+		return fmt.Sprintf("OpKind(%d)", k)
+	}
+}
+
+type OpState uint8
+
+const (
+	OsUnknown OpState = iota
+
+	// The op is waiting to be initiated by the next orchestrator tick.
+	OsQueued
+
+	// The op has been initiated, and is waiting for the ranges and placements
+	// involved to reach their new states.
+	OsRunning
+
+	// The op finished. These three states are final.
+	OsSucceeded
+	OsFailed
+	OsCancelled
+)
+
+func (s OpState) String() string {
+	switch s {
+	case OsQueued:
+		return "OsQueued"
+	case OsRunning:
+		return "OsRunning"
+	case OsSucceeded:
+		return "OsSucceeded"
+	case OsFailed:
+		return "OsFailed"
+	case OsCancelled:
+		return "OsCancelled"
+	default:
+		return fmt.Sprintf("OpState(%d)", s)
+	}
+}
+
+// Done returns whether the state is final.
+func (s OpState) Done() bool {
+	return s == OsSucceeded || s == OsFailed || s == OsCancelled
+}
+
+// Op is a move, split, or join which was requested via the Orchestrator API.
+// Unlike keyspace.Operation, which is derived from the state of the ranges,
+// these are persisted separately, so that ops which are still queued survive
+// the controller restarting, and so that callers can find out what happened to
+// an op after the RPC which requested it has returned.
+type Op struct {
+	ID    OpID
+	Kind  OpKind
+	State OpState
+
+	// Why the op failed, if it did.
+	Error string
+
+	// When the op was requested, and when its state last changed.
+	Created time.Time
+	Updated time.Time
+
+	// The range to move or split, or the ranges to join in order.
+	Ranges []api.RangeID
+
+	// The keys to split the range at.
+	Keys []api.Key
+
+	// The nodes to move the range to, place the children of a split on (in
+	// order), or place the child of a join on. Any can be empty.
+	Nodes []api.NodeID
+
+	// The node that a move is from. If not given, this is filled in when the
+	// move starts, so that it can be resumed after the controller restarts.
+	Src api.NodeID
+
+	// Whether cancellation has been requested, i.e. the split or join has been
+	// aborted, but the ranges haven't finished going back to active yet.
+	Cancel bool
+}
+
+func (op *Op) String() string {
+	return fmt.Sprintf("O%s(%s %v, %s)", op.ID, op.Kind, op.Ranges, op.State)
+}
@@ -42,7 +42,7 @@ type Placement struct {
 
 	// failures is updated by the actuator when an action is attempted a few
 	// times but fails. This generally causes the placement to become wedged
-	// until an operator intervenes.
+	// until an operator intervenes, via the ClearFailures or DropPlacement RPCs.
 	failures map[api.Action]bool
 
 	// Not persisted.
@@ -93,6 +93,7 @@ func (p *Placement) ToState(new api.PlacementState) error {
 	p.rang.dirty = true
 
 	log.Printf("R%sP%d: %s -> %s", p.rang.Meta.Ident, p.rang.PlacementIndex(p.NodeID), old, new)
+	p.rang.emit(EvPlacementState, p)
 
 	return nil
 }
@@ -110,6 +111,19 @@ func (p *Placement) Failed(a api.Action) bool {
 	return p.failures[a]
 }
 
+// Failures returns the actions which have been attempted but have failed, in
+// the order that they'd be attempted.
+func (p *Placement) Failures() []api.Action {
+	out := []api.Action{}
+	for _, a := range []api.Action{api.Prepare, api.Activate, api.Deactivate, api.Drop} {
+		if p.Failed(a) {
+			out = append(out, a)
+		}
+	}
+
+	return out
+}
+
 func (p *Placement) SetFailed(a api.Action, value bool) {
 	if p.failures == nil {
 		// lazy init, since most placements don't fail.
@@ -0,0 +1,132 @@
+package ranje
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selector matches nodes by their labels (see api.Remote.Labels). It's a comma
+// separated list of requirements, all of which must be met:
+//
+//	key=value   the label is set to the value
+//	key!=value  the label isn't set to the value (or isn't set at all)
+//	key         the label is set, to anything
+//	!key        the label isn't set
+//
+// The empty selector matches every node.
+type Selector string
+
+type requirement struct {
+	key   string
+	value string
+	op    string // "=", "!=", "", or "!"
+}
+
+func (s Selector) parse() ([]requirement, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	out := []requirement{}
+	for _, tok := range strings.Split(string(s), ",") {
+		tok = strings.TrimSpace(tok)
+		var req requirement
+
+		if k, v, ok := strings.Cut(tok, "!="); ok {
+			req = requirement{key: k, value: v, op: "!="}
+		} else if k, v, ok := strings.Cut(tok, "="); ok {
+			req = requirement{key: k, value: v, op: "="}
+		} else if strings.HasPrefix(tok, "!") {
+			req = requirement{key: tok[1:], op: "!"}
+		} else {
+			req = requirement{key: tok}
+		}
+
+		if req.key == "" {
+			return nil, fmt.Errorf("invalid selector requirement: %q", tok)
+		}
+
+		out = append(out, req)
+	}
+
+	return out, nil
+}
+
+// Validate returns an error if the selector can't be parsed.
+func (s Selector) Validate() error {
+	_, err := s.parse()
+	return err
+}
+
+// Matches returns whether a node with the given labels meets every requirement
+// of the selector. Invalid selectors match nothing.
+func (s Selector) Matches(labels map[string]string) bool {
+	reqs, err := s.parse()
+	if err != nil {
+		return false
+	}
+
+	for _, req := range reqs {
+		v, ok := labels[req.key]
+
+		switch req.op {
+		case "=":
+			if !ok || v != req.value {
+				return false
+			}
+		case "!=":
+			if ok && v == req.value {
+				return false
+			}
+		case "":
+			if !ok {
+				return false
+			}
+		case "!":
+			if ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// PlacementPolicy limits which nodes the placements of a range can be created
+// on, and which are preferred, beyond what the roster already considers. It's
+// only applied when creating placements; existing placements which don't meet
+// it are left alone until they're moved.
+type PlacementPolicy struct {
+
+	// Nodes which don't match this are never chosen, e.g. "pool=tenant-a" to
+	// pin a range to a dedicated pool of nodes.
+	Require Selector `json:",omitempty"`
+
+	// Nodes which match this are preferred over those which don't (affinity).
+	Prefer Selector `json:",omitempty"`
+
+	// Nodes which match this are only chosen if no others are available (anti
+	// affinity), e.g. "canary" to keep a range off canary nodes.
+	Avoid Selector `json:",omitempty"`
+
+	// Whether nodes which have placements of the ranges immediately before and
+	// after this one are preferred, so that adjacent ranges are co-located.
+	ColocateAdjacent bool `json:",omitempty"`
+}
+
+// Validate returns an error if any of the selectors can't be parsed.
+func (pp *PlacementPolicy) Validate() error {
+	if err := pp.Require.Validate(); err != nil {
+		return fmt.Errorf("invalid Require: %w", err)
+	}
+
+	if err := pp.Prefer.Validate(); err != nil {
+		return fmt.Errorf("invalid Prefer: %w", err)
+	}
+
+	if err := pp.Avoid.Validate(); err != nil {
+		return fmt.Errorf("invalid Avoid: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package ranje
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"pool": "a", "canary": "true"}
+
+	for _, ex := range []struct {
+		sel Selector
+		exp bool
+	}{
+		{"", true},
+		{"pool=a", true},
+		{"pool=b", false},
+		{"pool!=b", true},
+		{"pool!=a", false},
+		{"zone!=a", true}, // unset
+		{"canary", true},
+		{"!canary", false},
+		{"!zone", true},
+		{"pool=a, !canary", false},
+		{"pool=a,canary=true", true},
+		{"=a", false}, // invalid
+	} {
+		assert.Equal(t, ex.exp, ex.sel.Matches(labels), "%q", ex.sel)
+	}
+}
+
+func TestPlacementPolicyValidate(t *testing.T) {
+	assert.NoError(t, (&PlacementPolicy{}).Validate())
+	assert.NoError(t, (&PlacementPolicy{Require: "pool=a", Avoid: "canary", ColocateAdjacent: true}).Validate())
+
+	for _, pp := range []PlacementPolicy{
+		{Require: "=a"},
+		{Prefer: "pool=a,"},
+		{Avoid: "!"},
+	} {
+		assert.Error(t, pp.Validate(), "%+v", pp)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/adammck/ranger/pkg/api"
 )
@@ -21,12 +22,31 @@ type Range struct {
 	// TODO: Docs
 	Placements []*Placement
 
+	// The replication config for this range, if it's been overridden. Nil means
+	// use the keyspace default. Child ranges inherit this from their parents.
+	// This is never mutated in place, only replaced, so it can be shared.
+	Replication *ReplicationConfig `json:",omitempty"`
+
+	// The placement policy of this range, if it has one. Child ranges inherit
+	// this from their parents. Like Replication, it's only ever replaced.
+	Policy *PlacementPolicy `json:",omitempty"`
+
+	// When the range entered each of the states it has been in, oldest first.
+	// Ranges created before this was recorded have no history.
+	History []StateChange `json:",omitempty"`
+
+	// Whether the service has acknowledged that it no longer needs this range,
+	// once it's obsolete. See keyspace.Retention.Ack.
+	Acked bool `json:",omitempty"`
+
 	// Guards everything.
 	// TODO: Can we get rid of this and just use the keyspace lock?
 	sync.Mutex
 
 	// Not persisted.
 	onObsolete func()
+	onAbort    func()
+	onEvent    func(Event)
 
 	// Indicates that this range needs persisting before the keyspace lock is
 	// released. We've made changes locally which will be lost if we crash.
@@ -34,13 +54,18 @@ type Range struct {
 	// TODO: Invert so that zero value is the default: needing pesisting.
 	dirty bool
 
-	// The replication config for this range. It's a pointer so it can point
-	// back to the keyspace's default replication config and be updated
-	// together. In theory it can be changed on a per-range basis, but that
-	// isn't well tested as of today.
+	// The default replication config, which is used unless Replication is
+	// set. It's a pointer so it can point back to the keyspace's default
+	// replication config and be updated together.
 	repl *ReplicationConfig
 }
 
+// StateChange records when a range entered a state.
+type StateChange struct {
+	State api.RangeState
+	When  time.Time
+}
+
 func NewRange(rID api.RangeID, repl *ReplicationConfig) *Range {
 	return &Range{
 		Meta: api.Meta{
@@ -73,6 +98,7 @@ func (r *Range) NewPlacement(nodeID api.NodeID) *Placement {
 	}
 
 	r.Placements = append(r.Placements, p)
+	r.emit(EvPlacementCreated, p)
 
 	return p
 }
@@ -90,6 +116,7 @@ func (r *Range) DestroyPlacement(p *Placement) {
 		}
 
 		r.Placements = append(r.Placements[:i], r.Placements[i+1:]...)
+		r.emit(EvPlacementDestroyed, p)
 		return
 	}
 
@@ -139,8 +166,23 @@ func (r *Range) Dirty() bool {
 	return r.dirty
 }
 
+// ReplicationConfig returns a copy of the replication config of this range,
+// which is either its own override or the keyspace default.
+func (r *Range) ReplicationConfig() ReplicationConfig {
+	return *r.config()
+}
+
+// config returns the replication config which currently applies to this range.
+func (r *Range) config() *ReplicationConfig {
+	if r.Replication != nil {
+		return r.Replication
+	}
+
+	return r.repl
+}
+
 func (r *Range) TargetActive() int {
-	return r.repl.TargetActive
+	return r.config().TargetActive
 }
 
 // MinActive returns the minimum number of active placements that this range
@@ -148,27 +190,43 @@ func (r *Range) TargetActive() int {
 // during operations will be allowed to proceed so long as the number of active
 // ranges is not below this number. It can be equal to this number!
 func (r *Range) MinActive() int {
-	return r.repl.MinActive
+	return r.config().MinActive
 }
 
 // MaxActive returns the maximum number of active placements that this range
 // should ever have. Ranger will aim for exactly this number; any fewer, and
 // more should be activated asap.
 func (r *Range) MaxActive() int {
-	return r.repl.MaxActive
+	return r.config().MaxActive
 }
 
 // MinPlacements returns the number of placements (in any state) that this range
 // should have. If it has fewer than this, more should be created asap.
 func (r *Range) MinPlacements() int {
-	return r.repl.MinPlacements
+	return r.config().MinPlacements
 }
 
 // MaxPlacements return the maximum number of placements that this range should
 // ever have. It's fine to be lower, but no more than this will be created, even
 // if that means that an operation can't proceed.
 func (r *Range) MaxPlacements() int {
-	return r.repl.MaxPlacements
+	return r.config().MaxPlacements
+}
+
+// SpreadBy returns the label which placements of this range should be spread
+// across distinct values of, or an empty string if they needn't be.
+func (r *Range) SpreadBy() string {
+	return r.config().SpreadBy
+}
+
+// PlacementPolicy returns a copy of the placement policy of this range, or the
+// zero policy (which allows any node) if it doesn't have one.
+func (r *Range) PlacementPolicy() PlacementPolicy {
+	if r.Policy == nil {
+		return PlacementPolicy{}
+	}
+
+	return *r.Policy
 }
 
 // NumPlacements calls the given func for each placement, and returns the number
@@ -198,22 +256,27 @@ func (r *Range) NumPlacementsInState(s api.PlacementState) int {
 	})
 }
 
+// StateAt returns the state that the range was in at the given time, according
+// to its history. Returns false if the range didn't exist yet at that time, or
+// if it has no history.
+func (r *Range) StateAt(t time.Time) (api.RangeState, bool) {
+	for i := len(r.History) - 1; i >= 0; i-- {
+		if !r.History[i].When.After(t) {
+			return r.History[i].State, true
+		}
+	}
+
+	return api.RsUnknown, false
+}
+
 func (r *Range) ToState(new api.RangeState) error {
 	r.Lock()
 	defer r.Unlock()
 
-	ok := false
 	old := r.State
 
-	for _, t := range RangeStateTransitions {
-		if t.from == old && t.to == new {
-			ok = true
-			break
-		}
-	}
-
-	if !ok {
-		return fmt.Errorf("invalid range state transition: %s -> %s", old.String(), new.String())
+	if err := CanTransitionRange(old, new); err != nil {
+		return err
 	}
 
 	// Special case: When entering RsObsolete, fire the optional callback.
@@ -224,14 +287,56 @@ func (r *Range) ToState(new api.RangeState) error {
 		}
 	}
 
+	// Special case: When a subsuming range goes back to active, the operation
+	// it was part of was aborted. Fire the optional callback, and forget both
+	// of them, since they were for that operation.
+	if old == api.RsSubsuming && new == api.RsActive {
+		if r.onAbort != nil {
+			r.onAbort()
+		}
+
+		r.onObsolete = nil
+		r.onAbort = nil
+	}
+
 	r.State = new
+	r.History = append(r.History, StateChange{State: new, When: time.Now()})
 	r.dirty = true
 
 	log.Printf("R%s: %s -> %s", r.Meta.Ident, old, new)
+	r.emit(EvRangeState, nil)
 
 	return nil
 }
 
+// OnEvent sets a callback to be called whenever the range changes state, or
+// one of its placements is created, destroyed, or changes state. It's called
+// synchronously, sometimes with the range locked, so must not block.
+func (r *Range) OnEvent(f func(Event)) {
+	r.onEvent = f
+}
+
+// emit calls the OnEvent callback, if there is one, with an event of the given
+// type about the range or (if not nil) one of its placements.
+func (r *Range) emit(t EventType, p *Placement) {
+	if r.onEvent == nil {
+		return
+	}
+
+	e := Event{
+		Type:       t,
+		Range:      r.Meta.Ident,
+		RangeState: r.State,
+	}
+
+	if p != nil {
+		e.Node = p.NodeID
+		e.PlacementState = p.StateCurrent
+	}
+
+	r.onEvent(e)
+}
+
 func (r *Range) OnObsolete(f func()) {
 	r.Lock()
 	defer r.Unlock()
@@ -249,6 +354,20 @@ func (r *Range) OnObsolete(f func()) {
 	r.onObsolete = f
 }
 
+// OnAbort sets a callback to be called if the operation that this range is the
+// parent of is aborted, i.e. the range goes back to RsActive rather than on to
+// RsObsolete.
+func (r *Range) OnAbort(f func()) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.onAbort != nil {
+		panic(fmt.Sprintf("range %d has non-nil onAbort callback", r.Meta.Ident))
+	}
+
+	r.onAbort = f
+}
+
 // PlacementByNodeID returns the placement of this range with the given NodeID,
 // or nil if no such range exists. This was added just for testing, and it
 // should not be used elsewhere.
@@ -1,6 +1,8 @@
 package ranje
 
 import (
+	"fmt"
+
 	"github.com/adammck/ranger/pkg/api"
 )
 
@@ -20,5 +22,25 @@ func init() {
 		{api.RsActive, api.RsSubsuming},
 		{api.RsSubsuming, api.RsObsolete},
 		{api.RsSubsuming, api.RsObsolete},
+
+		// Children of a split or join become active when it completes.
+		{api.RsNew, api.RsActive},
+
+		// When a split or join is aborted, the parents go back to being active,
+		// and the children are discarded.
+		{api.RsSubsuming, api.RsActive},
+		{api.RsNew, api.RsAborted},
 	}
 }
+
+// CanTransitionRange returns an error if a range may not move from one state to
+// the other.
+func CanTransitionRange(from, to api.RangeState) error {
+	for _, t := range RangeStateTransitions {
+		if t.from == from && t.to == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid range state transition: %s -> %s", from.String(), to.String())
+}
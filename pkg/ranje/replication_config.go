@@ -1,5 +1,7 @@
 package ranje
 
+import "fmt"
+
 // TODO: Move this into the keyspace package.
 type ReplicationConfig struct {
 
@@ -17,15 +19,64 @@ type ReplicationConfig struct {
 	// to have.
 	MaxActive int
 
-	// TODO
+	// The number of placements (in any state) that a range should have when the
+	// keyspace is stable. Any beyond TargetActive are kept as spares, prepared
+	// but inactive, ready to be activated quickly if an active placement is
+	// lost.
 	MinPlacements int
 
-	// TODO
+	// The maximum number of placements (in any state) that a range will ever be
+	// allowed to have. Operations need room above MinPlacements to prepare new
+	// placements before the old ones are dropped.
 	MaxPlacements int
+
+	// The label (see api.Remote.Labels) which the placements of a range should
+	// be spread across distinct values of, e.g. "zone", so that losing every
+	// node with one value doesn't lose every placement. When there aren't
+	// enough distinct values, placements share them as evenly as possible.
+	// Nodes without the label are treated as all having the same value. Empty
+	// means placements aren't spread.
+	SpreadBy string `json:",omitempty"`
 }
 
-// TODO: Docs
+// Validate returns an error if the config is impossible to satisfy, or would
+// never allow any placement to be moved.
 func (rc *ReplicationConfig) Validate() error {
+	if rc.TargetActive < 1 {
+		return fmt.Errorf("TargetActive must be at least one (got=%d)", rc.TargetActive)
+	}
+
+	if rc.MinActive < 0 {
+		return fmt.Errorf("MinActive must not be negative (got=%d)", rc.MinActive)
+	}
+
+	if rc.MinActive > rc.TargetActive {
+		return fmt.Errorf("MinActive (%d) must not exceed TargetActive (%d)", rc.MinActive, rc.TargetActive)
+	}
+
+	if rc.MaxActive < rc.TargetActive {
+		return fmt.Errorf("MaxActive (%d) must be at least TargetActive (%d)", rc.MaxActive, rc.TargetActive)
+	}
+
+	// Moving an active placement means either briefly having one fewer active
+	// placement, or briefly having one more.
+	if rc.MinActive == rc.MaxActive {
+		return fmt.Errorf("MinActive and MaxActive must differ, or no placement can ever move (both=%d)", rc.MinActive)
+	}
+
+	if rc.MinPlacements < rc.TargetActive {
+		return fmt.Errorf("MinPlacements (%d) must be at least TargetActive (%d)", rc.MinPlacements, rc.TargetActive)
+	}
+
+	if rc.MaxPlacements < rc.MaxActive {
+		return fmt.Errorf("MaxPlacements (%d) must be at least MaxActive (%d)", rc.MaxPlacements, rc.MaxActive)
+	}
+
+	// Every operation prepares a new placement before dropping an old one.
+	if rc.MaxPlacements <= rc.MinPlacements {
+		return fmt.Errorf("MaxPlacements (%d) must exceed MinPlacements (%d), or no placement can ever move", rc.MaxPlacements, rc.MinPlacements)
+	}
+
 	return nil
 }
 
@@ -40,7 +91,7 @@ var R1 = ReplicationConfig{
 	MaxPlacements: 2,
 }
 
-// R1 is an example replication config for high-availability systems which want
+// R3 is an example replication config for high-availability systems which want
 // to maintain three active placements of each key, can tolerate an additional
 // two placements during operations, one of which can be active.
 var R3 = ReplicationConfig{
@@ -0,0 +1,28 @@
+package ranje
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationConfigValidate(t *testing.T) {
+	assert.NoError(t, R1.Validate())
+	assert.NoError(t, R3.Validate())
+
+	// One active placement and one spare.
+	assert.NoError(t, (&ReplicationConfig{1, 0, 1, 2, 3, ""}).Validate())
+
+	for _, rc := range []ReplicationConfig{
+		{0, 0, 0, 0, 0, ""},  // no active placements
+		{1, -1, 1, 1, 2, ""}, // negative MinActive
+		{1, 2, 2, 2, 3, ""},  // MinActive > TargetActive
+		{2, 1, 1, 2, 3, ""},  // MaxActive < TargetActive
+		{1, 1, 1, 1, 2, ""},  // MinActive == MaxActive, so can't move
+		{3, 3, 4, 2, 5, ""},  // MinPlacements < TargetActive
+		{3, 3, 4, 4, 3, ""},  // MaxPlacements < MaxActive
+		{1, 0, 1, 2, 2, ""},  // MaxPlacements == MinPlacements, so can't move
+	} {
+		assert.Error(t, rc.Validate(), "%+v", rc)
+	}
+}
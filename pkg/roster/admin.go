@@ -0,0 +1,85 @@
+package roster
+
+import (
+	"time"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/persister"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// LoadAdmin loads the admin state of nodes from the given persister, and
+// persists every change to it from now on. This must be called (if at all)
+// before the roster is used, or when a standby controller takes over, in which
+// case it replaces whatever was loaded before.
+func (r *Roster) LoadAdmin(pers persister.NodePersister) error {
+	nas, err := pers.GetNodeAdmins()
+	if err != nil {
+		return err
+	}
+
+	r.adminMu.Lock()
+	defer r.adminMu.Unlock()
+
+	r.adminPers = pers
+	r.admin = map[api.NodeID]*ranje.NodeAdmin{}
+	for _, na := range nas {
+		r.admin[na.NodeID] = na
+	}
+
+	return nil
+}
+
+// AdminState returns the admin state of the given node, which is AsNormal
+// unless an operator has changed it. The node needn't be in the roster.
+func (r *Roster) AdminState(nID api.NodeID) ranje.AdminState {
+	r.adminMu.RLock()
+	defer r.adminMu.RUnlock()
+
+	if na, ok := r.admin[nID]; ok {
+		return na.State
+	}
+
+	return ranje.AsNormal
+}
+
+// SetAdminState changes the admin state of the given node, and persists it. The
+// node needn't be in the roster, so that nodes can be cordoned before they are
+// discovered, or while they're down.
+func (r *Roster) SetAdminState(nID api.NodeID, state ranje.AdminState) error {
+	r.adminMu.Lock()
+	defer r.adminMu.Unlock()
+
+	na := &ranje.NodeAdmin{
+		NodeID:  nID,
+		State:   state,
+		Updated: time.Now(),
+	}
+
+	if r.adminPers != nil {
+		var err error
+		if state == ranje.AsNormal {
+			err = r.adminPers.DeleteNodeAdmins([]*ranje.NodeAdmin{na})
+		} else {
+			err = r.adminPers.PutNodeAdmins([]*ranje.NodeAdmin{na})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if state == ranje.AsNormal {
+		delete(r.admin, nID)
+	} else {
+		r.admin[nID] = na
+	}
+
+	return nil
+}
+
+// WantDrain returns whether every placement should be moved off the given
+// node, either because the node asked for that (see Node.WantDrain), or
+// because an operator did.
+func (r *Roster) WantDrain(n *Node) bool {
+	return n.WantDrain() || r.AdminState(n.Ident()) == ranje.AsDraining
+}
@@ -43,6 +43,7 @@ type Node struct {
 
 	// Populated by probeOne
 	wantDrain bool
+	capacity  api.LoadInfo
 	ranges    map[api.RangeID]*api.RangeInfo
 	muRanges  sync.RWMutex
 }
@@ -162,6 +163,35 @@ func (n *Node) Utilization() uint8 {
 	return uint8(l) // lol
 }
 
+// NumRanges returns the number of ranges which this node has, in any state.
+func (n *Node) NumRanges() int {
+	n.muRanges.RLock()
+	defer n.muRanges.RUnlock()
+	return len(n.ranges)
+}
+
+// Load returns the total load of the ranges on this node, as they last
+// reported it. Split points aren't included.
+func (n *Node) Load() api.LoadInfo {
+	n.muRanges.RLock()
+	defer n.muRanges.RUnlock()
+
+	out := api.LoadInfo{}
+	for _, ri := range n.ranges {
+		out = out.Add(ri.Info)
+	}
+
+	return out
+}
+
+// Capacity returns the total load which this node last reported that it can
+// handle. Metrics which are zero are unlimited.
+func (n *Node) Capacity() api.LoadInfo {
+	n.muRanges.RLock()
+	defer n.muRanges.RUnlock()
+	return n.capacity
+}
+
 func (n *Node) WantDrain() bool {
 	// TODO: Use a differet lock for this!
 	n.muRanges.RLock()
@@ -190,6 +220,22 @@ func (n *Node) PlacementFailed(rID api.RangeID, t time.Time) {
 	n.placementFailures = append(n.placementFailures, PlacementFailure{rID: rID, when: t})
 }
 
+// ClearPlacementFailures forgets every failure to place the given range on this
+// node, so that it can be placed here again right away.
+func (n *Node) ClearPlacementFailures(rID api.RangeID) {
+	n.muPF.Lock()
+	defer n.muPF.Unlock()
+
+	out := []PlacementFailure{}
+	for _, pf := range n.placementFailures {
+		if pf.rID != rID {
+			out = append(out, pf)
+		}
+	}
+
+	n.placementFailures = out
+}
+
 func (n *Node) PlacementFailures(rID api.RangeID, after time.Time) int {
 	n.muPF.RLock()
 	defer n.muPF.RUnlock()
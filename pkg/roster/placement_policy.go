@@ -0,0 +1,172 @@
+package roster
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/adammck/ranger/pkg/api"
+	"github.com/adammck/ranger/pkg/ranje"
+)
+
+// Proposal is a new placement which the roster is choosing a node for.
+type Proposal struct {
+
+	// The range which the placement is of, or nil if it doesn't exist yet,
+	// i.e. it's a child of a split or join which hasn't happened yet.
+	Range *ranje.Range
+
+	// The operation which the placement is part of. OpKindUnknown means that
+	// it's not part of any, i.e. it's being created to replace a placement
+	// which was lost, or to reach the minimum number of placements.
+	Kind ranje.OpKind
+
+	// The load which the placement is expected to have once it's active,
+	// estimated from the load reported by the placements it's being created
+	// from. Zero if that isn't known.
+	Load api.LoadInfo
+
+	Constraint ranje.Constraint
+}
+
+// PlacementPolicy decides which node a new placement goes on, out of those
+// which the roster considers eligible, i.e. which satisfy the constraint and
+// aren't drained, cordoned, missing, etc. This is unrelated to the per-range
+// ranje.PlacementPolicy, which is applied via the constraint.
+type PlacementPolicy interface {
+
+	// Filter returns the reason why the given node shouldn't be given the
+	// proposed placement, or an empty string if it can be.
+	Filter(p Proposal, n *Node) string
+
+	// Score returns a score for each of the given nodes, all of which passed
+	// Filter, lower being better. The node with the lowest score is chosen,
+	// with ties broken by ident.
+	Score(p Proposal, nodes []*Node) []float64
+}
+
+// NewPlacementPolicy returns the built-in placement policy with the given
+// name: least-ranges, least-load, or random-of-two.
+func NewPlacementPolicy(name string) (PlacementPolicy, error) {
+	switch name {
+	case "least-ranges":
+		return LeastRanges{}, nil
+	case "least-load":
+		return LeastLoad{}, nil
+	case "random-of-two":
+		return NewRandomOfTwo(rand.Int63()), nil
+	default:
+		return nil, fmt.Errorf("unknown placement policy: %q", name)
+	}
+}
+
+// LeastRanges prefers the nodes with the fewest ranges. This is the default.
+type LeastRanges struct{}
+
+func (LeastRanges) Filter(p Proposal, n *Node) string {
+	return ""
+}
+
+func (LeastRanges) Score(p Proposal, nodes []*Node) []float64 {
+	out := make([]float64, len(nodes))
+	for i, n := range nodes {
+		out[i] = float64(n.NumRanges())
+	}
+
+	return out
+}
+
+// LeastLoad prefers the nodes with the least load, as reported by the ranges
+// on them. If every node has reported its capacity (see Rangelet.SetCapacity),
+// it instead prefers the nodes which would have the lowest utilization once the
+// proposed placement is added, and never chooses nodes which it would put over
+// capacity. Ties, e.g. before any load has been reported, are broken by the
+// number of ranges, like LeastRanges.
+type LeastLoad struct{}
+
+func (LeastLoad) Filter(p Proposal, n *Node) string {
+	u, metric, ok := n.Load().Add(p.Load).Utilization(n.Capacity())
+	if ok && u > 1 {
+		return fmt.Sprintf("over capacity: %s", metric)
+	}
+
+	return ""
+}
+
+func (LeastLoad) Score(p Proposal, nodes []*Node) []float64 {
+	loads := make([]float64, len(nodes))
+	for i, n := range nodes {
+		u, _, ok := n.Load().Add(p.Load).Utilization(n.Capacity())
+		if !ok {
+			loads = nil
+			break
+		}
+		loads[i] = u
+	}
+
+	if loads == nil {
+		loads = make([]float64, len(nodes))
+		for i, n := range nodes {
+			loads[i] = float64(n.Load().Keys)
+		}
+	}
+
+	// Replace each load with its rank, so the number of ranges can be added
+	// as a fraction to break ties without affecting the order otherwise.
+	sorted := make([]float64, len(loads))
+	copy(sorted, loads)
+	sort.Float64s(sorted)
+
+	max := 0
+	for _, n := range nodes {
+		if nr := n.NumRanges(); nr > max {
+			max = nr
+		}
+	}
+
+	out := make([]float64, len(nodes))
+	for i, n := range nodes {
+		rank := sort.SearchFloat64s(sorted, loads[i])
+		out[i] = float64(rank) + float64(n.NumRanges())/float64(max+1)
+	}
+
+	return out
+}
+
+// RandomOfTwo chooses two of the nodes at random, and prefers the one with the
+// fewest ranges. This spreads out placements which are chosen at around the
+// same time, before the nodes have reported them, unlike LeastRanges which
+// would choose the same node for all of them.
+type RandomOfTwo struct {
+	rand *rand.Rand
+	mu   sync.Mutex
+}
+
+func NewRandomOfTwo(seed int64) *RandomOfTwo {
+	return &RandomOfTwo{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (*RandomOfTwo) Filter(p Proposal, n *Node) string {
+	return ""
+}
+
+func (rt *RandomOfTwo) Score(p Proposal, nodes []*Node) []float64 {
+	out := make([]float64, len(nodes))
+	for i := range out {
+		out[i] = math.Inf(1)
+	}
+
+	rt.mu.Lock()
+	perm := rt.rand.Perm(len(nodes))
+	rt.mu.Unlock()
+
+	for i := 0; i < 2 && i < len(perm); i++ {
+		out[perm[i]] = float64(nodes[perm[i]].NumRanges())
+	}
+
+	return out
+}
@@ -11,9 +11,11 @@ import (
 
 	"github.com/adammck/ranger/pkg/api"
 	"github.com/adammck/ranger/pkg/discovery"
+	"github.com/adammck/ranger/pkg/persister"
 	"github.com/adammck/ranger/pkg/proto/conv"
 	pb "github.com/adammck/ranger/pkg/proto/gen"
 	"github.com/adammck/ranger/pkg/ranje"
+	"github.com/adammck/ranger/pkg/util/wake"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -46,21 +48,49 @@ type Roster struct {
 
 	// To be stubbed when testing.
 	NodeConnFactory func(ctx context.Context, remote api.Remote) (*grpc.ClientConn, error)
+
+	// Chooses between the nodes which are eligible for a new placement. Nil
+	// means LeastRanges. Set it after construction.
+	Policy PlacementPolicy
+
+	// The admin state of nodes which aren't AsNormal, keyed by NodeID, since
+	// it outlives the Node. See SetAdminState.
+	admin     map[api.NodeID]*ranje.NodeAdmin
+	adminPers persister.NodePersister
+	adminMu   sync.RWMutex
+
+	// Notified to probe as soon as possible, rather than waiting for the ticker
+	// given to Run. See Wake.
+	sig *wake.Signal
 }
 
+// DefaultService is the service name which nodes are discovered by, unless
+// another one is given to NewForService.
+const DefaultService = "node"
+
 func New(disc discovery.Discoverer, add, remove func(rem *api.Remote), info chan NodeInfo) *Roster {
+	return NewForService(disc, DefaultService, add, remove, info)
+}
+
+// NewForService returns a roster of the nodes which are registered with the
+// given service name. This allows several sets of nodes, each serving its own
+// keyspace, to share a discovery backend.
+func NewForService(disc discovery.Discoverer, svcName string, add, remove func(rem *api.Remote), info chan NodeInfo) *Roster {
 	return &Roster{
 		Nodes:  make(map[api.NodeID]*Node),
-		disc:   disc.Discover("node", nil, nil),
+		disc:   disc.Discover(svcName, nil, nil),
 		add:    add,
 		remove: remove,
 		info:   info, // currently never closed
+		admin:  map[api.NodeID]*ranje.NodeAdmin{},
 
 		// Defaults to production implementation.
 		// Patch it after construction for tests.
 		NodeConnFactory: nodeConnFactory,
 
 		NodeExpireDuration: 1 * time.Minute,
+
+		sig: wake.New(),
 	}
 }
 
@@ -104,6 +134,28 @@ func (ros *Roster) NodeByIdent(nID api.NodeID) (*Node, error) {
 	return nil, ErrNodeNotFound{nID}
 }
 
+// RangeLoad returns the load of the given range, as the highest of each metric
+// reported by any of its placements, or zero if none have reported any. Split
+// points aren't included.
+func (ros *Roster) RangeLoad(r *ranje.Range) api.LoadInfo {
+	ros.RLock()
+	defer ros.RUnlock()
+
+	out := api.LoadInfo{}
+	for _, p := range r.Placements {
+		n, ok := ros.Nodes[p.NodeID]
+		if !ok {
+			continue
+		}
+
+		if ri, ok := n.Get(r.Meta.Ident); ok {
+			out = out.Max(ri.Info)
+		}
+	}
+
+	return out
+}
+
 // Location is returned by the Locate method. Don't use it for anything else.
 type Location struct {
 	Node api.NodeID
@@ -187,6 +239,9 @@ func (ros *Roster) Discover() {
 			}
 		}
 
+		// Labels (e.g. the zone) can change while the node is running.
+		n.Remote.Labels = r.Labels
+
 		n.whenLastSeen = time.Now()
 	}
 }
@@ -290,6 +345,8 @@ func (ros *Roster) probeOne(ctx context.Context, n *Node) error {
 
 	n.muRanges.Lock()
 	n.wantDrain = res.WantDrain
+	n.capacity = conv.LoadInfoFromProto(res.Capacity)
+	n.capacity.Splits = nil
 	n.ranges = ranges
 	n.muRanges.Unlock()
 
@@ -312,27 +369,59 @@ func (r *Roster) Tick() {
 	r.expire()
 }
 
+// Run calls Tick immediately, and then again whenever the roster is woken (see
+// Wake), or the given ticker fires, forever. Unlike the orchestrator and the
+// actuator, the ticker is what drives the roster, since probing is how it finds
+// out that anything has changed.
+//
 // TODO: Need some way to gracefully stop! Have to close the info channel to
 //       stop the reconciler.
 func (r *Roster) Run(t *time.Ticker) {
-	for ; true; <-t.C {
-		r.Tick()
-	}
+	wake.Loop(r.sig, t, r.Tick)
+}
+
+// Wake makes the next probe happen as soon as possible, rather than waiting for
+// the ticker given to Run. Call it when the state of a node is in doubt, e.g.
+// because a command sent to it failed, but might have been applied anyway.
+func (r *Roster) Wake() {
+	r.sig.Notify()
 }
 
 // Candidate returns the NodeIdent of a node which could accept the given range.
-//
-// TODO: Instead of an actual range, this should take a "pseudo-range" which is
-//       either a single range (wanting to split) and split point, or two ranges
-//       (wanting to join). Or I guess just a wrapper around a single (moving)
-//       range. From these, we can estimate how much capacity the candidate
-//       node(s) will need, allowing us to find candidates before actually
-//       performing splits and joins.
-//
+// See Choose, which takes more context about the placement.
 func (r *Roster) Candidate(rng *ranje.Range, c ranje.Constraint) (api.NodeID, error) {
+	nID, _, err := r.Explain(rng, c)
+	return nID, err
+}
+
+// Exclusion is a node which Explain didn't consider as a candidate, and why.
+type Exclusion struct {
+	NodeID api.NodeID
+	Reason string
+}
+
+// Explain is like Candidate, but also returns the nodes which were excluded,
+// and why, ordered by NodeID. If the constraint names a specific node, no other
+// node is considered, so none are excluded.
+func (r *Roster) Explain(rng *ranje.Range, c ranje.Constraint) (api.NodeID, []Exclusion, error) {
+	return r.Choose(Proposal{Range: rng, Constraint: c})
+}
+
+// Choose returns the node which the proposed placement should go on, along with
+// the nodes which were excluded, like Explain. Nodes are excluded by the
+// constraint, their state, and the placement policy's filter. The survivors are
+// ranked by the soft rules of the constraint, and the policy scores the best of
+// them.
+func (r *Roster) Choose(p Proposal) (api.NodeID, []Exclusion, error) {
 	r.RLock()
 	defer r.RUnlock()
 
+	rng, c := p.Range, p.Constraint
+	pol := r.Policy
+	if pol == nil {
+		pol = LeastRanges{}
+	}
+
 	// Build a list of nodes.
 	// TODO: Just store them this way!
 
@@ -344,6 +433,11 @@ func (r *Roster) Candidate(rng *ranje.Range, c ranje.Constraint) (api.NodeID, er
 		i += 1
 	}
 
+	// Sort them, so the exclusions are in order.
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Ident() < nodes[j].Ident()
+	})
+
 	// Build a list of indices of candidate nodes.
 	candidates := []int{}
 
@@ -362,7 +456,7 @@ func (r *Roster) Candidate(rng *ranje.Range, c ranje.Constraint) (api.NodeID, er
 
 		// Specific NodeID was given, but no such node was found.
 		if !found {
-			return "", fmt.Errorf("no such node: %v", c.NodeID)
+			return "", nil, fmt.Errorf("no such node: %v", c.NodeID)
 		}
 	}
 
@@ -377,48 +471,79 @@ func (r *Roster) Candidate(rng *ranje.Range, c ranje.Constraint) (api.NodeID, er
 		rID = rng.Meta.Ident
 	}
 
+	out := []Exclusion{}
+	exclude := func(n *Node, reason string) {
+		out = append(out, Exclusion{NodeID: n.Ident(), Reason: reason})
+	}
+
 	// Exclude a node if:
 	//
 	// 1. It has been explicitly excluded.
 	//
-	// 2. It already has this range.
+	// 2. It doesn't match the selector which the constraint requires.
+	//
+	// 3. It already has this range.
 	//
-	// 3. It's drained, i.e. it doesn't want any more ranges. It's probably
-	//    shutting down.
+	// 4. It's drained, i.e. it doesn't want any more ranges. It's probably
+	//    shutting down. Or it has been cordoned (or drained) by an operator.
 	//
-	// 4. It's missing, i.e. hasn't responded to our probes in a while. It might
+	// 5. It's missing, i.e. hasn't responded to our probes in a while. It might
 	//    still come back, but let's avoid it anyway.
 	//
-	// 5. This range has failed to place on this node within the past minute.
+	// 6. This range has failed to place on this node within the past minute.
+	//
+	// 7. The placement policy filters it out.
 	//
 	for i := range nodes {
 		if _, ok := excluded[nodes[i].Ident()]; ok {
+			exclude(nodes[i], "excluded by constraint")
 			if c.NodeID != "" {
-				return "", fmt.Errorf("node is excluded: %v", nodes[i].Ident())
+				return "", out, fmt.Errorf("node is excluded: %v", nodes[i].Ident())
+			}
+
+			continue
+		}
+
+		if !c.Require.Matches(nodes[i].Remote.Labels) {
+			exclude(nodes[i], "doesn't match selector")
+			if c.NodeID != "" {
+				return "", out, fmt.Errorf("node doesn't match selector: %v", nodes[i].Ident())
 			}
 
 			continue
 		}
 
 		if rID != api.ZeroRange && nodes[i].HasRange(rng.Meta.Ident) {
+			exclude(nodes[i], "already has range")
 			if c.NodeID != "" {
-				return "", fmt.Errorf("node already has range: %v", c.NodeID)
+				return "", out, fmt.Errorf("node already has range: %v", c.NodeID)
 			}
 
 			continue
 		}
 
 		if nodes[i].WantDrain() {
+			exclude(nodes[i], "wants drain")
 			if c.NodeID != "" {
-				return "", fmt.Errorf("node wants drain: %v", nodes[i].Ident())
+				return "", out, fmt.Errorf("node wants drain: %v", nodes[i].Ident())
+			}
+
+			continue
+		}
+
+		if r.AdminState(nodes[i].Ident()).Cordoned() {
+			exclude(nodes[i], "cordoned")
+			if c.NodeID != "" {
+				return "", out, fmt.Errorf("node is cordoned: %v", nodes[i].Ident())
 			}
 
 			continue
 		}
 
 		if nodes[i].IsMissing(r.NodeExpireDuration, time.Now()) {
+			exclude(nodes[i], "missing")
 			if c.NodeID != "" {
-				return "", fmt.Errorf("node is missing: %v", nodes[i].Ident())
+				return "", out, fmt.Errorf("node is missing: %v", nodes[i].Ident())
 			}
 
 			continue
@@ -427,34 +552,112 @@ func (r *Roster) Candidate(rng *ranje.Range, c ranje.Constraint) (api.NodeID, er
 		// node has recent failed placements
 		if nodes[i].PlacementFailures(rID, time.Now().Add(-1*time.Minute)) >= 1 {
 			if c.NodeID == "" {
+				exclude(nodes[i], "recent placement failure")
 				continue
 			}
 		}
 
+		if reason := pol.Filter(p, nodes[i]); reason != "" {
+			exclude(nodes[i], reason)
+			if c.NodeID != "" {
+				return "", out, fmt.Errorf("node rejected by placement policy: %v (%s)", nodes[i].Ident(), reason)
+			}
+
+			continue
+		}
+
 		candidates = append(candidates, i)
 	}
 
 	if len(candidates) == 0 {
-		return "", fmt.Errorf("no candidates available (rID=%v, c=%v)", rID, c)
+		return "", out, fmt.Errorf("no candidates available (rID=%v, c=%v)", rID, c)
 	}
 
-	// Pick the node with lowest utilization. For nodes with the exact same
-	// utilization, pick the node with the lowest (lexicographically) ident.
-	// TODO: This doesn't take into account ranges which are on the way to that
-	//       node, and is generally totally insufficient.
+	// If placements are to be spread across failure domains, count how many
+	// peers are in the same one as each candidate.
+	peers := r.peerDomains(c)
 
-	sort.Slice(candidates, func(i, j int) bool {
-		ci := nodes[candidates[i]]
-		cj := nodes[candidates[j]]
+	affinity := map[api.NodeID]bool{}
+	for _, nID := range c.Affinity {
+		affinity[nID] = true
+	}
+
+	// Rank the candidates by the soft rules of the constraint: nodes which
+	// aren't avoided, then which are preferred, then with the fewest peers in
+	// their failure domain, then which have an affinity. Lower is better.
+	ranks := map[api.NodeID][4]int{}
+	for _, i := range candidates {
+		n := nodes[i]
+		var rank [4]int
 
-		ciu := ci.Utilization()
-		cju := cj.Utilization()
-		if ciu != cju {
-			return ciu < cju
+		if c.Avoid != "" && c.Avoid.Matches(n.Remote.Labels) {
+			rank[0] = 1
 		}
 
-		return ci.Ident() < cj.Ident()
+		if c.Prefer != "" && !c.Prefer.Matches(n.Remote.Labels) {
+			rank[1] = 1
+		}
+
+		if c.SpreadBy != "" {
+			rank[2] = peers[n.Remote.Label(c.SpreadBy)]
+		}
+
+		if !affinity[n.Ident()] {
+			rank[3] = 1
+		}
+
+		ranks[n.Ident()] = rank
+	}
+
+	// Candidates are already ordered by ident, so keep that among equals.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri := ranks[nodes[candidates[i]].Ident()]
+		rj := ranks[nodes[candidates[j]].Ident()]
+		for k := range ri {
+			if ri[k] != rj[k] {
+				return ri[k] < rj[k]
+			}
+		}
+		return false
 	})
 
-	return nodes[candidates[0]].Ident(), nil
+	// The placement policy chooses between the best of them.
+	best := []*Node{}
+	for _, i := range candidates {
+		n := nodes[i]
+		if ranks[n.Ident()] != ranks[nodes[candidates[0]].Ident()] {
+			break
+		}
+		best = append(best, n)
+	}
+
+	// TODO: This doesn't take into account ranges which are on the way to the
+	//       nodes, which the policies can't see until the nodes report them.
+	scores := pol.Score(p, best)
+	chosen := 0
+	for i := range best {
+		if scores[i] < scores[chosen] {
+			chosen = i
+		}
+	}
+
+	return best[chosen].Ident(), out, nil
+}
+
+// peerDomains returns the number of peers in the given constraint with each
+// value of its SpreadBy label. Peers which aren't in the roster are ignored,
+// since we don't know where they are. Caller must hold the RWMutex.
+func (r *Roster) peerDomains(c ranje.Constraint) map[string]int {
+	out := map[string]int{}
+	if c.SpreadBy == "" {
+		return out
+	}
+
+	for _, nID := range c.Peers {
+		if n, ok := r.Nodes[nID]; ok {
+			out[n.Remote.Label(c.SpreadBy)] += 1
+		}
+	}
+
+	return out
 }
@@ -129,6 +129,308 @@ func (ts *RosterSuite) TestCandidateByNodeID() {
 	}
 }
 
+func (ts *RosterSuite) TestCandidateAdminState() {
+	aRem := api.Remote{
+		Ident: "test-aaa",
+		Host:  "host-aaa",
+		Port:  1,
+	}
+
+	bRem := api.Remote{
+		Ident: "test-bbb",
+		Host:  "host-bbb",
+		Port:  1,
+	}
+
+	ts.nodes.Add(ts.ctx, aRem, nil)
+	ts.nodes.Add(ts.ctx, bRem, nil)
+
+	ts.Init()
+	ts.rost.Tick()
+
+	// Both nodes are empty, so aaa is preferred, until it's cordoned.
+	ts.NoError(ts.rost.SetAdminState("test-aaa", ranje.AsCordoned))
+
+	nID, err := ts.rost.Candidate(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+	}
+
+	_, err = ts.rost.Candidate(ts.r, ranje.Constraint{NodeID: "test-aaa"})
+	if ts.Error(err) {
+		ts.Equal("node is cordoned: test-aaa", err.Error())
+	}
+
+	// Draining nodes are also cordoned, but additionally want drain.
+	ts.NoError(ts.rost.SetAdminState("test-bbb", ranje.AsDraining))
+	ts.False(ts.rost.WantDrain(ts.rost.Nodes["test-aaa"]))
+	ts.True(ts.rost.WantDrain(ts.rost.Nodes["test-bbb"]))
+
+	_, err = ts.rost.Candidate(ts.r, ranje.AnyNode)
+	ts.Error(err)
+
+	ts.NoError(ts.rost.SetAdminState("test-aaa", ranje.AsNormal))
+	ts.Equal(ranje.AsNormal, ts.rost.AdminState("test-aaa"))
+
+	nID, err = ts.rost.Candidate(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-aaa"), nID)
+	}
+}
+
+func (ts *RosterSuite) TestExplain() {
+	for _, s := range []string{"aaa", "bbb", "ccc"} {
+		ts.nodes.Add(ts.ctx, api.Remote{
+			Ident: "test-" + s,
+			Host:  "host-" + s,
+			Port:  1,
+		}, nil)
+	}
+
+	ts.Init()
+	ts.rost.Tick()
+
+	ts.NoError(ts.rost.SetAdminState("test-ccc", ranje.AsCordoned))
+
+	nID, ex, err := ts.rost.Explain(ts.r, ranje.Constraint{Not: []api.NodeID{"test-aaa"}})
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+		ts.Equal([]Exclusion{
+			{NodeID: "test-aaa", Reason: "excluded by constraint"},
+			{NodeID: "test-ccc", Reason: "cordoned"},
+		}, ex)
+	}
+
+	// When a specific node is requested, only it is considered.
+	_, ex, err = ts.rost.Explain(ts.r, ranje.Constraint{NodeID: "test-ccc"})
+	if ts.Error(err) {
+		ts.Equal("node is cordoned: test-ccc", err.Error())
+		ts.Equal([]Exclusion{{NodeID: "test-ccc", Reason: "cordoned"}}, ex)
+	}
+}
+
+func (ts *RosterSuite) TestCandidateSpreadBy() {
+	for s, zone := range map[string]string{"aaa": "a", "bbb": "a", "ccc": "b"} {
+		ts.nodes.Add(ts.ctx, api.Remote{
+			Ident:  "test-" + s,
+			Host:   "host-" + s,
+			Port:   1,
+			Labels: map[string]string{"zone": zone},
+		}, nil)
+	}
+
+	ts.Init()
+	ts.rost.Tick()
+
+	// Without spreading, bbb is preferred over ccc, because of its ident.
+	c := ranje.Constraint{Not: []api.NodeID{"test-aaa"}}
+	nID, err := ts.rost.Candidate(ts.r, c)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+	}
+
+	// But ccc is in another zone than the peer on aaa.
+	c = ranje.Constraint{SpreadBy: "zone"}.WithNot("test-aaa").WithPeer("test-aaa")
+	nID, err = ts.rost.Candidate(ts.r, c)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-ccc"), nID)
+	}
+
+	// Once both zones have a peer, they're equally good, so it's bbb again.
+	c = c.WithNot("test-ccc").WithPeer("test-ccc")
+	nID, err = ts.rost.Candidate(ts.r, c)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+	}
+}
+
+func (ts *RosterSuite) TestCandidatePolicy() {
+	for s, labels := range map[string]map[string]string{
+		"aaa": {"pool": "shared"},
+		"bbb": {"pool": "a", "canary": "true"},
+		"ccc": {"pool": "a"},
+		"ddd": {"pool": "a"},
+	} {
+		ts.nodes.Add(ts.ctx, api.Remote{
+			Ident:  "test-" + s,
+			Host:   "host-" + s,
+			Port:   1,
+			Labels: labels,
+		}, nil)
+	}
+
+	ts.Init()
+	ts.rost.Tick()
+
+	// Only nodes in the pool can be chosen, even explicitly.
+	c := ranje.Constraint{Require: "pool=a"}
+	nID, ex, err := ts.rost.Explain(ts.r, c)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+		ts.Equal([]Exclusion{{NodeID: "test-aaa", Reason: "doesn't match selector"}}, ex)
+	}
+
+	_, err = ts.rost.Candidate(ts.r, c.WithNodeID("test-aaa"))
+	if ts.Error(err) {
+		ts.Equal("node doesn't match selector: test-aaa", err.Error())
+	}
+
+	// Canaries are avoided, but not excluded.
+	c.Avoid = "canary"
+	nID, err = ts.rost.Candidate(ts.r, c)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-ccc"), nID)
+	}
+
+	nID, err = ts.rost.Candidate(ts.r, c.WithNot("test-ccc").WithNot("test-ddd"))
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+	}
+
+	// Without the requirement, aaa would be chosen, unless another is preferred.
+	nID, err = ts.rost.Candidate(ts.r, ranje.Constraint{Prefer: "canary"})
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+	}
+
+	// Nodes with an affinity are preferred, after the selectors.
+	nID, err = ts.rost.Candidate(ts.r, c.WithAffinity("test-ddd"))
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-ddd"), nID)
+	}
+
+	nID, err = ts.rost.Candidate(ts.r, c.WithAffinity("test-bbb"))
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-ccc"), nID)
+	}
+}
+
+// rejectNode is a placement policy which filters out a single node.
+type rejectNode struct {
+	LeastRanges
+	nID api.NodeID
+}
+
+func (rn rejectNode) Filter(p Proposal, n *Node) string {
+	if n.Ident() == rn.nID {
+		return "rejected"
+	}
+	return ""
+}
+
+func (ts *RosterSuite) TestPlacementPolicy() {
+	infos := func(keys ...int) map[api.RangeID]*api.RangeInfo {
+		out := map[api.RangeID]*api.RangeInfo{}
+		for i, k := range keys {
+			rID := api.RangeID(100 + i)
+			out[rID] = &api.RangeInfo{
+				Meta:  api.Meta{Ident: rID},
+				State: api.NsActive,
+				Info:  api.LoadInfo{Keys: k},
+			}
+		}
+		return out
+	}
+
+	// aaa has the most ranges, but the least load.
+	ts.nodes.Add(ts.ctx, api.Remote{Ident: "test-aaa", Host: "host-aaa", Port: 1}, infos(10, 10, 10))
+	ts.nodes.Add(ts.ctx, api.Remote{Ident: "test-bbb", Host: "host-bbb", Port: 1}, infos(500, 500))
+	ts.nodes.Add(ts.ctx, api.Remote{Ident: "test-ccc", Host: "host-ccc", Port: 1}, infos(1000))
+
+	ts.Init()
+	ts.rost.Tick()
+
+	nID, err := ts.rost.Candidate(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-ccc"), nID)
+	}
+
+	ts.rost.Policy = LeastLoad{}
+	nID, err = ts.rost.Candidate(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-aaa"), nID)
+	}
+
+	// The node with the most ranges is never chosen, since it's always
+	// compared to a node with fewer.
+	ts.rost.Policy = NewRandomOfTwo(1)
+	seen := map[api.NodeID]bool{}
+	for i := 0; i < 50; i++ {
+		nID, err = ts.rost.Candidate(ts.r, ranje.AnyNode)
+		if ts.NoError(err) {
+			seen[nID] = true
+		}
+	}
+	ts.Equal(map[api.NodeID]bool{"test-bbb": true, "test-ccc": true}, seen)
+
+	// Nodes filtered out by the policy are excluded, even if requested.
+	ts.rost.Policy = rejectNode{nID: "test-ccc"}
+	nID, ex, err := ts.rost.Explain(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+		ts.Equal([]Exclusion{{NodeID: "test-ccc", Reason: "rejected"}}, ex)
+	}
+
+	_, err = ts.rost.Candidate(ts.r, ranje.Constraint{NodeID: "test-ccc"})
+	if ts.Error(err) {
+		ts.Equal("node rejected by placement policy: test-ccc (rejected)", err.Error())
+	}
+
+	_, err = NewPlacementPolicy("most-ranges")
+	ts.Error(err)
+}
+
+func (ts *RosterSuite) TestLeastLoadCapacity() {
+	infos := func(keys, bytes int) map[api.RangeID]*api.RangeInfo {
+		return map[api.RangeID]*api.RangeInfo{
+			100: {
+				Meta:  api.Meta{Ident: 100},
+				State: api.NsActive,
+				Info:  api.LoadInfo{Keys: keys, Bytes: bytes, QPS: 5},
+			},
+		}
+	}
+
+	ts.nodes.Add(ts.ctx, api.Remote{Ident: "test-aaa", Host: "host-aaa", Port: 1}, infos(30, 900))
+	ts.nodes.Add(ts.ctx, api.Remote{Ident: "test-bbb", Host: "host-bbb", Port: 1}, infos(20, 500))
+	ts.nodes.Add(ts.ctx, api.Remote{Ident: "test-ccc", Host: "host-ccc", Port: 1}, infos(10, 150))
+	ts.nodes.Get("test-aaa").SetCapacity(api.LoadInfo{Bytes: 1000})
+	ts.nodes.Get("test-bbb").SetCapacity(api.LoadInfo{Bytes: 2000, QPS: 10})
+	ts.nodes.Get("test-ccc").SetCapacity(api.LoadInfo{Bytes: 200})
+
+	ts.Init()
+	ts.rost.Tick()
+	ts.rost.Policy = LeastLoad{}
+
+	ts.Equal(api.LoadInfo{Bytes: 2000, QPS: 10}, ts.rost.Nodes["test-bbb"].Capacity())
+	ts.Equal(api.LoadInfo{Keys: 20, Bytes: 500, QPS: 5}, ts.rost.Nodes["test-bbb"].Load())
+
+	// Utilization is 0.9, 0.5 (of QPS), and 0.75.
+	nID, err := ts.rost.Candidate(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+	}
+
+	// Nodes which the proposed placement would put over capacity are excluded,
+	// and the others compared as if it was already placed on them.
+	nID, ex, err := ts.rost.Choose(Proposal{Range: ts.r, Load: api.LoadInfo{Bytes: 200, QPS: 4}})
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-bbb"), nID)
+		ts.Equal([]Exclusion{
+			{NodeID: "test-aaa", Reason: "over capacity: bytes"},
+			{NodeID: "test-ccc", Reason: "over capacity: bytes"},
+		}, ex)
+	}
+
+	// If any node hasn't reported its capacity, only keys are compared.
+	ts.nodes.Get("test-ccc").SetCapacity(api.LoadInfo{})
+	ts.rost.Tick()
+	nID, err = ts.rost.Candidate(ts.r, ranje.AnyNode)
+	if ts.NoError(err) {
+		ts.Equal(api.NodeID("test-ccc"), nID)
+	}
+}
+
 func (ts *RosterSuite) TestProbeOne() {
 
 	rem := api.Remote{
@@ -70,9 +70,7 @@ func NewTestNode(ctx context.Context, addr string, rangeInfos map[api.RangeID]*a
 	// GetLoadInfo.
 	li := map[api.RangeID]api.LoadInfo{}
 	for _, ri := range rangeInfos {
-		li[ri.Meta.Ident] = api.LoadInfo{
-			Keys: int(ri.Info.Keys),
-		}
+		li[ri.Meta.Ident] = ri.Info
 	}
 
 	n := &TestNode{
@@ -302,6 +300,10 @@ func (n *TestNode) SetWantDrain(b bool) {
 	n.rglt.SetWantDrain(b)
 }
 
+func (n *TestNode) SetCapacity(li api.LoadInfo) {
+	n.rglt.SetCapacity(li)
+}
+
 func (n *TestNode) SetStrictTransitions(b bool) {
 	n.strictTransitions = b
 }
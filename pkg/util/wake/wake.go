@@ -0,0 +1,48 @@
+// Package wake lets the controller's loops (the orchestrator, actuator, and
+// roster) run as soon as something happens which they might need to react to,
+// rather than waiting for their next tick.
+package wake
+
+import "time"
+
+// Signal is a notification that something has changed. Any number of calls to
+// Notify before the waiter receives from C result in a single wakeup, so it's
+// cheap to notify on every change, and the waiter never falls behind.
+type Signal struct {
+	ch chan struct{}
+}
+
+func New() *Signal {
+	return &Signal{
+		ch: make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes the waiter, if it's not already due to wake. It never blocks,
+// so can be called with locks held.
+func (s *Signal) Notify() {
+	select {
+	case s.ch <- struct{}{}:
+	default:
+	}
+}
+
+// C returns the channel which receives a value after Notify is called.
+func (s *Signal) C() <-chan struct{} {
+	return s.ch
+}
+
+// Loop calls f immediately, and then again whenever the signal is notified or
+// the ticker fires, forever. The ticker is a safety net, in case something
+// changes without anyone calling Notify, so can be quite slow. Notifications
+// which arrive while f is running cause it to be called again once it returns.
+func Loop(s *Signal, t *time.Ticker, f func()) {
+	for {
+		f()
+
+		select {
+		case <-s.C():
+		case <-t.C:
+		}
+	}
+}
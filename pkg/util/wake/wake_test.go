@@ -0,0 +1,53 @@
+package wake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignal(t *testing.T) {
+	s := New()
+
+	// Nothing to receive yet.
+	select {
+	case <-s.C():
+		t.Fatal("received before Notify")
+	default:
+	}
+
+	// Several notifications are coalesced into one, and don't block.
+	s.Notify()
+	s.Notify()
+	s.Notify()
+
+	<-s.C()
+	select {
+	case <-s.C():
+		t.Fatal("received twice")
+	default:
+	}
+}
+
+func TestLoop(t *testing.T) {
+	s := New()
+	calls := make(chan struct{})
+
+	// Never fires during the test.
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	go Loop(s, ticker, func() {
+		calls <- struct{}{}
+	})
+
+	// Called once immediately, and then once per notification.
+	<-calls
+	s.Notify()
+	<-calls
+
+	select {
+	case <-calls:
+		t.Fatal("called without notification")
+	case <-time.After(10 * time.Millisecond):
+	}
+}